@@ -150,6 +150,33 @@ func (c *Client) net2LocalServerInfo(net *serverInfoResponseNet, local *GetServe
 	return nil
 }
 
+// GetCAInfo returns generic CA information
+func (c *Client) GetCAInfo(req *api.GetCAInfoRequest) (*GetServerInfoResponse, error) {
+	err := c.Init()
+	if err != nil {
+		return nil, err
+	}
+	body, err := util.Marshal(req, "GetCAInfo")
+	if err != nil {
+		return nil, err
+	}
+	cainforeq, err := c.newPost("cainfo", body)
+	if err != nil {
+		return nil, err
+	}
+	netSI := &serverInfoResponseNet{}
+	err = c.SendReq(cainforeq, netSI)
+	if err != nil {
+		return nil, err
+	}
+	localSI := &GetServerInfoResponse{}
+	err = c.net2LocalServerInfo(netSI, localSI)
+	if err != nil {
+		return nil, err
+	}
+	return localSI, nil
+}
+
 // EnrollmentResponse is the response from Client.Enroll and Identity.Reenroll
 type EnrollmentResponse struct {
 	Identity   *Identity
@@ -305,6 +332,45 @@ func (c *Client) newPost(endpoint string, reqBody []byte) (*http.Request, error)
 	return req, nil
 }
 
+// newGet create a new get request
+func (c *Client) newGet(endpoint string) (*http.Request, error) {
+	curl, err := c.getURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", curl, bytes.NewReader([]byte{}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed creating GET request for %s", curl)
+	}
+	return req, nil
+}
+
+// newPut create a new put request
+func (c *Client) newPut(endpoint string, reqBody []byte) (*http.Request, error) {
+	curl, err := c.getURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("PUT", curl, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed creating PUT request for %s", curl)
+	}
+	return req, nil
+}
+
+// newDelete create a new delete request
+func (c *Client) newDelete(endpoint string) (*http.Request, error) {
+	curl, err := c.getURL(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("DELETE", curl, bytes.NewReader([]byte{}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed creating DELETE request for %s", curl)
+	}
+	return req, nil
+}
+
 // SendReq sends a request to the fabric-ca-server and fills in the result
 func (c *Client) SendReq(req *http.Request, result interface{}) (err error) {
 
@@ -371,6 +437,34 @@ func (c *Client) SendReq(req *http.Request, result interface{}) (err error) {
 	return nil
 }
 
+// StreamResponse reads the response as it comes back from the server
+func (c *Client) StreamResponse(req *http.Request, stream string, cb func(*json.Decoder) error) (err error) {
+
+	reqStr := util.HTTPRequestToString(req)
+	log.Debugf("Sending request\n%s", reqStr)
+
+	err = c.Init()
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s failure of request: %s", req.Method, reqStr)
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(resp.Body)
+	results, err := streamJSONArray(dec, stream, cb)
+	if err != nil {
+		return err
+	}
+	if !results {
+		log.Debugf("No results returned")
+	}
+	return nil
+}
+
 func (c *Client) getURL(endpoint string) (string, error) {
 	nurl, err := NormalizeURL(c.Config.URL)
 	if err != nil {
@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. 2017 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+                 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+/*
+Notice: This file has been modified for Hyperledger Fabric SDK Go usage.
+Please review third_party pinning scripts and patches for more details.
+*/
+
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cfsslapi "github.com/cloudflare/cfssl/api"
+	"github.com/pkg/errors"
+)
+
+// searchElement defines the JSON arrays for which to search
+type searchElement struct {
+	Path string
+	CB   func(*json.Decoder) error
+}
+
+// streamJSONArray searches the JSON stream for an array matching 'path'.
+// For each element of this array, it streams one element at a time.
+func streamJSONArray(decoder *json.Decoder, path string, cb func(*json.Decoder) error) (bool, error) {
+	ses := []searchElement{
+		searchElement{Path: path, CB: cb},
+		searchElement{Path: "errors", CB: errCB},
+	}
+	return stream(decoder, ses)
+}
+
+func stream(decoder *json.Decoder, ses []searchElement) (bool, error) {
+	js := &jsonStream{decoder: decoder, cbs: ses}
+	err := js.stream()
+	return js.gotResults, err
+}
+
+// jsonStream holds the state of the stream
+type jsonStream struct {
+	decoder    *json.Decoder
+	cbs        []searchElement
+	path       []string
+	gotResults bool
+}
+
+// stream streams the JSON value at the current path
+func (js *jsonStream) stream() error {
+	t, err := js.getToken()
+	if err != nil {
+		return err
+	}
+	if _, ok := t.(json.Delim); !ok {
+		return nil
+	}
+	path := strings.Join(js.path, ".")
+	se := js.getSearchElement(path)
+	d := fmt.Sprintf("%s", t)
+	switch d {
+	case "[":
+		if se != nil {
+			for js.decoder.More() {
+				err = se.CB(js.decoder)
+				if err != nil {
+					return err
+				}
+				js.gotResults = true
+			}
+		} else {
+			for js.decoder.More() {
+				err = js.stream()
+				if err != nil {
+					return err
+				}
+			}
+		}
+		err = js.getExpectedDelim("]")
+		if err != nil {
+			return err
+		}
+	case "{":
+		if se != nil {
+			return errors.Errorf("Expecting array for value of '%s'", path)
+		}
+		for {
+			name, err := js.getNextName()
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				return nil
+			}
+			js.path = append(js.path, name)
+			err = js.stream()
+			if err != nil {
+				return err
+			}
+			js.path = js.path[:len(js.path)-1]
+		}
+	}
+	return nil
+}
+
+// getNextName returns the next name of an object, or an empty string at the end of the object
+func (js *jsonStream) getNextName() (string, error) {
+	token, err := js.getToken()
+	if err != nil {
+		return "", err
+	}
+	switch v := token.(type) {
+	case string:
+		return v, nil
+	case json.Delim:
+		d := fmt.Sprintf("%s", v)
+		if d == "}" {
+			return "", nil
+		}
+		return "", errors.Errorf("Expecting '}' delimiter but found '%s'", d)
+	default:
+		return "", errors.Errorf("Expecting string or delimiter but found '%s'", v)
+	}
+}
+
+func (js *jsonStream) getExpectedDelim(expected string) error {
+	token, err := js.getToken()
+	if err != nil {
+		return err
+	}
+	d, ok := token.(json.Delim)
+	if !ok || fmt.Sprintf("%s", d) != expected {
+		return errors.Errorf("Expecting '%s' delimiter but found '%v'", expected, token)
+	}
+	return nil
+}
+
+func (js *jsonStream) getSearchElement(path string) *searchElement {
+	for i := range js.cbs {
+		if js.cbs[i].Path == path {
+			return &js.cbs[i]
+		}
+	}
+	return nil
+}
+
+func (js *jsonStream) getToken() (interface{}, error) {
+	token, err := js.decoder.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get JSON token")
+	}
+	return token, nil
+}
+
+func errCB(decoder *json.Decoder) error {
+	errMsg := &cfsslapi.ResponseMessage{}
+	err := decoder.Decode(errMsg)
+	if err != nil {
+		return errors.Errorf("Invalid JSON error format: %s", err)
+	}
+	return errors.Errorf("Response from server: Error Code: %d - %s", errMsg.Code, errMsg.Message)
+}
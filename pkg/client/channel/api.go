@@ -31,6 +31,15 @@ type requestOptions struct {
 // RequestOption func for each Opts argument
 type RequestOption func(ctx context.Client, opts *requestOptions) error
 
+// batchOptions holds the options of ExecuteBatch
+type batchOptions struct {
+	Parallelism    int             //maximum number of requests of a batch that are endorsed concurrently
+	RequestOptions []RequestOption //options applied to the requests of a batch
+}
+
+// BatchOption func for each batchOptions argument
+type BatchOption func(opts *batchOptions) error
+
 // Request contains the parameters to query and execute an invocation transaction
 type Request struct {
 	ChaincodeID  string
@@ -50,6 +59,12 @@ type Response struct {
 	Responses       []*fab.TransactionProposalResponse
 }
 
+// BatchResult contains the response, or the error, of one of the requests of a batch
+type BatchResult struct {
+	Response Response
+	Error    error
+}
+
 //WithTargets encapsulates ProposalProcessors to Option
 func WithTargets(targets ...fab.Peer) RequestOption {
 	return func(ctx context.Client, o *requestOptions) error {
@@ -119,3 +134,42 @@ func WithParentContext(parentContext reqContext.Context) RequestOption {
 		return nil
 	}
 }
+
+// WithParallelism sets the maximum number of requests of a batch that are endorsed concurrently
+func WithParallelism(parallelism int) BatchOption {
+	return func(o *batchOptions) error {
+		if parallelism < 0 {
+			return errors.New("parallelism must not be negative")
+		}
+		o.Parallelism = parallelism
+		return nil
+	}
+}
+
+// WithRequestOptions sets the options, such as WithTargets or WithTimeout, applied to the requests of a batch
+func WithRequestOptions(options ...RequestOption) BatchOption {
+	return func(o *batchOptions) error {
+		o.RequestOptions = append(o.RequestOptions, options...)
+		return nil
+	}
+}
+
+// eventOptions contains the options for event registration
+type eventOptions struct {
+	replay    bool
+	fromBlock uint64
+}
+
+// EventOption func for each event registration option
+type EventOption func(opts *eventOptions) error
+
+// WithReplayFromBlock delivers the matching events that were committed from the given block onwards,
+// followed by new events as they are committed. Events are delivered in order and without duplicates.
+// Replay requires the Deliver service and permission to receive full blocks on the channel.
+func WithReplayFromBlock(blockNum uint64) EventOption {
+	return func(o *eventOptions) error {
+		o.replay = true
+		o.fromBlock = blockNum
+		return nil
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	reqContext "context"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// defaultBatchParallelism is the number of requests of a batch endorsed concurrently when not set by WithParallelism
+const defaultBatchParallelism = 10
+
+// batchTx tracks a request of a batch through endorsement, ordering and commit
+type batchTx struct {
+	index          int
+	requestContext *invoke.RequestContext
+	tx             *fab.Transaction
+	txStatus       *invoke.TxStatusRegistration
+}
+
+// ExecuteBatch prepares and executes several independent transactions using requests and optional options provided.
+// The requests are endorsed concurrently, up to the limit set by WithParallelism, and the endorsed transactions
+// are sent to the orderer together. The result of each request is returned in the order of the requests. An error
+// is returned only if the batch as a whole could not be processed; the failure of a single request is reported in
+// its result. Options set with WithRequestOptions apply to every request, and the Execute timeout applies to the
+// batch as a whole.
+func (cc *Client) ExecuteBatch(requests []Request, options ...BatchOption) ([]BatchResult, error) {
+	bo := batchOptions{Parallelism: defaultBatchParallelism}
+	for _, option := range options {
+		if err := option(&bo); err != nil {
+			return nil, errors.WithMessage(err, "option failed")
+		}
+	}
+
+	optsWithTimeout, err := cc.addDefaultTimeout(cc.context, core.Execute, bo.RequestOptions...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "option failed")
+	}
+
+	txnOpts, err := cc.prepareOptsFromOptions(cc.context, optsWithTimeout...)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := cc.createReqContext(&txnOpts)
+	defer cancel()
+
+	clientContext, err := cc.prepareClientContext(reqCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(requests))
+
+	endorsed := cc.endorseBatch(reqCtx, clientContext, requests, txnOpts, bo.Parallelism, results)
+	submitted := cc.submitBatch(clientContext, endorsed, results)
+	cc.awaitBatch(reqCtx, submitted, results)
+
+	return results, nil
+}
+
+// endorseBatch endorses the requests of a batch and creates their transactions. Requests that fail are
+// recorded in results; the others are returned in request order.
+func (cc *Client) endorseBatch(reqCtx reqContext.Context, clientContext *invoke.ClientContext, requests []Request, o requestOptions, parallelism int, results []BatchResult) []*batchTx {
+	if parallelism == 0 {
+		parallelism = defaultBatchParallelism
+	}
+
+	batch := make([]*batchTx, len(requests))
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, request := range requests {
+		if request.ChaincodeID == "" || request.Fcn == "" {
+			results[i].Error = errors.New("ChaincodeID and Fcn are required")
+			continue
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, request Request) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			requestContext := cc.prepareRequestContext(reqCtx, request, o)
+			tx, err := cc.endorse(requestContext, clientContext, o)
+			results[i].Response = Response(requestContext.Response)
+			if err != nil {
+				results[i].Error = err
+				return
+			}
+			batch[i] = &batchTx{index: i, requestContext: requestContext, tx: tx}
+		}(i, request)
+	}
+	wg.Wait()
+
+	var endorsed []*batchTx
+	for _, btx := range batch {
+		if btx != nil {
+			endorsed = append(endorsed, btx)
+		}
+	}
+	return endorsed
+}
+
+// endorse collects and validates the endorsements of a single request, retrying as configured, and creates its transaction
+func (cc *Client) endorse(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext, o requestOptions) (*fab.Transaction, error) {
+	handler := invoke.NewQueryHandler()
+	for {
+		handler.Handle(requestContext, clientContext)
+		if !cc.resolveRetry(requestContext, o) {
+			break
+		}
+	}
+	if requestContext.Error != nil {
+		return nil, requestContext.Error
+	}
+
+	tx, err := clientContext.Transactor.CreateTransaction(fab.TransactionRequest{
+		Proposal:          requestContext.Response.Proposal,
+		ProposalResponses: requestContext.Response.Responses,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "CreateTransaction failed")
+	}
+	return tx, nil
+}
+
+// submitBatch registers for the TxStatus events of the endorsed transactions and sends them to the orderer
+// together. Transactions that could not be sent are recorded in results; the others are returned.
+func (cc *Client) submitBatch(clientContext *invoke.ClientContext, endorsed []*batchTx, results []BatchResult) []*batchTx {
+	var registered []*batchTx
+	var txs []*fab.Transaction
+	for _, btx := range endorsed {
+		reg, notifier, err := cc.eventService.RegisterTxStatusEvent(string(btx.tx.Proposal.TxnID))
+		if err != nil {
+			results[btx.index].Error = errors.Wrap(err, "error registering for TxStatus event")
+			continue
+		}
+		btx.txStatus = &invoke.TxStatusRegistration{Registration: reg, Notifier: notifier}
+		registered = append(registered, btx)
+		txs = append(txs, btx.tx)
+	}
+
+	if len(txs) == 0 {
+		return nil
+	}
+
+	_, errs := sendTransactionBatch(clientContext.Transactor, txs)
+
+	var submitted []*batchTx
+	for n, btx := range registered {
+		if errs[n] != nil {
+			cc.eventService.Unregister(btx.txStatus.Registration)
+			results[btx.index].Error = errors.Wrap(errs[n], "CreateAndSendTransaction failed")
+			continue
+		}
+		submitted = append(submitted, btx)
+	}
+	return submitted
+}
+
+// awaitBatch waits for the TxStatus events of the submitted transactions and records the outcome in results
+func (cc *Client) awaitBatch(reqCtx reqContext.Context, submitted []*batchTx, results []BatchResult) {
+	for _, btx := range submitted {
+		result := &results[btx.index]
+
+		select {
+		case txStatus := <-btx.txStatus.Notifier:
+			result.Response.TxValidationCode = txStatus.TxValidationCode
+			if txStatus.TxValidationCode != pb.TxValidationCode_VALID {
+				result.Error = status.New(status.EventServerStatus, int32(txStatus.TxValidationCode), "received invalid transaction", nil)
+			}
+		case <-reqCtx.Done():
+			result.Error = status.New(status.ClientStatus, status.Timeout.ToInt32(),
+				"request timed out or been cancelled", nil)
+		}
+
+		cc.eventService.Unregister(btx.txStatus.Registration)
+	}
+}
+
+// sendTransactionBatch sends the transactions together if the transactor supports it, otherwise one at a time
+func sendTransactionBatch(transactor fab.Transactor, txs []*fab.Transaction) ([]*fab.TransactionResponse, []error) {
+	if batchSender, ok := transactor.(fab.BatchSender); ok {
+		return batchSender.SendTransactionBatch(txs)
+	}
+
+	responses := make([]*fab.TransactionResponse, len(txs))
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		responses[i], errs[i] = transactor.SendTransaction(tx)
+	}
+	return responses, errs
+}
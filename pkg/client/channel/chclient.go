@@ -36,8 +36,13 @@ type Client struct {
 	membership   fab.ChannelMembership
 	eventService fab.EventService
 	greylist     *greylist.Filter
+
+	replayClientProvider replayClientProvider
 }
 
+// replayClientProvider creates the event client used for replaying events from the given block
+type replayClientProvider func(ctx context.Client, chConfig fab.ChannelCfg, fromBlock uint64) (fab.EventClient, error)
+
 // ClientOption describes a functional parameter for the New constructor
 type ClientOption func(*Client) error
 
@@ -70,6 +75,8 @@ func New(channelProvider context.ChannelProvider, opts ...ClientOption) (*Client
 		eventService: eventService,
 		greylist:     greylistProvider,
 		context:      channelContext,
+
+		replayClientProvider: newReplayClient,
 	}
 
 	for _, param := range opts {
@@ -102,12 +109,40 @@ func (cc *Client) Execute(request Request, options ...RequestOption) (Response,
 	return cc.InvokeHandler(invoke.NewExecuteHandler(), request, optsWithTimeout...)
 }
 
+// ExecuteAsync prepares and submits a transaction using request and optional options provided.
+// Unlike Execute, it returns as soon as the transaction has been sent to the orderer. The returned
+// Commit is used to wait for the transaction to be committed, or to stop waiting for it.
+func (cc *Client) ExecuteAsync(request Request, options ...RequestOption) (*Commit, error) {
+	optsWithTimeout, err := cc.addDefaultTimeout(cc.context, core.Execute, options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "option failed")
+	}
+
+	requestContext, err := cc.invokeHandler(invoke.NewExecuteAsyncHandler(), request, cc.releaseTxStatus, optsWithTimeout...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCommit(Response(requestContext.Response), cc.eventService, requestContext.TxStatus), nil
+}
+
 //InvokeHandler invokes handler using request and options provided
 func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options ...RequestOption) (Response, error) {
+	requestContext, err := cc.invokeHandler(handler, request, nil, options...)
+	if requestContext == nil {
+		return Response{}, err
+	}
+	return Response(requestContext.Response), err
+}
+
+//invokeHandler invokes handler using request and options provided and returns the request context once the
+//handler has completed. If the request times out first, release (if provided) is called with the request
+//context once the handler eventually completes.
+func (cc *Client) invokeHandler(handler invoke.Handler, request Request, release func(*invoke.RequestContext), options ...RequestOption) (*invoke.RequestContext, error) {
 	//Read execute tx options
 	txnOpts, err := cc.prepareOptsFromOptions(cc.context, options...)
 	if err != nil {
-		return Response{}, err
+		return nil, err
 	}
 
 	reqCtx, cancel := cc.createReqContext(&txnOpts)
@@ -116,10 +151,10 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 	//Prepare context objects for handler
 	requestContext, clientContext, err := cc.prepareHandlerContexts(reqCtx, request, txnOpts)
 	if err != nil {
-		return Response{}, err
+		return nil, err
 	}
 
-	complete := make(chan bool)
+	complete := make(chan bool, 1)
 
 	go func() {
 	handleInvoke:
@@ -132,13 +167,26 @@ func (cc *Client) InvokeHandler(handler invoke.Handler, request Request, options
 	}()
 	select {
 	case <-complete:
-		return Response(requestContext.Response), requestContext.Error
+		return requestContext, requestContext.Error
 	case <-reqCtx.Done():
-		return Response{}, status.New(status.ClientStatus, status.Timeout.ToInt32(),
+		if release != nil {
+			go func() {
+				<-complete
+				release(requestContext)
+			}()
+		}
+		return nil, status.New(status.ClientStatus, status.Timeout.ToInt32(),
 			"request timed out or been cancelled", nil)
 	}
 }
 
+//releaseTxStatus unregisters the TxStatus event of a transaction that nobody is going to wait for
+func (cc *Client) releaseTxStatus(requestContext *invoke.RequestContext) {
+	if requestContext.TxStatus != nil {
+		cc.eventService.Unregister(requestContext.TxStatus.Registration)
+	}
+}
+
 func (cc *Client) resolveRetry(ctx *invoke.RequestContext, o requestOptions) bool {
 	errs, ok := ctx.Error.(multi.Errors)
 	if !ok {
@@ -187,15 +235,40 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 		return nil, nil, errors.New("ChaincodeID and Fcn are required")
 	}
 
+	clientContext, err := cc.prepareClientContext(reqCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cc.prepareRequestContext(reqCtx, request, o), clientContext, nil
+}
+
+//prepareClientContext prepares the client context object for handlers
+func (cc *Client) prepareClientContext(reqCtx reqContext.Context) (*invoke.ClientContext, error) {
 	chConfig, err := cc.context.ChannelService().ChannelConfig()
 	if err != nil {
-		return nil, nil, errors.WithMessage(err, "failed to retrieve channel config")
+		return nil, errors.WithMessage(err, "failed to retrieve channel config")
 	}
 	transactor, err := cc.context.InfraProvider().CreateChannelTransactor(reqCtx, chConfig)
 	if err != nil {
-		return nil, nil, errors.WithMessage(err, "failed to create transactor")
+		return nil, errors.WithMessage(err, "failed to create transactor")
 	}
 
+	clientContext := &invoke.ClientContext{
+		CryptoSuite:   cc.context.CryptoSuite(),
+		Selection:     cc.context.SelectionService(),
+		Discovery:     cc.context.DiscoveryService(),
+		Membership:    cc.membership,
+		Transactor:    transactor,
+		EventService:  cc.eventService,
+		ChannelConfig: chConfig,
+	}
+
+	return clientContext, nil
+}
+
+//prepareRequestContext prepares the request context object for handlers
+func (cc *Client) prepareRequestContext(reqCtx reqContext.Context, request Request, o requestOptions) *invoke.RequestContext {
 	peerFilter := func(peer fab.Peer) bool {
 		if !cc.greylist.Accept(peer) {
 			return false
@@ -206,15 +279,7 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 		return true
 	}
 
-	clientContext := &invoke.ClientContext{
-		Selection:    cc.context.SelectionService(),
-		Discovery:    cc.context.DiscoveryService(),
-		Membership:   cc.membership,
-		Transactor:   transactor,
-		EventService: cc.eventService,
-	}
-
-	requestContext := &invoke.RequestContext{
+	return &invoke.RequestContext{
 		Request:         invoke.Request(request),
 		Opts:            invoke.Opts(o),
 		Response:        invoke.Response{},
@@ -222,8 +287,6 @@ func (cc *Client) prepareHandlerContexts(reqCtx reqContext.Context, request Requ
 		Ctx:             reqCtx,
 		SelectionFilter: peerFilter,
 	}
-
-	return requestContext, clientContext, nil
 }
 
 //prepareOptsFromOptions Reads apitxn.Opts from Option array
@@ -258,12 +321,29 @@ func (cc *Client) addDefaultTimeout(ctx context.Client, timeOutType core.Timeout
 // RegisterChaincodeEvent registers chain code event
 // @param {chan bool} channel which receives event details when the event is complete
 // @returns {object} object handle that should be used to unregister
-func (cc *Client) RegisterChaincodeEvent(chainCodeID string, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
-	// Register callback for CE
-	return cc.eventService.RegisterChaincodeEvent(chainCodeID, eventFilter)
+// By default only events committed after registration are received. Use WithReplayFromBlock in order
+// to also receive the events that were committed from a given block onwards.
+func (cc *Client) RegisterChaincodeEvent(chainCodeID string, eventFilter string, options ...EventOption) (fab.Registration, <-chan *fab.CCEvent, error) {
+	opts := eventOptions{}
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return nil, nil, errors.WithMessage(err, "option failed")
+		}
+	}
+
+	if !opts.replay {
+		// Register callback for CE
+		return cc.eventService.RegisterChaincodeEvent(chainCodeID, eventFilter)
+	}
+
+	return cc.registerChaincodeEventWithReplay(chainCodeID, eventFilter, opts.fromBlock)
 }
 
 // UnregisterChaincodeEvent removes chain code event registration
 func (cc *Client) UnregisterChaincodeEvent(registration fab.Registration) {
+	if reg, ok := registration.(*replayRegistration); ok {
+		reg.unregister()
+		return
+	}
 	cc.eventService.Unregister(registration)
 }
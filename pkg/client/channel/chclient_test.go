@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	reqContext "context"
 	"fmt"
 	"testing"
 	"time"
@@ -375,6 +376,242 @@ func TestTransactionValidationError(t *testing.T) {
 	assert.EqualValues(t, validationCode, status.ToTransactionValidationCode(statusError.Code))
 }
 
+func TestExecuteAsync(t *testing.T) {
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Payload = []byte("test")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	commit, err := chClient.ExecuteAsync(Request{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}})
+	assert.Nil(t, err, "ExecuteAsync failed")
+
+	txStatusReg := <-mockEventService.TxStatusRegCh
+	assert.EqualValues(t, commit.TransactionID(), txStatusReg.TxID, "expected registration for submitted transaction")
+
+	ctx, cancel := reqContext.WithTimeout(reqContext.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = commit.Wait(ctx)
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error got %+v", err)
+	assert.EqualValues(t, status.Timeout, status.ToSDKStatusCode(statusError.Code))
+
+	go func() {
+		txStatusReg.Eventch <- &fab.TxStatusEvent{TxID: txStatusReg.TxID, TxValidationCode: pb.TxValidationCode_VALID}
+	}()
+
+	response, err := commit.Wait(reqContext.Background())
+	assert.Nil(t, err, "expected commit to succeed")
+	assert.Equal(t, pb.TxValidationCode_VALID, response.TxValidationCode)
+	assert.Equal(t, []byte("test"), response.Payload)
+
+	// Subsequent calls return the same result
+	response, err = commit.Wait(reqContext.Background())
+	assert.Nil(t, err, "expected commit to succeed")
+	assert.Equal(t, pb.TxValidationCode_VALID, response.TxValidationCode)
+}
+
+func TestExecuteAsyncValidationError(t *testing.T) {
+	validationCode := pb.TxValidationCode_MVCC_READ_CONFLICT
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	commit, err := chClient.ExecuteAsync(Request{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}})
+	assert.Nil(t, err, "ExecuteAsync failed")
+
+	txStatusReg := <-mockEventService.TxStatusRegCh
+	go func() {
+		txStatusReg.Eventch <- &fab.TxStatusEvent{TxID: txStatusReg.TxID, TxValidationCode: validationCode}
+	}()
+
+	response, err := commit.Wait(reqContext.Background())
+	assert.Equal(t, validationCode, response.TxValidationCode)
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error got %+v", err)
+	assert.EqualValues(t, validationCode, status.ToTransactionValidationCode(statusError.Code))
+}
+
+func TestExecuteAsyncCancel(t *testing.T) {
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	commit, err := chClient.ExecuteAsync(Request{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}})
+	assert.Nil(t, err, "ExecuteAsync failed")
+	<-mockEventService.TxStatusRegCh
+
+	commit.Cancel()
+	_, err = commit.Wait(reqContext.Background())
+	assert.NotNil(t, err, "expected error waiting on cancelled commit")
+}
+
+func TestExecuteAsyncOrdererError(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testOrderer1 := fcmocks.NewMockOrderer("", make(chan *fab.SignedEnvelope))
+	chClient := setupChannelClientWithNodes([]fab.Peer{testPeer1}, []fab.Orderer{testOrderer1}, t)
+	chClient.eventService = fcmocks.NewMockEventService()
+
+	testOrderer1.EnqueueSendBroadcastError(status.New(status.OrdererClientStatus,
+		status.ConnectionFailed.ToInt32(), "test error", nil))
+
+	commit, err := chClient.ExecuteAsync(Request{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}})
+	assert.Nil(t, commit, "expected no commit on orderer error")
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "Expected status error got %+v", err)
+	assert.Equal(t, status.OrdererClientStatus, statusError.Group)
+}
+
+func TestExecuteBatch(t *testing.T) {
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Payload = []byte("test")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	requests := []Request{
+		{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}},
+		{ChaincodeID: "test", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}},
+		{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("b"), []byte("a"), []byte("1")}},
+		{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("c"), []byte("1")}},
+	}
+
+	go func() {
+		for i := 0; i < len(requests)-1; i++ {
+			select {
+			case txStatusReg := <-mockEventService.TxStatusRegCh:
+				go func() {
+					txStatusReg.Eventch <- &fab.TxStatusEvent{TxID: txStatusReg.TxID, TxValidationCode: pb.TxValidationCode_VALID}
+				}()
+			case <-time.After(time.Second * 5):
+				panic("Timed out waiting for execute batch to register event callback")
+			}
+		}
+	}()
+
+	results, err := chClient.ExecuteBatch(requests, WithParallelism(2), WithRequestOptions(WithTargets(testPeer1)))
+	assert.Nil(t, err, "ExecuteBatch failed")
+	assert.Len(t, results, len(requests))
+
+	assert.Nil(t, results[0].Error)
+	assert.NotEmpty(t, results[0].Response.TransactionID)
+	assert.Equal(t, []byte("test"), results[0].Response.Payload)
+	assert.Equal(t, pb.TxValidationCode_VALID, results[0].Response.TxValidationCode)
+
+	assert.NotNil(t, results[1].Error, "expected error for request without function")
+
+	assert.Nil(t, results[2].Error)
+	assert.Nil(t, results[3].Error)
+	assert.NotEqual(t, results[0].Response.TransactionID, results[3].Response.TransactionID)
+
+	_, err = chClient.ExecuteBatch(requests, WithParallelism(-1))
+	assert.NotNil(t, err, "expected error for negative parallelism")
+}
+
+func TestExecuteBatchValidationError(t *testing.T) {
+	validationCode := pb.TxValidationCode_MVCC_READ_CONFLICT
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	go func() {
+		select {
+		case txStatusReg := <-mockEventService.TxStatusRegCh:
+			txStatusReg.Eventch <- &fab.TxStatusEvent{TxID: txStatusReg.TxID, TxValidationCode: validationCode}
+		case <-time.After(time.Second * 5):
+			panic("Timed out waiting for execute batch to register event callback")
+		}
+	}()
+
+	results, err := chClient.ExecuteBatch([]Request{{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}})
+	assert.Nil(t, err, "ExecuteBatch failed")
+	assert.Equal(t, validationCode, results[0].Response.TxValidationCode)
+	statusError, ok := status.FromError(results[0].Error)
+	assert.True(t, ok, "Expected status error got %+v", results[0].Error)
+	assert.EqualValues(t, validationCode, status.ToTransactionValidationCode(statusError.Code))
+}
+
+func TestExecuteBatchOrdererError(t *testing.T) {
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testOrderer1 := fcmocks.NewMockOrderer("", make(chan *fab.SignedEnvelope))
+	chClient := setupChannelClientWithNodes([]fab.Peer{testPeer1}, []fab.Orderer{testOrderer1}, t)
+	mockEventService := fcmocks.NewMockEventService()
+	chClient.eventService = mockEventService
+
+	testOrderer1.EnqueueSendBroadcastError(status.New(status.OrdererClientStatus,
+		status.ConnectionFailed.ToInt32(), "test error", nil))
+
+	results, err := chClient.ExecuteBatch([]Request{{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}})
+	assert.Nil(t, err, "ExecuteBatch failed")
+	<-mockEventService.TxStatusRegCh
+
+	statusError, ok := status.FromError(results[0].Error)
+	assert.True(t, ok, "Expected status error got %+v", results[0].Error)
+	assert.Equal(t, status.OrdererClientStatus, statusError.Group)
+}
+
+func TestOfflineSigning(t *testing.T) {
+	mockEventService := fcmocks.NewMockEventService()
+	testPeer1 := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	testPeer1.Payload = []byte("test")
+
+	chClient := setupChannelClient([]fab.Peer{testPeer1}, t)
+	chClient.eventService = mockEventService
+
+	ctx := chClient.context
+	creator, err := ctx.Serialize()
+	assert.Nil(t, err, "serialize failed")
+
+	_, err = chClient.PrepareProposal(Request{ChaincodeID: "test"}, creator)
+	assert.NotNil(t, err, "expected error for missing Fcn")
+
+	proposal, err := chClient.PrepareProposal(Request{ChaincodeID: "test", Fcn: "invoke",
+		Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}, creator)
+	assert.Nil(t, err, "PrepareProposal failed")
+
+	_, err = chClient.EndorseSignedProposal(proposal, nil)
+	assert.NotNil(t, err, "expected error for missing signature")
+
+	signature, err := ctx.SigningManager().Sign(proposal.Bytes, ctx.PrivateKey())
+	assert.Nil(t, err, "sign proposal failed")
+
+	response, err := chClient.EndorseSignedProposal(proposal, signature)
+	assert.Nil(t, err, "EndorseSignedProposal failed")
+	assert.Equal(t, []byte("test"), response.Payload)
+	assert.Equal(t, proposal.Proposal.TxnID, response.TransactionID)
+
+	tx, err := chClient.PrepareTransaction(response)
+	assert.Nil(t, err, "PrepareTransaction failed")
+	assert.Equal(t, response.TransactionID, tx.TransactionID)
+
+	signature, err = ctx.SigningManager().Sign(tx.Bytes, ctx.PrivateKey())
+	assert.Nil(t, err, "sign transaction failed")
+
+	go func() {
+		txStatusReg := <-mockEventService.TxStatusRegCh
+		txStatusReg.Eventch <- &fab.TxStatusEvent{TxID: txStatusReg.TxID, TxValidationCode: pb.TxValidationCode_VALID}
+	}()
+
+	response, err = chClient.CommitSignedTransaction(tx, signature)
+	assert.Nil(t, err, "CommitSignedTransaction failed")
+	assert.Equal(t, pb.TxValidationCode_VALID, response.TxValidationCode)
+	assert.Equal(t, tx.TransactionID, response.TransactionID)
+}
+
 func TestExecuteTxWithRetries(t *testing.T) {
 	testStatus := status.New(status.EndorserClientStatus, status.ConnectionFailed.ToInt32(), "test", nil)
 	testResp := []byte("test")
@@ -484,6 +721,82 @@ func TestDiscoveryGreylist(t *testing.T) {
 
 }
 
+func TestRegisterChaincodeEventWithReplay(t *testing.T) {
+	chClient := setupChannelClient(nil, t)
+
+	var eventClient *mockReplayClient
+	var fromBlock uint64
+	chClient.replayClientProvider = func(ctx context.Client, chConfig fab.ChannelCfg, blockNum uint64) (fab.EventClient, error) {
+		assert.Equal(t, channelID, chConfig.ID())
+		fromBlock = blockNum
+		eventClient = newMockReplayClient()
+		return eventClient, nil
+	}
+
+	reg, eventch, err := chClient.RegisterChaincodeEvent("example_cc", "transfer.*", WithReplayFromBlock(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), fromBlock)
+	assert.True(t, eventClient.connected, "expecting replay client to be connected")
+
+	eventClient.ccEventCh <- &fab.CCEvent{ChaincodeID: "example_cc", EventName: "transfer", BlockNumber: 1000}
+	select {
+	case event := <-eventch:
+		assert.Equal(t, uint64(1000), event.BlockNumber)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for replayed event")
+	}
+
+	chClient.UnregisterChaincodeEvent(reg)
+	chClient.UnregisterChaincodeEvent(reg)
+	assert.Equal(t, 1, eventClient.closeCalls, "expecting replay client to be closed once")
+
+	// Connection error
+	chClient.replayClientProvider = func(ctx context.Client, chConfig fab.ChannelCfg, blockNum uint64) (fab.EventClient, error) {
+		eventClient = newMockReplayClient()
+		eventClient.connectErr = errors.New("connect failed")
+		return eventClient, nil
+	}
+	_, _, err = chClient.RegisterChaincodeEvent("example_cc", "transfer.*", WithReplayFromBlock(1000))
+	assert.Error(t, err)
+	assert.Equal(t, 1, eventClient.closeCalls, "expecting replay client to be closed after connection error")
+}
+
+type mockReplayClient struct {
+	*fcmocks.MockEventService
+	ccEventCh  chan *fab.CCEvent
+	connectErr error
+	connected  bool
+	closeCalls int
+}
+
+func newMockReplayClient() *mockReplayClient {
+	return &mockReplayClient{
+		MockEventService: fcmocks.NewMockEventService(),
+		ccEventCh:        make(chan *fab.CCEvent, 1),
+	}
+}
+
+func (c *mockReplayClient) RegisterChaincodeEvent(ccID, eventFilter string) (fab.Registration, <-chan *fab.CCEvent, error) {
+	return struct{}{}, c.ccEventCh, nil
+}
+
+func (c *mockReplayClient) Connect() error {
+	if c.connectErr != nil {
+		return c.connectErr
+	}
+	c.connected = true
+	return nil
+}
+
+func (c *mockReplayClient) Close() {
+	c.closeCalls++
+}
+
+func (c *mockReplayClient) CloseIfIdle() bool {
+	c.Close()
+	return true
+}
+
 func setupTestChannelService(ctx context.Client, orderers []fab.Orderer) (fab.ChannelService, error) {
 	chProvider, err := fcmocks.NewMockChannelProvider(ctx)
 	if err != nil {
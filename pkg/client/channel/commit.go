@@ -0,0 +1,89 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	reqContext "context"
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// Commit tracks a transaction that has been submitted to the orderer by ExecuteAsync.
+//
+// A Commit holds an event registration until the transaction status has been received
+// or Cancel is called, so every Commit should eventually be waited on or cancelled.
+type Commit struct {
+	response     Response
+	eventService fab.EventService
+	registration fab.Registration
+	notifier     <-chan *fab.TxStatusEvent
+	once         sync.Once
+	done         chan struct{}
+	err          error
+}
+
+func newCommit(response Response, eventService fab.EventService, txStatus *invoke.TxStatusRegistration) *Commit {
+	return &Commit{
+		response:     response,
+		eventService: eventService,
+		registration: txStatus.Registration,
+		notifier:     txStatus.Notifier,
+		done:         make(chan struct{}),
+	}
+}
+
+// TransactionID returns the ID of the submitted transaction
+func (c *Commit) TransactionID() fab.TransactionID {
+	return c.response.TransactionID
+}
+
+// Wait blocks until the transaction has been committed, the commit has been cancelled or ctx is done.
+// On commit the endorsement response is returned along with the transaction validation code; an error is
+// returned if the transaction was found to be invalid. Wait may be called again after ctx is done.
+func (c *Commit) Wait(ctx reqContext.Context) (Response, error) {
+	select {
+	case txStatus, ok := <-c.notifier:
+		if !ok {
+			c.complete(nil, errors.New("TxStatus event registration was closed"))
+		} else {
+			c.complete(txStatus, nil)
+		}
+	case <-c.done:
+	case <-ctx.Done():
+		return Response{}, status.New(status.ClientStatus, status.Timeout.ToInt32(),
+			"request timed out or been cancelled", nil)
+	}
+
+	<-c.done
+	return c.response, c.err
+}
+
+// Cancel stops waiting for the transaction to be committed and releases its event registration.
+// Note that a transaction accepted by the orderer may still be committed after it is cancelled.
+func (c *Commit) Cancel() {
+	c.complete(nil, errors.New("commit was cancelled"))
+}
+
+func (c *Commit) complete(txStatus *fab.TxStatusEvent, err error) {
+	c.once.Do(func() {
+		c.eventService.Unregister(c.registration)
+
+		if txStatus != nil {
+			c.response.TxValidationCode = txStatus.TxValidationCode
+			if txStatus.TxValidationCode != pb.TxValidationCode_VALID {
+				err = status.New(status.EventServerStatus, int32(txStatus.TxValidationCode), "received invalid transaction", nil)
+			}
+		}
+		c.err = err
+		close(c.done)
+	})
+}
@@ -53,12 +53,13 @@ type Handler interface {
 
 //ClientContext contains context parameters for handler execution
 type ClientContext struct {
-	CryptoSuite  core.CryptoSuite
-	Discovery    fab.DiscoveryService
-	Selection    fab.SelectionService
-	Membership   fab.ChannelMembership
-	Transactor   fab.Transactor
-	EventService fab.EventService
+	CryptoSuite   core.CryptoSuite
+	Discovery     fab.DiscoveryService
+	Selection     fab.SelectionService
+	Membership    fab.ChannelMembership
+	Transactor    fab.Transactor
+	EventService  fab.EventService
+	ChannelConfig fab.ChannelCfg
 }
 
 //RequestContext contains request, opts, response parameters for handler execution
@@ -70,4 +71,20 @@ type RequestContext struct {
 	RetryHandler    retry.Handler
 	Ctx             reqContext.Context
 	SelectionFilter selectopts.PeerFilter
+	TxStatus        *TxStatusRegistration
+	SignedProposal  *SignedProposal
+}
+
+//TxStatusRegistration contains the TxStatus event registration for a transaction
+//that has been sent to the orderer but has not yet been committed
+type TxStatusRegistration struct {
+	Registration fab.Registration
+	Notifier     <-chan *fab.TxStatusEvent
+}
+
+//SignedProposal contains a transaction proposal that was signed outside of the SDK.
+//When it is set in the request context, it is sent to the endorsers instead of a new proposal.
+type SignedProposal struct {
+	Proposal *fab.TransactionProposal
+	Signed   *pb.SignedProposal
 }
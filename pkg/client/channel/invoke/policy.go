@@ -0,0 +1,167 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/ccpolicy"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel/membership"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+// policyCacheTimeout is the time after which a cached chaincode policy is queried again
+const policyCacheTimeout = 5 * time.Minute
+
+// Handlers are usually created for each invocation, so the chaincode policies and
+// the policy evaluators are cached across handlers
+var (
+	sharedPolicyCache    = ccpolicy.NewCache(policyCacheTimeout)
+	sharedEvaluatorCache = newEvaluatorCache()
+)
+
+//NewEndorsementPolicyValidationHandler returns a handler that validates the endorsements against the chaincode's endorsement policy
+func NewEndorsementPolicyValidationHandler(next ...Handler) *EndorsementPolicyValidationHandler {
+	return &EndorsementPolicyValidationHandler{
+		next:       getNext(next),
+		policies:   sharedPolicyCache,
+		evaluators: sharedEvaluatorCache,
+	}
+}
+
+//EndorsementPolicyValidationHandler for verifying, before the transaction is sent to the orderer,
+//that the collected endorsements satisfy the chaincode's endorsement policy
+type EndorsementPolicyValidationHandler struct {
+	next       Handler
+	policies   *ccpolicy.Cache
+	evaluators *evaluatorCache
+}
+
+//Handle for validating the endorsements against the endorsement policy
+func (h *EndorsementPolicyValidationHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
+	err := h.validate(requestContext, clientContext)
+	if err != nil {
+		requestContext.Error = errors.WithMessage(err, "endorsement policy validation failed")
+		return
+	}
+
+	// Delegate to next step if any
+	if h.next != nil {
+		h.next.Handle(requestContext, clientContext)
+	}
+}
+
+func (h *EndorsementPolicyValidationHandler) validate(requestContext *RequestContext, clientContext *ClientContext) error {
+	if clientContext.ChannelConfig == nil {
+		return errors.New("channel config is required")
+	}
+
+	evaluator, err := h.evaluators.get(clientContext.CryptoSuite, clientContext.ChannelConfig)
+	if err != nil {
+		return errors.WithMessage(err, "creating policy evaluator failed")
+	}
+
+	channelID := clientContext.ChannelConfig.ID()
+	chaincodeID := requestContext.Request.ChaincodeID
+	query := func() ([]byte, error) {
+		return queryChaincodeData(requestContext, clientContext)
+	}
+
+	policy, err := h.policies.Get(channelID, chaincodeID, query)
+	if err != nil {
+		return err
+	}
+	err = evaluator.Evaluate(policy, requestContext.Response.Responses)
+	if err == nil {
+		return nil
+	}
+
+	// The cached policy may be stale after a chaincode upgrade, so evaluate the current policy before failing
+	h.policies.Invalidate(channelID, chaincodeID)
+	policy, qerr := h.policies.Get(channelID, chaincodeID, query)
+	if qerr != nil {
+		return err
+	}
+	return evaluator.Evaluate(policy, requestContext.Response.Responses)
+}
+
+//queryChaincodeData queries the chaincode data from the request targets, stopping at the first valid response
+func queryChaincodeData(requestContext *RequestContext, clientContext *ClientContext) ([]byte, error) {
+	request := Request{
+		ChaincodeID: ccpolicy.DataProviderSCC,
+		Fcn:         ccpolicy.DataProviderFunction,
+		Args:        ccpolicy.QueryArgs(clientContext.ChannelConfig.ID(), requestContext.Request.ChaincodeID),
+	}
+
+	if len(requestContext.Opts.Targets) == 0 {
+		return nil, status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "targets were not provided", nil)
+	}
+
+	errs := multi.Errors{}
+	for _, target := range requestContext.Opts.Targets {
+		responses, _, err := createAndSendTransactionProposal(clientContext.Transactor, &request, []fab.ProposalProcessor{target})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		response := responses[0].ProposalResponse
+		if response.GetResponse().Status != int32(common.Status_SUCCESS) {
+			errs = append(errs, status.NewFromProposalResponse(response, responses[0].Endorser))
+			continue
+		}
+
+		payload := response.GetResponse().Payload
+		if _, err := ccpolicy.UnmarshalPolicy(payload); err != nil {
+			errs = append(errs, errors.WithMessage(err, responses[0].Endorser))
+			continue
+		}
+		return payload, nil
+	}
+
+	return nil, errors.WithMessage(errs.ToError(), "querying chaincode policy failed")
+}
+
+//evaluatorCache holds a policy evaluator for each channel, which is recreated when the channel config changes
+type evaluatorCache struct {
+	mutex      sync.Mutex
+	evaluators map[string]*channelEvaluator
+}
+
+type channelEvaluator struct {
+	blockNumber uint64
+	cryptoSuite core.CryptoSuite
+	evaluator   *membership.PolicyEvaluator
+}
+
+func newEvaluatorCache() *evaluatorCache {
+	return &evaluatorCache{evaluators: make(map[string]*channelEvaluator)}
+}
+
+func (c *evaluatorCache) get(cryptoSuite core.CryptoSuite, cfg fab.ChannelCfg) (*membership.PolicyEvaluator, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, ok := c.evaluators[cfg.ID()]
+	if ok && cached.blockNumber == cfg.BlockNumber() && cached.cryptoSuite == cryptoSuite {
+		return cached.evaluator, nil
+	}
+
+	evaluator, err := membership.NewPolicyEvaluator(cryptoSuite, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.evaluators[cfg.ID()] = &channelEvaluator{blockNumber: cfg.BlockNumber(), cryptoSuite: cryptoSuite, evaluator: evaluator}
+	return evaluator, nil
+}
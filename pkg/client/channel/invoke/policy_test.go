@@ -0,0 +1,268 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package invoke
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/ccpolicy"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite/bccsp/sw"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestEndorsementPolicyValidationHandlerSuccess(t *testing.T) {
+	// a policy that requires no signatures is always satisfied
+	policy := &common.SignaturePolicyEnvelope{Rule: cauthdsl.NOutOf(0, nil)}
+	peer := setupPolicyPeer(policy, t)
+
+	requestContext, clientContext := setupContextForPolicyValidation(peer, t)
+
+	handler := newTestPolicyValidationHandler(&payloadHandler{})
+	handler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error)
+	assert.Equal(t, []byte("custom"), requestContext.Response.Payload, "expected next handler to be called")
+	assert.Equal(t, 1, peer.ProcessProposalCalls, "expected chaincode policy to be queried")
+
+	// the policy is cached
+	requestContext.Response.Payload = nil
+	handler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error)
+	assert.Equal(t, []byte("custom"), requestContext.Response.Payload, "expected next handler to be called")
+	assert.Equal(t, 1, peer.ProcessProposalCalls, "expected chaincode policy to be cached")
+}
+
+func TestEndorsementPolicyValidationHandlerPolicyNotSatisfied(t *testing.T) {
+	peer := setupPolicyPeer(cauthdsl.SignedByMspMember("Org1MSP"), t)
+
+	requestContext, clientContext := setupContextForPolicyValidation(peer, t)
+
+	handler := newTestPolicyValidationHandler(&payloadHandler{})
+	handler.Handle(requestContext, clientContext)
+
+	statusError, ok := status.FromError(errors.Cause(requestContext.Error))
+	assert.True(t, ok, "expected status error, got %v", requestContext.Error)
+	assert.EqualValues(t, status.EndorsementPolicyFailure.ToInt32(), statusError.Code)
+	assert.True(t, strings.Contains(statusError.Message, "Org1MSP.member"), "expected missing principal in error: %s", statusError.Message)
+	assert.Nil(t, requestContext.Response.Payload, "next handler should not have been called")
+}
+
+func TestEndorsementPolicyValidationHandlerStalePolicy(t *testing.T) {
+	peer := setupPolicyPeer(cauthdsl.SignedByMspMember("Org1MSP"), t)
+
+	requestContext, clientContext := setupContextForPolicyValidation(peer, t)
+
+	handler := newTestPolicyValidationHandler(&payloadHandler{})
+	handler.Handle(requestContext, clientContext)
+	assert.NotNil(t, requestContext.Error, "expected policy not to be satisfied")
+
+	// the chaincode is upgraded with a policy that the endorsements satisfy
+	upgraded := setupPolicyPeer(&common.SignaturePolicyEnvelope{Rule: cauthdsl.NOutOf(0, nil)}, t)
+	peer.Payload = upgraded.Payload
+	calls := peer.ProcessProposalCalls
+
+	requestContext.Error = nil
+	handler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error, "expected the current policy to be evaluated")
+	assert.Equal(t, calls+1, peer.ProcessProposalCalls, "expected chaincode policy to be queried again")
+	assert.Equal(t, []byte("custom"), requestContext.Response.Payload, "expected next handler to be called")
+}
+
+func TestEndorsementPolicyValidationHandlerQueryError(t *testing.T) {
+	peer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer.Error = errors.New("lscc unavailable")
+
+	requestContext, clientContext := setupContextForPolicyValidation(peer, t)
+
+	handler := newTestPolicyValidationHandler()
+	handler.Handle(requestContext, clientContext)
+	assert.NotNil(t, requestContext.Error)
+	assert.True(t, strings.Contains(requestContext.Error.Error(), "querying chaincode policy failed"), "unexpected error: %s", requestContext.Error)
+
+	clientContext.ChannelConfig = nil
+	requestContext.Error = nil
+	handler.Handle(requestContext, clientContext)
+	assert.NotNil(t, requestContext.Error, "expected error for missing channel config")
+}
+
+func TestEndorsementPolicyValidationHandlerInvalidChaincodeData(t *testing.T) {
+	invalidPeer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	invalidPeer.Payload = []byte("invalid chaincode data")
+	peer := setupPolicyPeer(&common.SignaturePolicyEnvelope{Rule: cauthdsl.NOutOf(0, nil)}, t)
+
+	requestContext, clientContext := setupContextForPolicyValidation(invalidPeer, t)
+	requestContext.Opts.Targets = append(requestContext.Opts.Targets, peer)
+
+	handler := newTestPolicyValidationHandler(&payloadHandler{})
+	handler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error, "expected chaincode data to be queried from the next target")
+	assert.Equal(t, 1, invalidPeer.ProcessProposalCalls)
+	assert.Equal(t, 1, peer.ProcessProposalCalls)
+}
+
+func TestEndorsementPolicyValidationHandlerSignature(t *testing.T) {
+	caCert, caKey := generatePolicyCA(t)
+	cert, key := generatePolicyCert(t, caCert, caKey)
+
+	cryptoSuite, err := sw.GetSuiteWithDefaultEphemeral()
+	assert.Nil(t, err)
+
+	peer := setupPolicyPeer(cauthdsl.SignedByMspMember("Org1MSP"), t)
+	requestContext, clientContext := setupContextForPolicyValidation(peer, t)
+	clientContext.CryptoSuite = cryptoSuite
+	channelConfig := fcmocks.NewMockChannelCfg("signatureChannel")
+	channelConfig.MockMSPs = []*mb.MSPConfig{policyMSPConfig(t, "Org1MSP", caCert)}
+	clientContext.ChannelConfig = channelConfig
+
+	response := signedPolicyResponse(t, "Org1MSP", cert, key)
+	requestContext.Response.Responses = []*fab.TransactionProposalResponse{response}
+
+	handler := newTestPolicyValidationHandler(&payloadHandler{})
+	handler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error, "expected a valid endorsement to satisfy the policy")
+
+	// tamper with the signature
+	signature := response.ProposalResponse.Endorsement.Signature
+	signature[len(signature)-1] ^= 0xff
+
+	requestContext.Error = nil
+	requestContext.Response.Payload = nil
+	handler.Handle(requestContext, clientContext)
+	statusError, ok := status.FromError(errors.Cause(requestContext.Error))
+	assert.True(t, ok, "expected status error, got %v", requestContext.Error)
+	assert.EqualValues(t, status.SignatureVerificationFailed.ToInt32(), statusError.Code)
+	assert.Nil(t, requestContext.Response.Payload, "next handler should not have been called")
+}
+
+type payloadHandler struct{}
+
+func (h *payloadHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
+	requestContext.Response.Payload = []byte("custom")
+}
+
+// newTestPolicyValidationHandler returns a handler with its own caches so that tests don't share policies
+func newTestPolicyValidationHandler(next ...Handler) *EndorsementPolicyValidationHandler {
+	handler := NewEndorsementPolicyValidationHandler(next...)
+	handler.policies = ccpolicy.NewCache(0)
+	handler.evaluators = newEvaluatorCache()
+	return handler
+}
+
+func setupPolicyPeer(policy *common.SignaturePolicyEnvelope, t *testing.T) *fcmocks.MockPeer {
+	policyBytes, err := proto.Marshal(policy)
+	assert.Nil(t, err)
+	ccData, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "test", Policy: policyBytes})
+	assert.Nil(t, err)
+
+	peer := fcmocks.NewMockPeer("Peer1", "http://peer1.com")
+	peer.Payload = ccData
+	return peer
+}
+
+func setupContextForPolicyValidation(peer fab.Peer, t *testing.T) (*RequestContext, *ClientContext) {
+	requestContext := prepareRequestContext(Request{ChaincodeID: "test", Fcn: "invoke"}, Opts{Targets: []fab.Peer{peer}}, t)
+
+	clientContext := setupChannelClientContext(nil, nil, nil, t)
+	clientContext.CryptoSuite = &fcmocks.MockCryptoSuite{}
+	clientContext.ChannelConfig = fcmocks.NewMockChannelCfg("testChannel")
+
+	return requestContext, clientContext
+}
+
+func signedPolicyResponse(t *testing.T, mspID string, cert []byte, key *ecdsa.PrivateKey) *fab.TransactionProposalResponse {
+	endorser, err := proto.Marshal(&mb.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	assert.Nil(t, err)
+
+	payload := []byte("payload")
+	digest := sha256.Sum256(append(append([]byte{}, payload...), endorser...))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	assert.Nil(t, err)
+	// Fabric only accepts signatures with a low S value
+	halfOrder := new(big.Int).Rsh(key.Params().N, 1)
+	if s.Cmp(halfOrder) > 0 {
+		s.Sub(key.Params().N, s)
+	}
+	signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+	assert.Nil(t, err)
+
+	return &fab.TransactionProposalResponse{
+		Endorser: mspID,
+		ProposalResponse: &pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200},
+			Payload:     payload,
+			Endorsement: &pb.Endorsement{Endorser: endorser, Signature: signature},
+		},
+	}
+}
+
+func policyMSPConfig(t *testing.T, name string, root []byte) *mb.MSPConfig {
+	config, err := proto.Marshal(&mb.FabricMSPConfig{Name: name, RootCerts: [][]byte{root}})
+	assert.Nil(t, err)
+	return &mb.MSPConfig{Config: config}
+}
+
+func generatePolicyCA(t *testing.T) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.org1", Organization: []string{"org1"}},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		SubjectKeyId:          []byte("org1"),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw}), key
+}
+
+func generatePolicyCert(t *testing.T, caPEM []byte, caKey *ecdsa.PrivateKey) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	block, _ := pem.Decode(caPEM)
+	ca, err := x509.ParseCertificate(block.Bytes)
+	assert.Nil(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "peer0.org1"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, &template, ca, &key.PublicKey, caKey)
+	assert.Nil(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw}), key
+}
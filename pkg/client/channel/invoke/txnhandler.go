@@ -16,6 +16,7 @@ import (
 	selectopts "github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/rwset"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
@@ -35,7 +36,15 @@ func (e *EndorsementHandler) Handle(requestContext *RequestContext, clientContex
 	}
 
 	// Endorse Tx
-	transactionProposalResponses, proposal, err := createAndSendTransactionProposal(clientContext.Transactor, &requestContext.Request, peer.PeersToTxnProcessors(requestContext.Opts.Targets))
+	var transactionProposalResponses []*fab.TransactionProposalResponse
+	var proposal *fab.TransactionProposal
+	var err error
+	if requestContext.SignedProposal != nil {
+		proposal = requestContext.SignedProposal.Proposal
+		transactionProposalResponses, err = txn.SendSignedProposal(requestContext.Ctx, requestContext.SignedProposal.Signed, peer.PeersToTxnProcessors(requestContext.Opts.Targets))
+	} else {
+		transactionProposalResponses, proposal, err = createAndSendTransactionProposal(clientContext.Transactor, &requestContext.Request, peer.PeersToTxnProcessors(requestContext.Opts.Targets))
+	}
 
 	requestContext.Response.Proposal = proposal
 	requestContext.Response.TransactionID = proposal.TxnID // TODO: still needed?
@@ -57,6 +66,25 @@ func (e *EndorsementHandler) Handle(requestContext *RequestContext, clientContex
 	}
 }
 
+//rwSetDifferences returns the differences between the read-write sets of the given responses,
+//to be included in the details of an endorsement mismatch error. Nil is returned if they cannot be decoded.
+func rwSetDifferences(left, right *pb.ProposalResponse) []interface{} {
+	leftRWSet, err := rwset.FromProposalResponse(left)
+	if err != nil {
+		return nil
+	}
+	rightRWSet, err := rwset.FromProposalResponse(right)
+	if err != nil {
+		return nil
+	}
+
+	var details []interface{}
+	for _, d := range rwset.Diff(leftRWSet, rightRWSet) {
+		details = append(details, d)
+	}
+	return details
+}
+
 //ProposalProcessorHandler for selecting proposal processors
 type ProposalProcessorHandler struct {
 	next Handler
@@ -119,38 +147,50 @@ func (f *EndorsementValidationHandler) validate(txProposalResponse []*fab.Transa
 		if !bytes.Equal(a1.Payload, r.ProposalResponse.Payload) ||
 			!bytes.Equal(a1.GetResponse().Payload, r.ProposalResponse.GetResponse().Payload) {
 			return status.New(status.EndorserClientStatus, status.EndorsementMismatch.ToInt32(),
-				"ProposalResponsePayloads do not match", nil)
+				"ProposalResponsePayloads do not match", rwSetDifferences(a1, r.ProposalResponse))
 		}
 	}
 
 	return nil
 }
 
-//CommitTxHandler for committing transactions
-type CommitTxHandler struct {
+//SubmitTxHandler for sending transactions to the orderer without waiting for them to be committed
+type SubmitTxHandler struct {
 	next Handler
 }
 
-//Handle handles commit tx
-func (c *CommitTxHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
-	txnID := requestContext.Response.TransactionID
-
-	//Register Tx event
-	reg, statusNotifier, err := clientContext.EventService.RegisterTxStatusEvent(string(txnID)) // TODO: Change func to use TransactionID instead of string
+//Handle registers for the TxStatus event and sends the transaction to the orderer.
+//The registration is left in the request context so that the commit can be awaited later.
+func (c *SubmitTxHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
+	txStatus, err := submitTransaction(requestContext, clientContext)
 	if err != nil {
-		requestContext.Error = errors.Wrap(err, "error registering for TxStatus event")
+		requestContext.Error = err
 		return
 	}
-	defer clientContext.EventService.Unregister(reg)
+	requestContext.TxStatus = txStatus
 
-	_, err = createAndSendTransaction(clientContext.Transactor, requestContext.Response.Proposal, requestContext.Response.Responses)
+	//Delegate to next step if any
+	if c.next != nil {
+		c.next.Handle(requestContext, clientContext)
+	}
+}
+
+//CommitTxHandler for committing transactions
+type CommitTxHandler struct {
+	next Handler
+}
+
+//Handle handles commit tx
+func (c *CommitTxHandler) Handle(requestContext *RequestContext, clientContext *ClientContext) {
+	txStatusReg, err := submitTransaction(requestContext, clientContext)
 	if err != nil {
-		requestContext.Error = errors.Wrap(err, "CreateAndSendTransaction failed")
+		requestContext.Error = err
 		return
 	}
+	defer clientContext.EventService.Unregister(txStatusReg.Registration)
 
 	select {
-	case txStatus := <-statusNotifier:
+	case txStatus := <-txStatusReg.Notifier:
 		requestContext.Response.TxValidationCode = txStatus.TxValidationCode
 
 		if txStatus.TxValidationCode != pb.TxValidationCode_VALID {
@@ -190,6 +230,17 @@ func NewExecuteHandler(next ...Handler) Handler {
 	)
 }
 
+//NewExecuteAsyncHandler returns execute handler with EndorseTxHandler, EndorsementValidationHandler & SubmitTxHandler Chained
+func NewExecuteAsyncHandler(next ...Handler) Handler {
+	return NewProposalProcessorHandler(
+		NewEndorsementHandler(
+			NewEndorsementValidationHandler(
+				NewSignatureValidationHandler(NewSubmitHandler(next...)),
+			),
+		),
+	)
+}
+
 //NewProposalProcessorHandler returns a handler that selects proposal processors
 func NewProposalProcessorHandler(next ...Handler) *ProposalProcessorHandler {
 	return &ProposalProcessorHandler{next: getNext(next)}
@@ -210,6 +261,11 @@ func NewCommitHandler(next ...Handler) *CommitTxHandler {
 	return &CommitTxHandler{next: getNext(next)}
 }
 
+//NewSubmitHandler returns a handler that sends transaction proposal responses to the orderer
+func NewSubmitHandler(next ...Handler) *SubmitTxHandler {
+	return &SubmitTxHandler{next: getNext(next)}
+}
+
 func getNext(next []Handler) Handler {
 	if len(next) > 0 {
 		return next[0]
@@ -217,6 +273,26 @@ func getNext(next []Handler) Handler {
 	return nil
 }
 
+//submitTransaction registers for the TxStatus event of the transaction and sends it to the orderer.
+//The registration is released if the transaction could not be sent.
+func submitTransaction(requestContext *RequestContext, clientContext *ClientContext) (*TxStatusRegistration, error) {
+	txnID := requestContext.Response.TransactionID
+
+	//Register Tx event
+	reg, statusNotifier, err := clientContext.EventService.RegisterTxStatusEvent(string(txnID)) // TODO: Change func to use TransactionID instead of string
+	if err != nil {
+		return nil, errors.Wrap(err, "error registering for TxStatus event")
+	}
+
+	_, err = createAndSendTransaction(clientContext.Transactor, requestContext.Response.Proposal, requestContext.Response.Responses)
+	if err != nil {
+		clientContext.EventService.Unregister(reg)
+		return nil, errors.Wrap(err, "CreateAndSendTransaction failed")
+	}
+
+	return &TxStatusRegistration{Registration: reg, Notifier: statusNotifier}, nil
+}
+
 func createAndSendTransaction(sender fab.Sender, proposal *fab.TransactionProposal, resps []*fab.TransactionProposalResponse) (*fab.TransactionResponse, error) {
 
 	txnRequest := fab.TransactionRequest{
@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
@@ -22,7 +23,10 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/rwset"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -87,6 +91,33 @@ func TestExecuteTxHandlerSuccess(t *testing.T) {
 	assert.Nil(t, requestContext.Error)
 }
 
+func TestExecuteAsyncHandlerSuccess(t *testing.T) {
+	//Sample request
+	request := Request{ChaincodeID: "test", Fcn: "invoke", Args: [][]byte{[]byte("move"), []byte("a"), []byte("b"), []byte("1")}}
+
+	//Prepare context objects for handler
+	requestContext := prepareRequestContext(request, Opts{}, t)
+
+	mockPeer1 := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Status: 200, Payload: []byte("value")}
+
+	clientContext := setupChannelClientContext(nil, nil, []fab.Peer{mockPeer1}, t)
+
+	//Prepare mock eventhub
+	mockEventService := fcmocks.NewMockEventService()
+	clientContext.EventService = mockEventService
+
+	//Get execute async handler
+	executeHandler := NewExecuteAsyncHandler()
+	//Perform action through handler; it must return without waiting for the TxStatus event
+	executeHandler.Handle(requestContext, clientContext)
+	assert.Nil(t, requestContext.Error)
+	assert.NotNil(t, requestContext.TxStatus, "expected TxStatus registration")
+
+	txStatusReg := <-mockEventService.TxStatusRegCh
+	assert.EqualValues(t, requestContext.Response.TransactionID, txStatusReg.TxID)
+	assert.Equal(t, txStatusReg, requestContext.TxStatus.Registration)
+}
+
 func TestQueryHandlerErrors(t *testing.T) {
 
 	//Error Scenario 1
@@ -189,6 +220,39 @@ func TestResponseValidation(t *testing.T) {
 	assert.EqualValues(t, int32(status.EndorsementMismatch), s.Code, "expected endorsement mismatch")
 }
 
+func TestResponseValidationRWSetDifferences(t *testing.T) {
+	newResponse := func(value string) *fab.TransactionProposalResponse {
+		txRWSet := &rwsetutil.TxRwSet{NsRwSets: []*rwsetutil.NsRwSet{{NameSpace: "ns1",
+			KvRwSet: &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "key1", Value: []byte(value)}}}}}}
+		results, err := txRWSet.ToProtoBytes()
+		assert.Nil(t, err)
+		ccAction, err := proto.Marshal(&pb.ChaincodeAction{Results: results})
+		assert.Nil(t, err)
+		payload, err := proto.Marshal(&pb.ProposalResponsePayload{Extension: ccAction})
+		assert.Nil(t, err)
+
+		return &fab.TransactionProposalResponse{
+			Endorser: "peer 1",
+			Status:   http.StatusOK,
+			ProposalResponse: &pb.ProposalResponse{Response: &pb.Response{
+				Message: "test", Status: http.StatusOK, Payload: []byte("ResponsePayload")},
+				Payload: payload,
+			}}
+	}
+
+	h := EndorsementValidationHandler{}
+	err := h.validate([]*fab.TransactionProposalResponse{newResponse("a"), newResponse("b")})
+	s, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, int32(status.EndorsementMismatch), s.Code, "expected endorsement mismatch")
+	if assert.Equal(t, 1, len(s.Details), "expected read-write set difference in details") {
+		d, ok := s.Details[0].(*rwset.Difference)
+		assert.True(t, ok, "expected read-write set difference")
+		assert.Equal(t, rwset.WriteDifference, d.Type)
+		assert.Equal(t, "key1", d.Key)
+	}
+}
+
 func TestProposalProcessorHandler(t *testing.T) {
 	peer1 := fcmocks.NewMockPeer("p1", "peer1:7051")
 	peer2 := fcmocks.NewMockPeer("p2", "peer2:7051")
@@ -0,0 +1,170 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel/invoke"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// UnsignedProposal contains a transaction proposal created for an identity whose signing key is not
+// available to the SDK (e.g. a key held in a hardware wallet or by a remote signing service).
+// Bytes must be signed by the identity and the signature passed to EndorseSignedProposal.
+type UnsignedProposal struct {
+	Request  Request
+	Proposal *fab.TransactionProposal
+	Bytes    []byte
+}
+
+// UnsignedTransaction contains an endorsed transaction whose envelope has yet to be signed by its creator.
+// Bytes must be signed by the identity that created the proposal and the signature passed to
+// CommitSignedTransaction.
+type UnsignedTransaction struct {
+	TransactionID fab.TransactionID
+	Bytes         []byte
+}
+
+// PrepareProposal creates a transaction proposal for the given serialized creator identity
+// and returns it along with the bytes that have to be signed by the creator.
+func (cc *Client) PrepareProposal(request Request, creator []byte) (*UnsignedProposal, error) {
+	if request.ChaincodeID == "" || request.Fcn == "" {
+		return nil, errors.New("ChaincodeID and Fcn are required")
+	}
+
+	txh, err := txn.NewHeaderFromCreator(cc.context.CryptoSuite(), cc.context.ChannelID(), creator, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating transaction header failed")
+	}
+
+	proposal, err := txn.CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{
+		ChaincodeID:  request.ChaincodeID,
+		Fcn:          request.Fcn,
+		Args:         request.Args,
+		TransientMap: request.TransientMap,
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating transaction proposal failed")
+	}
+
+	proposalBytes, err := txn.ProposalBytes(proposal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnsignedProposal{Request: request, Proposal: proposal, Bytes: proposalBytes}, nil
+}
+
+// EndorseSignedProposal sends a proposal prepared by PrepareProposal, along with its creator's signature,
+// to the endorsers and validates the endorsements. The response can be passed to PrepareTransaction.
+func (cc *Client) EndorseSignedProposal(proposal *UnsignedProposal, signature []byte, options ...RequestOption) (Response, error) {
+	if proposal == nil || proposal.Proposal == nil {
+		return Response{}, errors.New("proposal is required")
+	}
+	if len(signature) == 0 {
+		return Response{}, errors.New("signature is required")
+	}
+
+	optsWithTimeout, err := cc.addDefaultTimeout(cc.context, core.Query, options...)
+	if err != nil {
+		return Response{}, errors.WithMessage(err, "option failed")
+	}
+
+	handler := &signedProposalHandler{
+		proposal: &invoke.SignedProposal{
+			Proposal: proposal.Proposal,
+			Signed:   &pb.SignedProposal{ProposalBytes: proposal.Bytes, Signature: signature},
+		},
+		next: invoke.NewQueryHandler(),
+	}
+
+	return cc.InvokeHandler(handler, proposal.Request, optsWithTimeout...)
+}
+
+// PrepareTransaction creates the transaction for the endorsements returned by EndorseSignedProposal
+// and returns it along with the envelope payload bytes that have to be signed by the creator.
+func (cc *Client) PrepareTransaction(response Response) (*UnsignedTransaction, error) {
+	if response.Proposal == nil {
+		return nil, errors.New("proposal is required")
+	}
+
+	tx, err := txn.New(fab.TransactionRequest{Proposal: response.Proposal, ProposalResponses: response.Responses})
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating transaction failed")
+	}
+
+	payloadBytes, err := txn.TransactionPayloadBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnsignedTransaction{TransactionID: response.Proposal.TxnID, Bytes: payloadBytes}, nil
+}
+
+// CommitSignedTransaction sends a transaction prepared by PrepareTransaction, along with its creator's
+// signature, to the orderer and waits for it to be committed.
+func (cc *Client) CommitSignedTransaction(tx *UnsignedTransaction, signature []byte, options ...RequestOption) (Response, error) {
+	if tx == nil || len(tx.Bytes) == 0 {
+		return Response{}, errors.New("transaction is required")
+	}
+	if len(signature) == 0 {
+		return Response{}, errors.New("signature is required")
+	}
+
+	optsWithTimeout, err := cc.addDefaultTimeout(cc.context, core.Execute, options...)
+	if err != nil {
+		return Response{}, errors.WithMessage(err, "option failed")
+	}
+
+	txnOpts, err := cc.prepareOptsFromOptions(cc.context, optsWithTimeout...)
+	if err != nil {
+		return Response{}, err
+	}
+
+	reqCtx, cancel := cc.createReqContext(&txnOpts)
+	defer cancel()
+
+	clientContext, err := cc.prepareClientContext(reqCtx)
+	if err != nil {
+		return Response{}, err
+	}
+
+	sender, ok := clientContext.Transactor.(fab.SignedEnvelopeSender)
+	if !ok {
+		return Response{}, errors.New("transactor does not support sending signed envelopes")
+	}
+
+	reg, statusNotifier, err := cc.eventService.RegisterTxStatusEvent(string(tx.TransactionID))
+	if err != nil {
+		return Response{}, errors.Wrap(err, "error registering for TxStatus event")
+	}
+
+	commit := newCommit(Response{TransactionID: tx.TransactionID}, cc.eventService,
+		&invoke.TxStatusRegistration{Registration: reg, Notifier: statusNotifier})
+	defer commit.Cancel()
+
+	if _, err := sender.SendSignedEnvelope(&fab.SignedEnvelope{Payload: tx.Bytes, Signature: signature}); err != nil {
+		return Response{}, errors.WithMessage(err, "SendSignedEnvelope failed")
+	}
+
+	return commit.Wait(reqCtx)
+}
+
+//signedProposalHandler sets the externally signed proposal in the request context before delegating
+type signedProposalHandler struct {
+	proposal *invoke.SignedProposal
+	next     invoke.Handler
+}
+
+//Handle sets the signed proposal to be sent by the endorsement handler
+func (h *signedProposalHandler) Handle(requestContext *invoke.RequestContext, clientContext *invoke.ClientContext) {
+	requestContext.SignedProposal = h.proposal
+	h.next.Handle(requestContext, clientContext)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
+	"github.com/pkg/errors"
+)
+
+// replayRegistration is the registration returned when events are replayed. Each such registration
+// has its own event client which is closed when the registration is unregistered.
+type replayRegistration struct {
+	fab.Registration
+	eventClient fab.EventClient
+	once        sync.Once
+}
+
+func (r *replayRegistration) unregister() {
+	r.once.Do(func() {
+		r.eventClient.Unregister(r.Registration)
+		r.eventClient.Close()
+	})
+}
+
+// registerChaincodeEventWithReplay registers for chaincode events using a dedicated event client which
+// seeks from the given block. Historical events and new events are therefore delivered over the same
+// stream so there is no hand-over during which events could be missed or delivered twice.
+func (cc *Client) registerChaincodeEventWithReplay(chainCodeID string, eventFilter string, fromBlock uint64) (fab.Registration, <-chan *fab.CCEvent, error) {
+	chConfig, err := cc.context.ChannelService().ChannelConfig()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "unable to get channel config")
+	}
+
+	eventClient, err := cc.replayClientProvider(cc.context, chConfig, fromBlock)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "unable to create event client for replay")
+	}
+
+	// Register before connecting so that none of the replayed events are missed
+	reg, eventch, err := eventClient.RegisterChaincodeEvent(chainCodeID, eventFilter)
+	if err != nil {
+		eventClient.Close()
+		return nil, nil, err
+	}
+
+	if err := eventClient.Connect(); err != nil {
+		eventClient.Unregister(reg)
+		eventClient.Close()
+		return nil, nil, errors.WithMessage(err, "unable to connect event client for replay")
+	}
+
+	logger.Debugf("Registered for chaincode events [%s:%s] from block #%d", chainCodeID, eventFilter, fromBlock)
+
+	return &replayRegistration{Registration: reg, eventClient: eventClient}, eventch, nil
+}
+
+func newReplayClient(ctx context.Client, chConfig fab.ChannelCfg, fromBlock uint64) (fab.EventClient, error) {
+	return deliverclient.New(
+		ctx, chConfig,
+		client.WithBlockEvents(),
+		deliverclient.WithSeekType(seek.FromBlock),
+		deliverclient.WithBlockNum(fromBlock),
+	)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccpolicy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+)
+
+const (
+	// DataProviderSCC is the system chaincode that provides the chaincode data
+	DataProviderSCC = "lscc"
+	// DataProviderFunction is the function of DataProviderSCC that returns the chaincode data
+	DataProviderFunction = "getccdata"
+)
+
+// QueryArgs returns the arguments of DataProviderFunction for the given chaincode
+func QueryArgs(channelID string, chaincodeID string) [][]byte {
+	return [][]byte{[]byte(channelID), []byte(chaincodeID)}
+}
+
+// QueryFunc queries the chaincode data of a chaincode and returns the payload of the response
+type QueryFunc func() ([]byte, error)
+
+// Cache holds the endorsement policies of chaincodes by channel and chaincode ID.
+// Entries expire after the timeout given to NewCache, so that a policy changed by a
+// chaincode upgrade is picked up; a zero timeout keeps entries until they are invalidated.
+type Cache struct {
+	timeout  time.Duration
+	mutex    sync.RWMutex
+	policies map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	policy *common.SignaturePolicyEnvelope
+	expiry time.Time
+}
+
+// NewCache returns a new chaincode policy cache
+func NewCache(timeout time.Duration) *Cache {
+	return &Cache{
+		timeout:  timeout,
+		policies: make(map[string]*cacheEntry),
+	}
+}
+
+// Get returns the policy of the chaincode from the cache, or calls query to retrieve it
+func (c *Cache) Get(channelID string, chaincodeID string, query QueryFunc) (*common.SignaturePolicyEnvelope, error) {
+	key := cacheKey(channelID, chaincodeID)
+
+	c.mutex.RLock()
+	entry, ok := c.policies[key]
+	c.mutex.RUnlock()
+	if ok && (entry.expiry.IsZero() || time.Now().Before(entry.expiry)) {
+		return entry.policy, nil
+	}
+
+	payload, err := query()
+	if err != nil {
+		return nil, err
+	}
+	policy, err := UnmarshalPolicy(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	entry = &cacheEntry{policy: policy}
+	if c.timeout > 0 {
+		entry.expiry = time.Now().Add(c.timeout)
+	}
+
+	c.mutex.Lock()
+	c.policies[key] = entry
+	c.mutex.Unlock()
+
+	return policy, nil
+}
+
+// Invalidate removes the policy of the chaincode from the cache
+func (c *Cache) Invalidate(channelID string, chaincodeID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.policies, cacheKey(channelID, chaincodeID))
+}
+
+// UnmarshalPolicy returns the endorsement policy of the given chaincode data
+func UnmarshalPolicy(ccDataBytes []byte) (*common.SignaturePolicyEnvelope, error) {
+	ccData := &ccprovider.ChaincodeData{}
+	if err := proto.Unmarshal(ccDataBytes, ccData); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode data")
+	}
+
+	policy := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(ccData.Policy, policy); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling SignaturePolicyEnvelope")
+	}
+	return policy, nil
+}
+
+func cacheKey(channelID string, chaincodeID string) string {
+	return channelID + "/" + chaincodeID
+}
@@ -0,0 +1,82 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
+)
+
+func TestCache(t *testing.T) {
+	ccData := chaincodeData(t, "Org1MSP")
+	calls := 0
+	query := func() ([]byte, error) {
+		calls++
+		return ccData, nil
+	}
+
+	cache := NewCache(0)
+	policy, err := cache.Get("mychannel", "mycc", query)
+	assert.Nil(t, err)
+	assert.Equal(t, cauthdsl.SignedByMspMember("Org1MSP").Identities, policy.Identities)
+
+	_, err = cache.Get("mychannel", "mycc", query)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls, "expected policy to be cached")
+
+	_, err = cache.Get("otherchannel", "mycc", query)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls, "expected policies to be cached by channel")
+
+	cache.Invalidate("mychannel", "mycc")
+	_, err = cache.Get("mychannel", "mycc", query)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls, "expected invalidated policy to be queried")
+
+	_, err = cache.Get("mychannel", "errorcc", func() ([]byte, error) {
+		return nil, errors.New("query failed")
+	})
+	assert.NotNil(t, err)
+
+	_, err = cache.Get("mychannel", "invalidcc", func() ([]byte, error) {
+		return []byte("invalid"), nil
+	})
+	assert.NotNil(t, err)
+}
+
+func TestCacheExpiry(t *testing.T) {
+	ccData := chaincodeData(t, "Org1MSP")
+	calls := 0
+	query := func() ([]byte, error) {
+		calls++
+		return ccData, nil
+	}
+
+	cache := NewCache(10 * time.Millisecond)
+	_, err := cache.Get("mychannel", "mycc", query)
+	assert.Nil(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+	_, err = cache.Get("mychannel", "mycc", query)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls, "expected expired policy to be queried")
+}
+
+func chaincodeData(t *testing.T, mspID string) []byte {
+	policy, err := proto.Marshal(cauthdsl.SignedByMspMember(mspID))
+	assert.Nil(t, err)
+	ccData, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "mycc", Policy: policy})
+	assert.Nil(t, err)
+	return ccData
+}
@@ -0,0 +1,241 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dynamicdiscovery
+
+import (
+	reqContext "context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazycache"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazyref"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+const defaultRefreshInterval = 10 * time.Second
+
+/**
+ * Discovery Provider is used to discover peers on the network
+ */
+
+// DiscoveryProvider implements a discovery provider which queries the discovery service
+// of the channel's peers. The peers of each channel are cached and refreshed periodically.
+//
+// Discovery requests are signed by the user of the organization given to New, unless the
+// discovery service is initialized with a channel context, in which case they are signed
+// by the identity of the channel context.
+type DiscoveryProvider struct {
+	orgName         string
+	username        string
+	refreshInterval time.Duration
+	peers           *lazycache.Cache
+	providers       contextAPI.Providers
+	ctx             contextAPI.Client
+	lock            sync.Mutex
+}
+
+// Opt applies a discovery provider option
+type Opt func(*DiscoveryProvider)
+
+// WithRefreshInterval sets the interval at which the peers are refreshed from the discovery service
+func WithRefreshInterval(interval time.Duration) Opt {
+	return func(p *DiscoveryProvider) {
+		p.refreshInterval = interval
+	}
+}
+
+// New returns a dynamic discovery provider which signs discovery requests as the given user of the organization
+func New(orgName, username string, opts ...Opt) (*DiscoveryProvider, error) {
+	if orgName == "" || username == "" {
+		return nil, errors.New("Must provide organisation and user name for discovery provider")
+	}
+
+	p := &DiscoveryProvider{
+		orgName:         orgName,
+		username:        username,
+		refreshInterval: defaultRefreshInterval,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.peers = lazycache.New(
+		"Discovery_Peers_Cache",
+		func(key lazycache.Key) (interface{}, error) {
+			ck := key.(*cacheKey)
+			return lazyref.New(
+				func() (interface{}, error) {
+					return discoverPeers(ck.ctx, ck.channelID)
+				},
+				lazyref.WithRefreshInterval(lazyref.InitOnFirstAccess, p.refreshInterval),
+			), nil
+		},
+	)
+	return p, nil
+}
+
+// Initialize sets the providers from which the client context of the provider's user is created
+func (p *DiscoveryProvider) Initialize(providers contextAPI.Providers) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.providers = providers
+	p.ctx = nil
+	return nil
+}
+
+// CreateDiscoveryService returns a discovery service for the given channel. If the channel ID
+// is empty then the service returns all of the peers known to the peers in the network configuration.
+func (p *DiscoveryProvider) CreateDiscoveryService(channelID string) (fab.DiscoveryService, error) {
+	return &discoveryService{provider: p, channelID: channelID}, nil
+}
+
+// Close frees the cached peers
+func (p *DiscoveryProvider) Close() {
+	p.peers.Close()
+}
+
+// clientContext returns the client context of the provider's user
+func (p *DiscoveryProvider) clientContext() (contextAPI.Client, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.ctx != nil {
+		return p.ctx, nil
+	}
+	if p.providers == nil {
+		return nil, errors.New("discovery provider has not been initialized")
+	}
+
+	mgr, ok := p.providers.IdentityManager(p.orgName)
+	if !ok {
+		return nil, errors.Errorf("invalid organisation [%s] for discovery provider", p.orgName)
+	}
+	identity, err := mgr.GetSigningIdentity(p.username)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to get identity for discovery provider")
+	}
+
+	p.ctx = &context.Client{Providers: p.providers, SigningIdentity: identity}
+	return p.ctx, nil
+}
+
+// discoveryService implements discovery service
+type discoveryService struct {
+	provider  *DiscoveryProvider
+	channelID string
+	ctx       contextAPI.Client
+}
+
+// Initialize sets the channel context which signs discovery requests instead of the provider's user
+func (s *discoveryService) Initialize(ctx contextAPI.Channel) error {
+	s.ctx = ctx
+	return nil
+}
+
+// GetPeers returns the (cached) peers discovered on the channel. The returned peers
+// are of type *discovery.Peer, which also provides the block height and chaincodes of the peer.
+func (s *discoveryService) GetPeers() ([]fab.Peer, error) {
+	ctx := s.ctx
+	if ctx == nil {
+		var err error
+		ctx, err = s.provider.clientContext()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := s.provider.peers.Get(newCacheKey(ctx, s.channelID))
+	if err != nil {
+		return nil, err
+	}
+	peers, err := value.(*lazyref.Reference).Get()
+	if err != nil {
+		return nil, err
+	}
+	return peers.([]fab.Peer), nil
+}
+
+func discoverPeers(ctx contextAPI.Client, channelID string) ([]fab.Peer, error) {
+	targets, err := discovery.Targets(ctx.Config(), channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := discovery.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := discovery.NewRequest()
+	if channelID == "" {
+		req.AddLocalPeersQuery()
+	} else {
+		req.AddPeersQuery(channelID)
+	}
+
+	reqCtx, cancel := reqContext.WithTimeout(reqContext.Background(), ctx.Config().TimeoutOrDefault(core.PeerResponse))
+	defer cancel()
+
+	response, err := client.Send(reqCtx, req, targets...)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("discovery of peers on channel [%s] failed", channelID))
+	}
+	discovered, err := response.PeersAt(0)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("discovery of peers on channel [%s] failed", channelID))
+	}
+
+	var peers []fab.Peer
+	for _, p := range discovered {
+		info, err := discovery.ParsePeer(p)
+		if err != nil {
+			logger.Warnf("Ignoring invalid peer returned by discovery service: %s", err)
+			continue
+		}
+		peer, err := discovery.NewPeer(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+		peers = append(peers, peer)
+	}
+
+	logger.Debugf("Discovered %d peers on channel [%s]", len(peers), channelID)
+	return peers, nil
+}
+
+// cacheKey holds the channel and the context of the identity which queries the
+// discovery service, since the peers returned depend on the identity's permissions
+type cacheKey struct {
+	key       string
+	channelID string
+	ctx       contextAPI.Client
+}
+
+func newCacheKey(ctx contextAPI.Client, channelID string) *cacheKey {
+	identifier := ctx.Identifier()
+	return &cacheKey{
+		key:       fmt.Sprintf("%s_%s_%s", channelID, identifier.MSPID, identifier.ID),
+		channelID: channelID,
+		ctx:       ctx,
+	}
+}
+
+// String returns the key as a string
+func (k *cacheKey) String() string {
+	return k.key
+}
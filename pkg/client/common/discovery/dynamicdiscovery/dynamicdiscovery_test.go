@@ -0,0 +1,155 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dynamicdiscovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+)
+
+const channelID = "mychannel"
+
+type serviceInit interface {
+	Initialize(context context.Channel) error
+}
+
+func TestDiscoveryProvider(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetPeers(
+		mocks.NewDiscoveryPeer("Org1MSP", "peer0.org1.example.com:7051", 10, "cc1"),
+		mocks.NewDiscoveryPeer("Org2MSP", "peer0.org2.example.com:7051", 12, "cc1", "cc2"),
+	)
+
+	provider, err := New("Org1MSP", "user1", WithRefreshInterval(200*time.Millisecond))
+	require.NoError(t, err)
+	defer provider.Close()
+
+	service, err := provider.CreateDiscoveryService(channelID)
+	require.NoError(t, err)
+
+	_, err = service.GetPeers()
+	assert.Error(t, err, "expecting error for uninitialized service")
+
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(address)))
+
+	peers, err := service.GetPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+	for _, p := range peers {
+		peer, ok := p.(*discovery.Peer)
+		require.True(t, ok, "expecting discovered peer")
+		switch peer.MSPID() {
+		case "Org1MSP":
+			assert.Equal(t, "peer0.org1.example.com:7051", peer.URL())
+			assert.Equal(t, uint64(10), peer.BlockHeight())
+			assert.Equal(t, []string{"cc1"}, peer.Chaincodes())
+		case "Org2MSP":
+			assert.Equal(t, uint64(12), peer.BlockHeight())
+			assert.True(t, peer.HasChaincode("cc2"))
+		default:
+			t.Fatalf("unexpected peer from %s", peer.MSPID())
+		}
+	}
+
+	_, err = service.GetPeers()
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.RequestCount(), "expecting peers to be cached")
+
+	// The cached peers are refreshed in the background
+	server.SetPeers(mocks.NewDiscoveryPeer("Org1MSP", "peer0.org1.example.com:7051", 11, "cc1"))
+	time.Sleep(time.Second)
+	peers, err = service.GetPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, uint64(11), peers[0].(*discovery.Peer).BlockHeight())
+}
+
+func TestDiscoveryProviderError(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetError(errors.New("access denied"))
+
+	provider, err := New("Org1MSP", "user1")
+	require.NoError(t, err)
+	defer provider.Close()
+
+	service, err := provider.CreateDiscoveryService(channelID)
+	require.NoError(t, err)
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(address)))
+
+	_, err = service.GetPeers()
+	assert.Error(t, err)
+}
+
+func TestDiscoveryProviderContext(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetPeers(mocks.NewDiscoveryPeer("Org1MSP", "peer0.org1.example.com:7051", 10, "cc1"))
+
+	_, err := New("", "user1")
+	assert.Error(t, err, "expecting error for missing organisation")
+
+	provider, err := New("org1", "user1")
+	require.NoError(t, err)
+	defer provider.Close()
+
+	// The service uses the context of the provider's user if it isn't initialized with a channel context
+	require.NoError(t, provider.Initialize(newProviders(address, "org1")))
+	service, err := provider.CreateDiscoveryService(channelID)
+	require.NoError(t, err)
+	peers, err := service.GetPeers()
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, "peer0.org1.example.com:7051", peers[0].URL())
+
+	provider, err = New("org2", "user1")
+	require.NoError(t, err)
+	defer provider.Close()
+	require.NoError(t, provider.Initialize(newProviders(address, "org1")))
+	service, err = provider.CreateDiscoveryService(channelID)
+	require.NoError(t, err)
+	_, err = service.GetPeers()
+	assert.Error(t, err, "expecting error for unknown organisation")
+}
+
+// testConfig returns the mock discovery server as the only peer of the channel and
+// no configuration for the discovered peers
+type testConfig struct {
+	core.Config
+	address string
+}
+
+func (c *testConfig) ChannelPeers(name string) ([]core.ChannelPeer, error) {
+	return []core.ChannelPeer{{NetworkPeer: core.NetworkPeer{PeerConfig: core.PeerConfig{URL: "grpc://" + c.address}, MSPID: "Org1MSP"}}}, nil
+}
+
+func (c *testConfig) PeerConfigByURL(url string) (*core.PeerConfig, error) {
+	return nil, errors.Errorf("no peer config for [%s]", url)
+}
+
+func newChannelContext(address string) *mocks.MockChannelContext {
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP"))
+	ctx.SetConfig(&testConfig{Config: mocks.NewMockConfig(), address: address})
+	return mocks.NewMockChannelContext(ctx, channelID)
+}
+
+func newProviders(address, orgName string) *mocks.MockProviderContext {
+	providers := mocks.NewMockProviderContext(mocks.WithProviderUser("user1", orgName))
+	providers.SetConfig(&testConfig{Config: mocks.NewMockConfig(), address: address})
+	return providers
+}
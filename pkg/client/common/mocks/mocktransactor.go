@@ -51,3 +51,17 @@ func (t *MockTransactor) SendTransaction(tx *fab.Transaction) (*fab.TransactionR
 	defer cancel()
 	return txn.Send(rqtx, tx, t.Orderers)
 }
+
+// SendTransactionBatch sends several transactions to the chain’s orderer service together.
+func (t *MockTransactor) SendTransactionBatch(txs []*fab.Transaction) ([]*fab.TransactionResponse, []error) {
+	rqtx, cancel := contextImpl.NewRequest(t.Ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+	return txn.SendBatch(rqtx, txs, t.Orderers)
+}
+
+// SendSignedEnvelope sends a transaction envelope that was signed outside of the SDK to the chain’s orderer service.
+func (t *MockTransactor) SendSignedEnvelope(envelope *fab.SignedEnvelope) (*fab.TransactionResponse, error) {
+	rqtx, cancel := contextImpl.NewRequest(t.Ctx, contextImpl.WithTimeout(10*time.Second))
+	defer cancel()
+	return txn.SendSignedEnvelope(rqtx, envelope, t.Orderers)
+}
@@ -10,12 +10,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"sync"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/client/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/ccpolicy"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
@@ -23,7 +22,6 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/api"
-	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
 
@@ -31,11 +29,6 @@ const loggerModule = "fabsdk/client"
 
 var logger = logging.NewLogger(loggerModule)
 
-const (
-	ccDataProviderSCC      = "lscc"
-	ccDataProviderfunction = "getccdata"
-)
-
 type peerCreator interface {
 	CreatePeerFromConfig(peerCfg *core.NetworkPeer) (fab.Peer, error)
 }
@@ -74,7 +67,7 @@ func newCCPolicyProvider(providers api.Providers, channelID string, username str
 		channelID:   channelID,
 		identity:    identity,
 		targetPeers: targetPeers,
-		ccDataMap:   make(map[string]*ccprovider.ChaincodeData),
+		policyCache: ccpolicy.NewCache(0),
 		provider:    providers.InfraProvider(),
 	}
 
@@ -87,8 +80,7 @@ type ccPolicyProvider struct {
 	channelID   string
 	identity    msp.SigningIdentity
 	targetPeers []core.ChannelPeer
-	ccDataMap   map[string]*ccprovider.ChaincodeData // TODO: Add expiry and configurable timeout for map entries
-	mutex       sync.RWMutex
+	policyCache *ccpolicy.Cache // TODO: Add configurable timeout for cache entries
 	provider    peerCreator
 }
 
@@ -97,43 +89,13 @@ func (dp *ccPolicyProvider) GetChaincodePolicy(chaincodeID string) (*common.Sign
 		return nil, errors.New("Must provide chaincode ID")
 	}
 
-	key := newResolverKey(dp.channelID, chaincodeID)
-	var ccData *ccprovider.ChaincodeData
-
-	dp.mutex.RLock()
-	ccData = dp.ccDataMap[chaincodeID]
-	dp.mutex.RUnlock()
-	if ccData != nil {
-		return unmarshalPolicy(ccData.Policy)
-	}
-
-	dp.mutex.Lock()
-	defer dp.mutex.Unlock()
-
-	response, err := dp.queryChaincode(ccDataProviderSCC, ccDataProviderfunction, [][]byte{[]byte(dp.channelID), []byte(chaincodeID)})
-	if err != nil {
-		return nil, errors.WithMessage(err, fmt.Sprintf("error querying chaincode data for chaincode [%s] on channel [%s]", chaincodeID, dp.channelID))
-	}
-
-	ccData = &ccprovider.ChaincodeData{}
-	err = proto.Unmarshal(response, ccData)
-	if err != nil {
-		return nil, errors.WithMessage(err, "Error unmarshalling chaincode data")
-	}
-
-	dp.ccDataMap[key.String()] = ccData
-
-	return unmarshalPolicy(ccData.Policy)
-}
-
-func unmarshalPolicy(policy []byte) (*common.SignaturePolicyEnvelope, error) {
-
-	sigPolicyEnv := &common.SignaturePolicyEnvelope{}
-	if err := proto.Unmarshal(policy, sigPolicyEnv); err != nil {
-		return nil, errors.WithMessage(err, "error unmarshalling SignaturePolicyEnvelope")
-	}
-
-	return sigPolicyEnv, nil
+	return dp.policyCache.Get(dp.channelID, chaincodeID, func() ([]byte, error) {
+		response, err := dp.queryChaincode(ccpolicy.DataProviderSCC, ccpolicy.DataProviderFunction, ccpolicy.QueryArgs(dp.channelID, chaincodeID))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error querying chaincode data for chaincode [%s] on channel [%s]", chaincodeID, dp.channelID))
+		}
+		return response, nil
+	})
 }
 
 func (dp *ccPolicyProvider) queryChaincode(ccID string, ccFcn string, ccArgs [][]byte) ([]byte, error) {
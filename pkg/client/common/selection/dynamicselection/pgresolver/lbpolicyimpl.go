@@ -8,6 +8,9 @@ package pgresolver
 
 import (
 	"math/rand"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockheight"
 )
 
 type randomLBP struct {
@@ -63,3 +66,52 @@ func (lbp *roundRobinLBP) Choose(peerGroups []PeerGroup) PeerGroup {
 
 	return peerGroups[lbp.index]
 }
+
+type blockHeightLBP struct {
+	tracker *blockheight.Tracker
+	lbp     LoadBalancePolicy
+}
+
+// NewBlockHeightLBP returns a load-balance policy which excludes the peer groups containing peers that
+// are lagging behind the tallest peer and then chooses one of the remaining peer groups using the given
+// load-balance policy. If all of the peer groups contain a lagging peer then the choice is made from all
+// of the peer groups.
+func NewBlockHeightLBP(tracker *blockheight.Tracker, lbp LoadBalancePolicy) LoadBalancePolicy {
+	return &blockHeightLBP{tracker: tracker, lbp: lbp}
+}
+
+func (lbp *blockHeightLBP) Choose(peerGroups []PeerGroup) PeerGroup {
+	var peers []fab.Peer
+	for _, pg := range peerGroups {
+		peers = append(peers, pg.Peers()...)
+	}
+
+	accepted := make(map[fab.Peer]bool)
+	for _, peer := range lbp.tracker.Filter(peers) {
+		accepted[peer] = true
+	}
+
+	var current []PeerGroup
+	for _, pg := range peerGroups {
+		if containsOnly(pg, accepted) {
+			current = append(current, pg)
+		}
+	}
+
+	if len(current) == 0 {
+		logger.Warn("All peer groups contain a peer that is lagging behind\n")
+		return lbp.lbp.Choose(peerGroups)
+	}
+
+	logger.Debugf("blockHeightLBP - Choosing from %d of %d peer groups\n", len(current), len(peerGroups))
+	return lbp.lbp.Choose(current)
+}
+
+func containsOnly(pg PeerGroup, accepted map[fab.Peer]bool) bool {
+	for _, peer := range pg.Peers() {
+		if !accepted[peer] {
+			return false
+		}
+	}
+	return true
+}
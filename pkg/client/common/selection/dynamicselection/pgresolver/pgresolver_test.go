@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockheight"
 	mocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	common "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 )
@@ -397,3 +398,28 @@ func containsPeer(pg []fab.Peer, p fab.Peer) bool {
 func init() {
 	rand.Seed(time.Now().Unix())
 }
+
+func TestBlockHeightLBP(t *testing.T) {
+	p1 := peer("p1", "peer1.org1.com:7051", org1)
+	p2 := peer("p2", "peer2.org1.com:7051", org1)
+	p3 := peer("p3", "peer1.org2.com:7051", org2)
+
+	tracker := blockheight.NewTracker(blockheight.WithMaxBlocksBehind(2))
+	tracker.Update(p1.URL(), 100)
+	tracker.Update(p2.URL(), 90)
+	tracker.Update(p3.URL(), 99)
+
+	lbp := NewBlockHeightLBP(tracker, NewRoundRobinLBP())
+
+	peerGroups := []PeerGroup{pg(p1, p3), pg(p2, p3)}
+	for i := 0; i < 5; i++ {
+		if chosen := lbp.Choose(peerGroups); !containsAllPeers(chosen, peerGroups[0]) {
+			t.Fatalf("expecting peer group %s but got %s", peerGroups[0], chosen)
+		}
+	}
+
+	// All of the groups contain a lagging peer so choose from all of them
+	if chosen := lbp.Choose([]PeerGroup{pg(p2, p3)}); len(chosen.Peers()) != 2 {
+		t.Fatalf("expecting peer group to be chosen from all groups but got %s", chosen)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabricselection
+
+import (
+	reqContext "context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	copts "github.com/hyperledger/fabric-sdk-go/pkg/common/options"
+	contextAPI "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/discovery"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazycache"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/concurrent/lazyref"
+)
+
+var logger = logging.NewLogger("fabsdk/client")
+
+const defaultCacheTimeout = 5 * time.Minute
+
+// SelectionProvider implements a selection provider which selects endorsers using the
+// endorsement layouts returned by the discovery service of the channel's peers
+type SelectionProvider struct {
+	cacheTimeout time.Duration
+	endorsers    *lazycache.Cache
+}
+
+// Opt applies a selection provider option
+type Opt func(*SelectionProvider)
+
+// WithCacheTimeout sets the expiration timeout of the cached endorsement layouts
+func WithCacheTimeout(timeout time.Duration) Opt {
+	return func(p *SelectionProvider) {
+		p.cacheTimeout = timeout
+	}
+}
+
+// New returns a fabric selection provider
+func New(opts ...Opt) (*SelectionProvider, error) {
+	p := &SelectionProvider{cacheTimeout: defaultCacheTimeout}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.endorsers = lazycache.New(
+		"Endorsement_Layout_Cache",
+		func(key lazycache.Key) (interface{}, error) {
+			ck := key.(*cacheKey)
+			return lazyref.New(
+				func() (interface{}, error) {
+					return queryEndorsers(ck.ctx, ck.channelID, ck.calls)
+				},
+				lazyref.WithAbsoluteExpiration(p.cacheTimeout),
+			), nil
+		},
+	)
+	return p, nil
+}
+
+// CreateSelectionService creates a selection service for the given channel.
+// The service must be initialized with a channel context before use.
+func (p *SelectionProvider) CreateSelectionService(channelID string) (fab.SelectionService, error) {
+	if channelID == "" {
+		return nil, errors.New("Must provide channel ID")
+	}
+
+	return &selectionService{provider: p, channelID: channelID}, nil
+}
+
+// Close frees the cached endorsement layouts
+func (p *SelectionProvider) Close() {
+	p.endorsers.Close()
+}
+
+type selectionService struct {
+	provider  *SelectionProvider
+	channelID string
+	ctx       contextAPI.Client
+}
+
+// Initialize sets the context which signs discovery requests
+func (s *selectionService) Initialize(ctx contextAPI.Channel) error {
+	s.ctx = ctx
+	return nil
+}
+
+// GetEndorsersForChaincode returns a set of peers which satisfies one of the endorsement layouts for a transaction
+// which invokes the given chaincodes. The first chaincode ID is the chaincode invoked by the client and the others
+// are chaincodes which it calls. The collections accessed by each chaincode may be provided with options.WithCollections.
+func (s *selectionService) GetEndorsersForChaincode(chaincodeIDs []string, opts ...copts.Opt) ([]fab.Peer, error) {
+	if len(chaincodeIDs) == 0 {
+		return nil, errors.New("no chaincode IDs provided")
+	}
+	if s.ctx == nil {
+		return nil, errors.New("selection service has not been initialized")
+	}
+
+	params := options.NewParams(opts)
+
+	var calls []discovery.ChaincodeCall
+	for _, ccID := range chaincodeIDs {
+		calls = append(calls, discovery.ChaincodeCall{ID: ccID, Collections: params.Collections[ccID]})
+	}
+
+	value, err := s.provider.endorsers.Get(newCacheKey(s.ctx, s.channelID, calls))
+	if err != nil {
+		return nil, err
+	}
+	desc, err := value.(*lazyref.Reference).Get()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("Error getting endorsement layouts for chaincodes [%v] on channel [%s]", chaincodeIDs, s.channelID))
+	}
+
+	return desc.(*endorsementDescriptor).endorsers(params.PeerFilter)
+}
+
+// endorsementDescriptor holds the discovered endorsement layouts along with the peers of each group
+type endorsementDescriptor struct {
+	layouts []map[string]uint32
+	groups  map[string][]*discovery.Peer
+}
+
+// endorsers returns the peers of the first layout which can be satisfied by the peers accepted by
+// the filter. The peers with the highest block heights are chosen from each group.
+func (d *endorsementDescriptor) endorsers(filter options.PeerFilter) ([]fab.Peer, error) {
+	for _, layout := range d.layouts {
+		if peers, ok := d.satisfy(layout, filter); ok {
+			return peers, nil
+		}
+	}
+	return nil, errors.New("no endorsement layout can be satisfied by the available peers")
+}
+
+func (d *endorsementDescriptor) satisfy(layout map[string]uint32, filter options.PeerFilter) ([]fab.Peer, bool) {
+	var endorsers []fab.Peer
+	for group, quantity := range layout {
+		var candidates []*discovery.Peer
+		for _, peer := range d.groups[group] {
+			if filter != nil && !filter(peer) {
+				logger.Debugf("Peer [%s] is not accepted by the filter", peer.URL())
+				continue
+			}
+			candidates = append(candidates, peer)
+		}
+		if uint32(len(candidates)) < quantity {
+			logger.Debugf("Group [%s] has %d peers but %d are required", group, len(candidates), quantity)
+			return nil, false
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].BlockHeight() > candidates[j].BlockHeight()
+		})
+		for _, peer := range candidates[:quantity] {
+			if !containsPeer(endorsers, peer) {
+				endorsers = append(endorsers, peer)
+			}
+		}
+	}
+	return endorsers, true
+}
+
+func containsPeer(peers []fab.Peer, peer fab.Peer) bool {
+	for _, p := range peers {
+		if p.URL() == peer.URL() {
+			return true
+		}
+	}
+	return false
+}
+
+func queryEndorsers(ctx contextAPI.Client, channelID string, calls []discovery.ChaincodeCall) (*endorsementDescriptor, error) {
+	targets, err := discovery.Targets(ctx.Config(), channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := discovery.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := reqContext.WithTimeout(reqContext.Background(), ctx.Config().TimeoutOrDefault(core.PeerResponse))
+	defer cancel()
+
+	response, err := client.Send(reqCtx, discovery.NewRequest().AddEndorsersQuery(channelID, calls...), targets...)
+	if err != nil {
+		return nil, err
+	}
+	descriptor, err := response.EndorsersAt(0)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &endorsementDescriptor{groups: make(map[string][]*discovery.Peer)}
+	for group, groupPeers := range descriptor.EndorsersByGroups {
+		for _, p := range groupPeers.Peers {
+			info, err := discovery.ParsePeer(p)
+			if err != nil {
+				logger.Warnf("Ignoring invalid peer returned by discovery service: %s", err)
+				continue
+			}
+			peer, err := discovery.NewPeer(ctx, info)
+			if err != nil {
+				return nil, err
+			}
+			d.groups[group] = append(d.groups[group], peer)
+		}
+	}
+	for _, layout := range descriptor.Layouts {
+		d.layouts = append(d.layouts, layout.QuantitiesByGroup)
+	}
+
+	logger.Debugf("Discovered %d endorsement layouts for chaincode [%s] on channel [%s]", len(d.layouts), descriptor.Chaincode, channelID)
+	return d, nil
+}
+
+// cacheKey holds the channel, the chaincode calls and the context of the
+// identity which queries the discovery service
+type cacheKey struct {
+	key       string
+	channelID string
+	calls     []discovery.ChaincodeCall
+	ctx       contextAPI.Client
+}
+
+func newCacheKey(ctx contextAPI.Client, channelID string, calls []discovery.ChaincodeCall) *cacheKey {
+	identifier := ctx.Identifier()
+
+	var ccs []string
+	for _, call := range calls {
+		ccs = append(ccs, fmt.Sprintf("%s:%s", call.ID, strings.Join(call.Collections, ",")))
+	}
+
+	return &cacheKey{
+		key:       fmt.Sprintf("%s_%s_%s_%s", channelID, identifier.MSPID, identifier.ID, strings.Join(ccs, ";")),
+		channelID: channelID,
+		calls:     calls,
+		ctx:       ctx,
+	}
+}
+
+// String returns the key as a string
+func (k *cacheKey) String() string {
+	return k.key
+}
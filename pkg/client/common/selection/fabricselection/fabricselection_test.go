@@ -0,0 +1,145 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fabricselection
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/selection/options"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+)
+
+const (
+	channelID = "mychannel"
+	peer1     = "peer0.org1.example.com:7051"
+	peer2     = "peer1.org1.example.com:7051"
+	peer3     = "peer0.org2.example.com:7051"
+)
+
+type serviceInit interface {
+	Initialize(context context.Channel) error
+}
+
+func TestGetEndorsersForChaincode(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetEndorsers("cc1", &discpb.EndorsementDescriptor{
+		Chaincode: "cc1",
+		EndorsersByGroups: map[string]*discpb.Peers{
+			"G1": {Peers: []*discpb.Peer{
+				mocks.NewDiscoveryPeer("Org1MSP", peer1, 10, "cc1"),
+				mocks.NewDiscoveryPeer("Org1MSP", peer2, 12, "cc1"),
+			}},
+			"G2": {Peers: []*discpb.Peer{
+				mocks.NewDiscoveryPeer("Org2MSP", peer3, 11, "cc1"),
+			}},
+		},
+		Layouts: []*discpb.Layout{
+			{QuantitiesByGroup: map[string]uint32{"G1": 1, "G2": 1}},
+			{QuantitiesByGroup: map[string]uint32{"G1": 2}},
+		},
+	})
+
+	provider, err := New()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	_, err = provider.CreateSelectionService("")
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	service, err := provider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+
+	_, err = service.GetEndorsersForChaincode([]string{"cc1"})
+	assert.Error(t, err, "expecting error for uninitialized service")
+
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(address)))
+
+	_, err = service.GetEndorsersForChaincode(nil)
+	assert.Error(t, err, "expecting error for missing chaincode IDs")
+
+	// The peer with the highest block height is chosen from G1
+	peers, err := service.GetEndorsersForChaincode([]string{"cc1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{peer3, peer2}, urls(peers))
+
+	// Excluding Org2 leaves the second layout
+	org1Only := options.WithPeerFilter(func(peer fab.Peer) bool { return peer.MSPID() == "Org1MSP" })
+	peers, err = service.GetEndorsersForChaincode([]string{"cc1"}, org1Only)
+	require.NoError(t, err)
+	assert.Equal(t, []string{peer1, peer2}, urls(peers))
+
+	_, err = service.GetEndorsersForChaincode([]string{"cc1"}, options.WithPeerFilter(func(peer fab.Peer) bool { return false }))
+	assert.Error(t, err, "expecting error when no layout can be satisfied")
+
+	assert.Equal(t, 1, server.RequestCount(), "expecting endorsement layouts to be cached")
+
+	// Collections are part of the query and therefore the cache key
+	_, err = service.GetEndorsersForChaincode([]string{"cc1"}, options.WithCollections("cc1", "coll1"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.RequestCount())
+
+	_, err = service.GetEndorsersForChaincode([]string{"cc2"})
+	assert.Error(t, err, "expecting error for chaincode without endorsement descriptor")
+}
+
+func TestGetEndorsersForChaincodeError(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetError(errors.New("access denied"))
+
+	provider, err := New()
+	require.NoError(t, err)
+	defer provider.Close()
+
+	service, err := provider.CreateSelectionService(channelID)
+	require.NoError(t, err)
+	require.NoError(t, service.(serviceInit).Initialize(newChannelContext(address)))
+
+	_, err = service.GetEndorsersForChaincode([]string{"cc1"})
+	assert.Error(t, err)
+}
+
+func urls(peers []fab.Peer) []string {
+	var result []string
+	for _, peer := range peers {
+		result = append(result, peer.URL())
+	}
+	sort.Strings(result)
+	return result
+}
+
+// testConfig returns the mock discovery server as the only peer of the channel and
+// no configuration for the discovered peers
+type testConfig struct {
+	core.Config
+	address string
+}
+
+func (c *testConfig) ChannelPeers(name string) ([]core.ChannelPeer, error) {
+	return []core.ChannelPeer{{NetworkPeer: core.NetworkPeer{PeerConfig: core.PeerConfig{URL: "grpc://" + c.address}, MSPID: "Org1MSP"}}}, nil
+}
+
+func (c *testConfig) PeerConfigByURL(url string) (*core.PeerConfig, error) {
+	return nil, errors.Errorf("no peer config for [%s]", url)
+}
+
+func newChannelContext(address string) *mocks.MockChannelContext {
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP"))
+	ctx.SetConfig(&testConfig{Config: mocks.NewMockConfig(), address: address})
+	return mocks.NewMockChannelContext(ctx, channelID)
+}
@@ -20,6 +20,8 @@ type PeerFilter func(peer fab.Peer) bool
 // Params defines the parameters of a selection service request
 type Params struct {
 	PeerFilter PeerFilter
+	// Collections holds the private data collections accessed by each chaincode
+	Collections map[string][]string
 }
 
 // NewParams creates new parameters based on the provided options
@@ -47,3 +49,27 @@ func (p *Params) SetPeerFilter(value PeerFilter) {
 	logger.Debugf("PeerFilter: %#v", value)
 	p.PeerFilter = value
 }
+
+// WithCollections sets the private data collections which are accessed by the given chaincode, so that
+// the peers selected for endorsement are members of the collections. (Note that not all selection
+// services take collections into account.)
+func WithCollections(chaincodeID string, collectionNames ...string) copts.Opt {
+	return func(p copts.Params) {
+		if setter, ok := p.(collectionsSetter); ok {
+			setter.SetCollections(chaincodeID, collectionNames)
+		}
+	}
+}
+
+type collectionsSetter interface {
+	SetCollections(chaincodeID string, collectionNames []string)
+}
+
+// SetCollections sets the collections accessed by the given chaincode
+func (p *Params) SetCollections(chaincodeID string, collectionNames []string) {
+	logger.Debugf("Collections for chaincode [%s]: %v", chaincodeID, collectionNames)
+	if p.Collections == nil {
+		p.Collections = make(map[string][]string)
+	}
+	p.Collections[chaincodeID] = append(p.Collections[chaincodeID], collectionNames...)
+}
@@ -12,6 +12,34 @@ type AttributeRequest struct {
 	Optional bool
 }
 
+// CSRInfo is Certificate Signing Request (CSR) Information.
+// The common name of the CSR is always the enrollment ID.
+type CSRInfo struct {
+	// Names are the subject names of the certificate
+	Names []CSRName
+	// Hosts are the host names of the certificate. If omitted, the local host name is used.
+	Hosts []string
+	// KeyRequest is the algorithm and size of the generated key. If omitted, an ECDSA P-256 key is generated.
+	KeyRequest *KeyRequest
+	// SerialNumber is the serial number of the certificate subject
+	SerialNumber string
+}
+
+// CSRName contains the subject name fields of a certificate
+type CSRName struct {
+	C  string // Country
+	ST string // State
+	L  string // Locality
+	O  string // OrganisationName
+	OU string // OrganisationalUnitName
+}
+
+// KeyRequest contains the algorithm (e.g. "ecdsa") and size (e.g. 256) of a key to generate
+type KeyRequest struct {
+	Algo string
+	Size int
+}
+
 // RegistrationRequest defines the attributes required to register a user with the CA
 type RegistrationRequest struct {
 	// Name is the unique name of the identity
@@ -38,6 +66,8 @@ type Attribute struct {
 	Name  string
 	Key   string
 	Value string
+	// ECert adds the attribute to the enrollment certificate by default
+	ECert bool
 }
 
 // RevocationRequest defines the attributes required to revoke credentials with the CA
@@ -72,3 +102,95 @@ type RevokedCert struct {
 	// AKI of the revoked certificate
 	AKI string
 }
+
+// IdentityRequest defines the attributes of an identity which are modified on the CA
+type IdentityRequest struct {
+	// ID is the unique name of the identity
+	ID string
+	// Type of identity (e.g. "peer, app, user").
+	// If omitted, the type is not modified.
+	Type string
+	// MaxEnrollments is the number of times the secret can be reused to enroll.
+	// If omitted, the maximum number of enrollments is not modified.
+	MaxEnrollments int
+	// The identity's affiliation e.g. org1.department1.
+	// If omitted, the affiliation is not modified.
+	Affiliation string
+	// Attributes associated with this identity.
+	// If omitted, the attributes are not modified.
+	Attributes []Attribute
+	// CAName is the name of the CA to connect to
+	CAName string
+	// Secret is the new enrollment secret of the identity.
+	// If omitted, the secret is not modified.
+	Secret string
+}
+
+// RemoveIdentityRequest defines the attributes required to remove an identity from the CA
+type RemoveIdentityRequest struct {
+	// ID is the unique name of the identity
+	ID string
+	// Force forces the removal of the caller's own identity
+	Force bool
+	// CAName is the name of the CA to connect to
+	CAName string
+}
+
+// IdentityResponse represents an identity returned by the CA
+type IdentityResponse struct {
+	// ID is the unique name of the identity
+	ID string
+	// Type of identity (e.g. "peer, app, user")
+	Type string
+	// MaxEnrollments is the number of times the secret can be reused to enroll
+	MaxEnrollments int
+	// The identity's affiliation e.g. org1.department1
+	Affiliation string
+	// Attributes associated with this identity
+	Attributes []Attribute
+	// CAName is the name of the CA which returned the identity
+	CAName string
+	// Secret is the enrollment secret of the identity, which is only returned if it was modified
+	Secret string
+}
+
+// AffiliationRequest defines the attributes required to add or remove an affiliation on the CA
+type AffiliationRequest struct {
+	// Name is the name of the affiliation e.g. org1.department1
+	Name string
+	// Force creates the parent affiliations if they don't exist when adding an affiliation.
+	// When removing an affiliation, Force also removes its sub-affiliations and identities.
+	Force bool
+	// CAName is the name of the CA to connect to
+	CAName string
+}
+
+// ModifyAffiliationRequest defines the attributes required to rename an affiliation on the CA
+type ModifyAffiliationRequest struct {
+	AffiliationRequest
+	// NewName is the new name of the affiliation. Force must be set if identities are affected by the change.
+	NewName string
+}
+
+// AffiliationResponse contains the response of an affiliation request
+type AffiliationResponse struct {
+	AffiliationInfo
+	// CAName is the name of the CA which returned the response
+	CAName string
+}
+
+// AffiliationInfo contains an affiliation along with its sub-affiliations and the identities that belong to it
+type AffiliationInfo struct {
+	Name         string
+	Affiliations []AffiliationInfo
+	Identities   []IdentityInfo
+}
+
+// IdentityInfo contains information about an identity
+type IdentityInfo struct {
+	ID             string
+	Type           string
+	Affiliation    string
+	Attributes     []Attribute
+	MaxEnrollments int
+}
@@ -0,0 +1,100 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// GetCAInfoResponse contains the name, certificate chain and version of a CA
+type GetCAInfoResponse struct {
+	// CAName is the name of the CA
+	CAName string
+	// CAChain is the PEM-encoded certificate chain of the CA, starting with the root CA certificate
+	CAChain []byte
+	// RootCertificates are the PEM-encoded root CA certificates of the chain
+	RootCertificates [][]byte
+	// IntermediateCertificates are the PEM-encoded intermediate CA certificates of the chain
+	IntermediateCertificates [][]byte
+	// Version of the CA server
+	Version string
+}
+
+// VerifyCertificate checks that the PEM-encoded certificate was issued by the CA,
+// i.e. that it chains up to a root certificate of the CA through its intermediate certificates.
+func (r *GetCAInfoResponse) VerifyCertificate(certPEM []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return errors.New("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse certificate")
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, rootCert := range r.RootCertificates {
+		opts.Roots.AppendCertsFromPEM(rootCert)
+	}
+	for _, intermediateCert := range r.IntermediateCertificates {
+		opts.Intermediates.AppendCertsFromPEM(intermediateCert)
+	}
+
+	if _, err := cert.Verify(opts); err != nil {
+		return errors.Wrapf(err, "certificate was not issued by CA [%s]", r.CAName)
+	}
+	return nil
+}
+
+// StoreCACerts writes the root and intermediate certificates of the CA to the
+// cacerts and intermediatecerts folders of the MSP directory, one certificate per file.
+func (r *GetCAInfoResponse) StoreCACerts(mspDir string) error {
+	if err := storeCerts(filepath.Join(mspDir, "cacerts"), r.certFileName(), r.RootCertificates); err != nil {
+		return errors.WithMessage(err, "failed to store root CA certificates")
+	}
+	if err := storeCerts(filepath.Join(mspDir, "intermediatecerts"), r.certFileName(), r.IntermediateCertificates); err != nil {
+		return errors.WithMessage(err, "failed to store intermediate CA certificates")
+	}
+	return nil
+}
+
+func (r *GetCAInfoResponse) certFileName() string {
+	if r.CAName == "" {
+		return "ca"
+	}
+	return r.CAName
+}
+
+func storeCerts(dir string, name string, certs [][]byte) error {
+	if len(certs) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", dir)
+	}
+	for i, cert := range certs {
+		fileName := name + "-cert.pem"
+		if i > 0 {
+			fileName = fmt.Sprintf("%s-cert-%d.pem", name, i)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, fileName), cert, 0644); err != nil {
+			return errors.Wrapf(err, "failed to write %s", fileName)
+		}
+	}
+	return nil
+}
@@ -79,10 +79,15 @@ func newCAClient(ctx context.Client, orgName string) (mspapi.CAClient, error) {
 
 // enrollmentOptions represent enrollment options
 type enrollmentOptions struct {
-	secret string
+	secret   string
+	caName   string
+	attrReqs []*mspapi.AttributeRequest
+	profile  string
+	label    string
+	csr      *mspapi.CSRInfo
 }
 
-// EnrollmentOption describes a functional parameter for Enroll
+// EnrollmentOption describes a functional parameter for Enroll and Reenroll
 type EnrollmentOption func(*enrollmentOptions) error
 
 // WithSecret enrollment option
@@ -93,6 +98,78 @@ func WithSecret(secret string) EnrollmentOption {
 	}
 }
 
+// WithCAName enrollment option sets the name of the CA which issues the certificate.
+// If it is the ID or name of another CA configured for the organization, the request is sent to that CA,
+// and the credentials it issues are stored under the enrollment ID qualified with the ID of the CA
+// (enrollmentID@caID) so that they don't replace the credentials issued by the default CA.
+func WithCAName(caName string) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		o.caName = caName
+		return nil
+	}
+}
+
+// WithAttributeRequests enrollment option requests attributes to be added to the certificate.
+// An attribute is added only if the identity owns it, unless the request is optional.
+func WithAttributeRequests(attrReqs ...AttributeRequest) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		for _, attrReq := range attrReqs {
+			if attrReq.Name == "" {
+				return errors.New("attribute name is required")
+			}
+			o.attrReqs = append(o.attrReqs, &mspapi.AttributeRequest{Name: attrReq.Name, Optional: attrReq.Optional})
+		}
+		return nil
+	}
+}
+
+// WithProfile enrollment option sets the signing profile used to issue the certificate (e.g. "tls")
+func WithProfile(profile string) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		o.profile = profile
+		return nil
+	}
+}
+
+// WithLabel enrollment option sets the label of the CA key used in HSM operations
+func WithLabel(label string) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		o.label = label
+		return nil
+	}
+}
+
+// WithCSR enrollment option sets the host names, subject names and key request of the certificate signing request
+func WithCSR(csr *CSRInfo) EnrollmentOption {
+	return func(o *enrollmentOptions) error {
+		if csr == nil {
+			return errors.New("CSR info is required")
+		}
+		o.csr = &mspapi.CSRInfo{
+			Hosts:        csr.Hosts,
+			SerialNumber: csr.SerialNumber,
+		}
+		for _, name := range csr.Names {
+			o.csr.Names = append(o.csr.Names, mspapi.CSRName(name))
+		}
+		if csr.KeyRequest != nil {
+			o.csr.KeyRequest = &mspapi.KeyRequest{Algo: csr.KeyRequest.Algo, Size: csr.KeyRequest.Size}
+		}
+		return nil
+	}
+}
+
+func prepareEnrollmentOpts(opts ...EnrollmentOption) (enrollmentOptions, error) {
+	eo := enrollmentOptions{}
+	for _, param := range opts {
+		err := param(&eo)
+		if err != nil {
+			return eo, err
+		}
+	}
+	return eo, nil
+}
+
 // Enroll enrolls a registered user in order to receive a signed X509 certificate.
 // A new key pair is generated for the user. The private key and the
 // enrollment certificate issued by the CA are stored in SDK stores.
@@ -102,28 +179,51 @@ func WithSecret(secret string) EnrollmentOption {
 // opts represent enrollment options
 func (c *Client) Enroll(enrollmentID string, opts ...EnrollmentOption) error {
 
-	eo := enrollmentOptions{}
-	for _, param := range opts {
-		err := param(&eo)
-		if err != nil {
-			return errors.WithMessage(err, "failed to enroll")
-		}
+	eo, err := prepareEnrollmentOpts(opts...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to enroll")
 	}
 
 	ca, err := newCAClient(c.ctx, c.orgName)
 	if err != nil {
 		return err
 	}
-	return ca.Enroll(enrollmentID, eo.secret)
+	req := &mspapi.EnrollmentRequest{
+		Name:     enrollmentID,
+		Secret:   eo.secret,
+		CAName:   eo.caName,
+		AttrReqs: eo.attrReqs,
+		Profile:  eo.profile,
+		Label:    eo.label,
+		CSR:      eo.csr,
+	}
+	return ca.EnrollWithRequest(req)
 }
 
 // Reenroll reenrolls an enrolled user in order to obtain a new signed X509 certificate
-func (c *Client) Reenroll(enrollmentID string) error {
+//
+// enrollmentID enrollment ID of an enrolled user
+// opts represent enrollment options, of which WithSecret doesn't apply
+func (c *Client) Reenroll(enrollmentID string, opts ...EnrollmentOption) error {
+
+	eo, err := prepareEnrollmentOpts(opts...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to reenroll")
+	}
+
 	ca, err := newCAClient(c.ctx, c.orgName)
 	if err != nil {
 		return err
 	}
-	return ca.Reenroll(enrollmentID)
+	req := &mspapi.ReenrollmentRequest{
+		Name:     enrollmentID,
+		CAName:   eo.caName,
+		AttrReqs: eo.attrReqs,
+		Profile:  eo.profile,
+		Label:    eo.label,
+		CSR:      eo.csr,
+	}
+	return ca.ReenrollWithRequest(req)
 }
 
 // Register registers a User with the Fabric CA
@@ -180,6 +280,300 @@ func (c *Client) Revoke(request *RevocationRequest) (*RevocationResponse, error)
 	}, nil
 }
 
+// requestOptions contains options for CA requests
+type requestOptions struct {
+	CA string
+}
+
+// RequestOption func for each Opts argument
+type RequestOption func(ctx context.Client, opts *requestOptions) error
+
+// WithCA allows for specifying optional CA name.
+// If it is the ID or name of another CA configured for the organization, the request is sent to that CA.
+func WithCA(caname string) RequestOption {
+	return func(ctx context.Client, o *requestOptions) error {
+		o.CA = caname
+		return nil
+	}
+}
+
+func (c *Client) prepareRequestOpts(options ...RequestOption) (requestOptions, error) {
+	opts := requestOptions{}
+	for _, option := range options {
+		err := option(c.ctx, &opts)
+		if err != nil {
+			return opts, errors.WithMessage(err, "failed to read request opts")
+		}
+	}
+	return opts, nil
+}
+
+// GetIdentity retrieves information about an identity from the Fabric CA
+// id: The ID of the identity
+// options: WithCA to query a CA other than the default CA
+func (c *Client) GetIdentity(id string, options ...RequestOption) (*IdentityResponse, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ca.GetIdentity(id, opts.CA)
+	if err != nil {
+		return nil, err
+	}
+	return getIdentityResponse(resp), nil
+}
+
+// GetAllIdentities returns all of the identities that the registrar is authorized to see
+// options: WithCA to query a CA other than the default CA
+func (c *Client) GetAllIdentities(options ...RequestOption) ([]*IdentityResponse, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ca.GetAllIdentities(opts.CA)
+	if err != nil {
+		return nil, err
+	}
+
+	var identities []*IdentityResponse
+	for _, identity := range resp {
+		identities = append(identities, getIdentityResponse(identity))
+	}
+	return identities, nil
+}
+
+// ModifyIdentity modifies the type, affiliation, attributes, maximum enrollments
+// and/or secret of an identity on the Fabric CA
+// request: Identity Request
+func (c *Client) ModifyIdentity(request *IdentityRequest) (*IdentityResponse, error) {
+	if request == nil {
+		return nil, errors.New("identity request is required")
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []mspapi.Attribute
+	for i := range request.Attributes {
+		attributes = append(attributes, mspapi.Attribute(request.Attributes[i]))
+	}
+	r := mspapi.IdentityRequest{
+		ID:             request.ID,
+		Type:           request.Type,
+		MaxEnrollments: request.MaxEnrollments,
+		Affiliation:    request.Affiliation,
+		Attributes:     attributes,
+		CAName:         request.CAName,
+		Secret:         request.Secret,
+	}
+	resp, err := ca.ModifyIdentity(&r)
+	if err != nil {
+		return nil, err
+	}
+	return getIdentityResponse(resp), nil
+}
+
+// RemoveIdentity removes an identity from the Fabric CA
+// request: Remove Identity Request
+func (c *Client) RemoveIdentity(request *RemoveIdentityRequest) (*IdentityResponse, error) {
+	if request == nil {
+		return nil, errors.New("remove identity request is required")
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	req := mspapi.RemoveIdentityRequest(*request)
+	resp, err := ca.RemoveIdentity(&req)
+	if err != nil {
+		return nil, err
+	}
+	return getIdentityResponse(resp), nil
+}
+
+// GetAffiliation returns an affiliation along with its sub-affiliations and identities
+// affiliation: The name of the affiliation e.g. org1.department1
+// options: WithCA to query a CA other than the default CA
+func (c *Client) GetAffiliation(affiliation string, options ...RequestOption) (*AffiliationResponse, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ca.GetAffiliation(affiliation, opts.CA)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliationResponse(resp), nil
+}
+
+// GetAllAffiliations returns the tree of affiliations that the registrar is authorized to see
+// options: WithCA to query a CA other than the default CA
+func (c *Client) GetAllAffiliations(options ...RequestOption) (*AffiliationResponse, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ca.GetAllAffiliations(opts.CA)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliationResponse(resp), nil
+}
+
+// AddAffiliation adds an affiliation to the Fabric CA
+// request: Affiliation Request
+func (c *Client) AddAffiliation(request *AffiliationRequest) (*AffiliationResponse, error) {
+	if request == nil {
+		return nil, errors.New("affiliation request is required")
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	req := mspapi.AffiliationRequest(*request)
+	resp, err := ca.AddAffiliation(&req)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliationResponse(resp), nil
+}
+
+// ModifyAffiliation renames an affiliation on the Fabric CA
+// request: Modify Affiliation Request
+func (c *Client) ModifyAffiliation(request *ModifyAffiliationRequest) (*AffiliationResponse, error) {
+	if request == nil {
+		return nil, errors.New("modify affiliation request is required")
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	req := mspapi.ModifyAffiliationRequest{
+		AffiliationRequest: mspapi.AffiliationRequest(request.AffiliationRequest),
+		NewName:            request.NewName,
+	}
+	resp, err := ca.ModifyAffiliation(&req)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliationResponse(resp), nil
+}
+
+// RemoveAffiliation removes an affiliation from the Fabric CA
+// request: Affiliation Request
+func (c *Client) RemoveAffiliation(request *AffiliationRequest) (*AffiliationResponse, error) {
+	if request == nil {
+		return nil, errors.New("affiliation request is required")
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	req := mspapi.AffiliationRequest(*request)
+	resp, err := ca.RemoveAffiliation(&req)
+	if err != nil {
+		return nil, err
+	}
+	return getAffiliationResponse(resp), nil
+}
+
+// GetCAInfo returns the name, certificate chain and version of the CA.
+// The certificate chain can be used to populate the cacerts and intermediatecerts
+// folders of an MSP directory, and to verify certificates issued by the CA.
+// options: WithCA to query a CA other than the default CA
+func (c *Client) GetCAInfo(options ...RequestOption) (*GetCAInfoResponse, error) {
+	opts, err := c.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := newCAClient(c.ctx, c.orgName)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ca.GetCAInfo(opts.CA)
+	if err != nil {
+		return nil, err
+	}
+	return &GetCAInfoResponse{
+		CAName:                   resp.CAName,
+		CAChain:                  resp.CAChain,
+		RootCertificates:         resp.RootCertificates,
+		IntermediateCertificates: resp.IntermediateCertificates,
+		Version:                  resp.Version,
+	}, nil
+}
+
+func getAffiliationResponse(resp *mspapi.AffiliationResponse) *AffiliationResponse {
+	return &AffiliationResponse{
+		AffiliationInfo: getAffiliationInfo(resp.AffiliationInfo),
+		CAName:          resp.CAName,
+	}
+}
+
+func getAffiliationInfo(info mspapi.AffiliationInfo) AffiliationInfo {
+	result := AffiliationInfo{Name: info.Name}
+	for _, affiliation := range info.Affiliations {
+		result.Affiliations = append(result.Affiliations, getAffiliationInfo(affiliation))
+	}
+	for _, identity := range info.Identities {
+		var attributes []Attribute
+		for i := range identity.Attributes {
+			attributes = append(attributes, Attribute(identity.Attributes[i]))
+		}
+		result.Identities = append(result.Identities, IdentityInfo{
+			ID:             identity.ID,
+			Type:           identity.Type,
+			Affiliation:    identity.Affiliation,
+			Attributes:     attributes,
+			MaxEnrollments: identity.MaxEnrollments,
+		})
+	}
+	return result
+}
+
+func getIdentityResponse(resp *mspapi.IdentityResponse) *IdentityResponse {
+	var attributes []Attribute
+	for i := range resp.Attributes {
+		attributes = append(attributes, Attribute(resp.Attributes[i]))
+	}
+	return &IdentityResponse{
+		ID:             resp.ID,
+		Type:           resp.Type,
+		MaxEnrollments: resp.MaxEnrollments,
+		Affiliation:    resp.Affiliation,
+		Attributes:     attributes,
+		CAName:         resp.CAName,
+		Secret:         resp.Secret,
+	}
+}
+
 // GetSigningIdentity returns signing identity for id
 func (c *Client) GetSigningIdentity(id string) (mspctx.SigningIdentity, error) {
 	im, _ := c.ctx.IdentityManager(c.orgName)
@@ -16,6 +16,7 @@ import (
 
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
@@ -133,6 +134,256 @@ func TestMSP(t *testing.T) {
 
 }
 
+// TestEnrollmentOptions tests that enrollment options are sent to the CA
+func TestEnrollmentOptions(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	err = msp.Enroll(randomUsername(), WithSecret("enrollmentSecret"), WithAttributeRequests(AttributeRequest{}))
+	if err == nil {
+		t.Fatalf("Enroll should return error for attribute request without name")
+	}
+
+	err = msp.Enroll(randomUsername(), WithSecret("enrollmentSecret"), WithCSR(nil))
+	if err == nil {
+		t.Fatalf("Enroll should return error for nil CSR info")
+	}
+
+	enrollUsername := randomUsername()
+	err = msp.Enroll(enrollUsername,
+		WithSecret("enrollmentSecret"),
+		WithCAName("ca.org1.example.com"),
+		WithProfile("tls"),
+		WithLabel("label1"),
+		WithAttributeRequests(AttributeRequest{Name: "attr1"}, AttributeRequest{Name: "attr2", Optional: true}),
+		WithCSR(&CSRInfo{Hosts: []string{"host1.example.com"}, KeyRequest: &KeyRequest{Algo: "ecdsa", Size: 384}}),
+	)
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	checkEnrollmentRequest(t, "ca.org1.example.com", "tls", "label1", 2, "host1.example.com")
+
+	err = msp.Reenroll(enrollUsername, WithProfile("ca"), WithAttributeRequests(AttributeRequest{Name: "attr1"}))
+	if err != nil {
+		t.Fatalf("Reenroll return error %v", err)
+	}
+	checkEnrollmentRequest(t, "ca.org1.example.com", "ca", "", 1, "")
+}
+
+func checkEnrollmentRequest(t *testing.T, caName, profile, label string, numAttrReqs int, hosts string) {
+	req := caServer.LastEnrollmentRequest()
+	if req == nil {
+		t.Fatalf("CA server didn't receive an enrollment request")
+	}
+	if req.CAName != caName {
+		t.Fatalf("Expected CA name %s, got %s", caName, req.CAName)
+	}
+	if req.Profile != profile || req.Label != label {
+		t.Fatalf("Unexpected profile and label: %s, %s", req.Profile, req.Label)
+	}
+	if len(req.AttrReqs) != numAttrReqs {
+		t.Fatalf("Expected %d attribute requests, got %d", numAttrReqs, len(req.AttrReqs))
+	}
+	if strings.Join(req.Hosts, ",") != hosts {
+		t.Fatalf("Unexpected hosts: %v", req.Hosts)
+	}
+}
+
+// TestGetCAInfo tests retrieval of the CA chain and its use for MSP directories and certificate validation
+func TestGetCAInfo(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	resp, err := msp.GetCAInfo()
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+	if resp.CAName != "ca.org1.example.com" || len(resp.RootCertificates) != 1 {
+		t.Fatalf("Unexpected CA info: %s with %d root certificates", resp.CAName, len(resp.RootCertificates))
+	}
+
+	// Certificates issued by the CA are validated against the chain
+	enrollUsername := randomUsername()
+	err = msp.Enroll(enrollUsername, WithSecret("enrollmentSecret"))
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	enrolledUser, err := msp.GetSigningIdentity(enrollUsername)
+	if err != nil {
+		t.Fatalf("Expected to find user")
+	}
+	if err = resp.VerifyCertificate(enrolledUser.EnrollmentCertificate()); err != nil {
+		t.Fatalf("VerifyCertificate return error %v", err)
+	}
+	if err = resp.VerifyCertificate([]byte("invalid")); err == nil {
+		t.Fatalf("VerifyCertificate should fail for invalid certificate")
+	}
+	if err = (&GetCAInfoResponse{CAName: "other"}).VerifyCertificate(enrolledUser.EnrollmentCertificate()); err == nil {
+		t.Fatalf("VerifyCertificate should fail for certificate issued by another CA")
+	}
+
+	// The chain is stored in the MSP directory
+	mspDir, err := ioutil.TempDir("", "msp")
+	if err != nil {
+		t.Fatalf("failed to create MSP directory: %v", err)
+	}
+	defer os.RemoveAll(mspDir)
+	if err = resp.StoreCACerts(mspDir); err != nil {
+		t.Fatalf("StoreCACerts return error %v", err)
+	}
+	rootCert, err := ioutil.ReadFile(filepath.Join(mspDir, "cacerts", "ca.org1.example.com-cert.pem"))
+	if err != nil || string(rootCert) != string(resp.RootCertificates[0]) {
+		t.Fatalf("Expected root CA certificate in MSP directory: %v", err)
+	}
+
+	// Another CA of the organization
+	resp, err = msp.GetCAInfo(WithCA("tlsca.org1.example.com"))
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+	if resp.CAName != "tlsca.org1" {
+		t.Fatalf("Expected CA name tlsca.org1, got %s", resp.CAName)
+	}
+}
+
+// TestIdentityManagement is a unit test for Client identity management scenarios
+func TestIdentityManagement(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	if _, err := msp.ModifyIdentity(nil); err == nil {
+		t.Fatalf("ModifyIdentity should return error for nil request")
+	}
+	if _, err := msp.RemoveIdentity(nil); err == nil {
+		t.Fatalf("RemoveIdentity should return error for nil request")
+	}
+
+	username := randomUsername()
+	_, err = msp.Register(&RegistrationRequest{Name: username, Type: "client", Affiliation: "org1"})
+	if err != nil {
+		t.Fatalf("Register return error %v", err)
+	}
+
+	identity, err := msp.GetIdentity(username, WithCA("ca.org1.example.com"))
+	if err != nil {
+		t.Fatalf("GetIdentity return error %v", err)
+	}
+	if identity.ID != username || identity.Affiliation != "org1" || identity.CAName != "ca.org1.example.com" {
+		t.Fatalf("GetIdentity returned wrong identity: %+v", identity)
+	}
+
+	identity, err = msp.ModifyIdentity(&IdentityRequest{ID: username, Type: "peer", Attributes: []Attribute{{Key: "attr1", Value: "value1", ECert: true}}})
+	if err != nil {
+		t.Fatalf("ModifyIdentity return error %v", err)
+	}
+	if identity.Type != "peer" || identity.Affiliation != "org1" || len(identity.Attributes) != 1 || identity.Attributes[0].Value != "value1" || !identity.Attributes[0].ECert {
+		t.Fatalf("ModifyIdentity returned wrong identity: %+v", identity)
+	}
+
+	identities, err := msp.GetAllIdentities()
+	if err != nil {
+		t.Fatalf("GetAllIdentities return error %v", err)
+	}
+	if len(identities) == 0 {
+		t.Fatalf("GetAllIdentities should return registered identity")
+	}
+
+	_, err = msp.RemoveIdentity(&RemoveIdentityRequest{ID: username})
+	if err != nil {
+		t.Fatalf("RemoveIdentity return error %v", err)
+	}
+	if _, err := msp.GetIdentity(username); err == nil {
+		t.Fatalf("GetIdentity should return error for removed identity")
+	}
+}
+
+// TestAffiliationManagement is a unit test for Client affiliation management scenarios
+func TestAffiliationManagement(t *testing.T) {
+
+	f := textFixture{}
+	sdk := f.setup()
+	defer f.close()
+
+	msp, err := New(sdk.Context())
+	if err != nil {
+		t.Fatalf("failed to create CA client: %v", err)
+	}
+
+	if _, err := msp.AddAffiliation(nil); err == nil {
+		t.Fatalf("AddAffiliation should return error for nil request")
+	}
+	if _, err := msp.ModifyAffiliation(nil); err == nil {
+		t.Fatalf("ModifyAffiliation should return error for nil request")
+	}
+
+	dept := "org2." + randomUsername()
+	resp, err := msp.AddAffiliation(&AffiliationRequest{Name: dept})
+	if err != nil {
+		t.Fatalf("AddAffiliation return error %v", err)
+	}
+	if resp.Name != dept {
+		t.Fatalf("AddAffiliation returned wrong affiliation: %+v", resp)
+	}
+
+	resp, err = msp.GetAffiliation("org2", WithCA("ca.org1.example.com"))
+	if err != nil {
+		t.Fatalf("GetAffiliation return error %v", err)
+	}
+	if resp.CAName != "ca.org1.example.com" {
+		t.Fatalf("GetAffiliation returned wrong CA name: %s", resp.CAName)
+	}
+	found := false
+	for _, affiliation := range resp.Affiliations {
+		if affiliation.Name == dept {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("GetAffiliation didn't return sub-affiliation %s", dept)
+	}
+
+	if _, err = msp.GetAllAffiliations(); err != nil {
+		t.Fatalf("GetAllAffiliations return error %v", err)
+	}
+
+	newDept := dept + "renamed"
+	resp, err = msp.ModifyAffiliation(&ModifyAffiliationRequest{AffiliationRequest: AffiliationRequest{Name: dept}, NewName: newDept})
+	if err != nil {
+		t.Fatalf("ModifyAffiliation return error %v", err)
+	}
+	if resp.Name != newDept {
+		t.Fatalf("ModifyAffiliation returned wrong affiliation: %+v", resp)
+	}
+
+	if _, err = msp.RemoveAffiliation(&AffiliationRequest{Name: newDept}); err != nil {
+		t.Fatalf("RemoveAffiliation return error %v", err)
+	}
+	if _, err = msp.GetAffiliation(newDept); err == nil {
+		t.Fatalf("GetAffiliation should return error for removed affiliation")
+	}
+}
+
 type textFixture struct {
 	config core.Config
 }
@@ -0,0 +1,355 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/verifier"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// defaultInventoryParallelism is the number of peers queried concurrently when not set by WithParallelism
+const defaultInventoryParallelism = 10
+
+// InconsistencyType identifies a kind of inconsistency found in an inventory
+type InconsistencyType string
+
+const (
+	// ChannelNotJoined indicates that a peer hasn't joined a channel which other peers of its organization have joined
+	ChannelNotJoined InconsistencyType = "ChannelNotJoined"
+	// ChaincodeVersionMismatch indicates that the peers of a channel report different versions of an instantiated chaincode
+	ChaincodeVersionMismatch InconsistencyType = "ChaincodeVersionMismatch"
+	// ChaincodeNotInstalled indicates that a peer of a channel doesn't have the instantiated version of a chaincode installed
+	ChaincodeNotInstalled InconsistencyType = "ChaincodeNotInstalled"
+)
+
+// Inconsistency describes an inconsistency between peers found in an inventory
+type Inconsistency struct {
+	Type      InconsistencyType
+	Target    string // the peer concerned, if any
+	ChannelID string
+	Chaincode string // the chaincode concerned, if any
+	Details   string
+}
+
+// PeerChannelInventory is the state of a channel on a peer
+type PeerChannelInventory struct {
+	ChannelID              string
+	Height                 uint64
+	CurrentBlockHash       []byte
+	InstantiatedChaincodes []*pb.ChaincodeInfo
+	// Err is the error returned by the ledger or instantiated chaincodes queries, if any
+	Err error
+}
+
+// PeerInventory is the state of a peer: the channels that it has joined and the chaincodes that are installed on it
+type PeerInventory struct {
+	Target              string
+	MSPID               string
+	InstalledChaincodes []*pb.ChaincodeInfo
+	Channels            []*PeerChannelInventory
+	// Err is the error returned by the channels or installed chaincodes queries, if any
+	Err error
+}
+
+// Channel returns the state of the given channel on the peer, or nil if the peer hasn't joined the channel
+func (p *PeerInventory) Channel(channelID string) *PeerChannelInventory {
+	for _, ch := range p.Channels {
+		if ch.ChannelID == channelID {
+			return ch
+		}
+	}
+	return nil
+}
+
+// Inventory is a consolidated report of the channels and chaincodes of a set of peers
+type Inventory struct {
+	Peers           []*PeerInventory
+	Inconsistencies []Inconsistency
+}
+
+// QueryInventory queries the joined channels, installed chaincodes, ledger height and instantiated
+// chaincodes of each channel of every peer and reports the inconsistencies between the peers.
+// The peers are queried concurrently. A peer which fails to respond is reported with an error and
+// is excluded from the consistency checks.
+// Valid options are WithTargets, WithTargetURLs, WithTargetFilter, WithParallelism, WithTimeout and
+// WithParentContext. If no targets are provided then all of the peers in the network are queried.
+func (rc *Client) QueryInventory(options ...RequestOption) (*Inventory, error) {
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get opts for QueryInventory")
+	}
+	rc.resolveTimeouts(&opts)
+
+	// Unlike other requests, the default targets are the peers of all organizations
+	targets := opts.Targets
+	if targets == nil {
+		targets, err = rc.discovery.GetPeers()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to discover peers")
+		}
+	}
+	targets = filterTargets(targets, opts.TargetFilter)
+	if len(targets) == 0 {
+		return nil, errors.WithStack(status.New(status.ClientStatus, status.NoPeersFound.ToInt32(), "no targets available", nil))
+	}
+
+	parallelism := opts.Parallelism
+	if parallelism == 0 {
+		parallelism = defaultInventoryParallelism
+	}
+
+	verifiers := &channelVerifiers{rc: rc, verifiers: make(map[string]channel.ResponseVerifier)}
+	inventory := &Inventory{Peers: make([]*PeerInventory, len(targets))}
+	semaphore := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, target fab.Peer) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			inventory.Peers[i] = rc.queryPeerInventory(target, opts, verifiers)
+		}(i, target)
+	}
+	wg.Wait()
+
+	inventory.Inconsistencies = findInconsistencies(inventory.Peers)
+	return inventory, nil
+}
+
+func (rc *Client) queryPeerInventory(target fab.Peer, opts requestOptions, verifiers *channelVerifiers) *PeerInventory {
+	inventory := &PeerInventory{Target: target.URL(), MSPID: target.MSPID()}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.PeerResponse)
+	channels, err := resource.QueryChannels(reqCtx, target)
+	cancel()
+	if err != nil {
+		inventory.Err = errors.WithMessage(err, "failed to query channels")
+		return inventory
+	}
+
+	reqCtx, cancel = rc.createRequestContext(opts, core.PeerResponse)
+	installed, err := resource.QueryInstalledChaincodes(reqCtx, target)
+	cancel()
+	if err != nil {
+		inventory.Err = errors.WithMessage(err, "failed to query installed chaincodes")
+		return inventory
+	}
+	inventory.InstalledChaincodes = installed.Chaincodes
+
+	for _, ch := range channels.Channels {
+		inventory.Channels = append(inventory.Channels, rc.queryPeerChannelInventory(ch.ChannelId, target, opts, verifiers))
+	}
+	return inventory
+}
+
+func (rc *Client) queryPeerChannelInventory(channelID string, target fab.Peer, opts requestOptions, verifiers *channelVerifiers) *PeerChannelInventory {
+	inventory := &PeerChannelInventory{ChannelID: channelID}
+
+	sv, err := verifiers.get(channelID)
+	if err != nil {
+		inventory.Err = err
+		return inventory
+	}
+	l, err := channel.NewLedger(channelID)
+	if err != nil {
+		inventory.Err = err
+		return inventory
+	}
+	targets := []fab.ProposalProcessor{target}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.PeerResponse)
+	info, err := l.QueryInfo(reqCtx, targets, sv)
+	cancel()
+	if err != nil {
+		inventory.Err = errors.WithMessage(err, "failed to query ledger info")
+		return inventory
+	}
+	if bci := info[0].BCI; bci != nil {
+		inventory.Height = bci.Height
+		inventory.CurrentBlockHash = bci.CurrentBlockHash
+	}
+
+	reqCtx, cancel = rc.createRequestContext(opts, core.PeerResponse)
+	instantiated, err := l.QueryInstantiatedChaincodes(reqCtx, targets, sv)
+	cancel()
+	if err != nil {
+		inventory.Err = errors.WithMessage(err, "failed to query instantiated chaincodes")
+		return inventory
+	}
+	inventory.InstantiatedChaincodes = instantiated[0].Chaincodes
+	return inventory
+}
+
+// channelVerifiers creates the response verifier of each channel once
+type channelVerifiers struct {
+	rc        *Client
+	mutex     sync.Mutex
+	verifiers map[string]channel.ResponseVerifier
+}
+
+func (v *channelVerifiers) get(channelID string) (channel.ResponseVerifier, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if sv, ok := v.verifiers[channelID]; ok {
+		return sv, nil
+	}
+
+	channelService, err := v.rc.ctx.ChannelProvider().ChannelService(v.rc.ctx, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "Unable to get channel service")
+	}
+	membership, err := channelService.Membership()
+	if err != nil {
+		return nil, errors.WithMessage(err, "membership creation failed")
+	}
+
+	sv := &verifier.Signature{Membership: membership}
+	v.verifiers[channelID] = sv
+	return sv, nil
+}
+
+// findInconsistencies compares the peers which responded successfully
+func findInconsistencies(peers []*PeerInventory) []Inconsistency {
+	var inconsistencies []Inconsistency
+
+	// Channels joined by each organization's peers
+	orgChannels := make(map[string]map[string]bool)
+	// Peers of each channel
+	channelPeers := make(map[string][]*PeerInventory)
+	var channelIDs []string
+	for _, peer := range peers {
+		if peer.Err != nil {
+			continue
+		}
+		if orgChannels[peer.MSPID] == nil {
+			orgChannels[peer.MSPID] = make(map[string]bool)
+		}
+		for _, ch := range peer.Channels {
+			orgChannels[peer.MSPID][ch.ChannelID] = true
+			if _, ok := channelPeers[ch.ChannelID]; !ok {
+				channelIDs = append(channelIDs, ch.ChannelID)
+			}
+			channelPeers[ch.ChannelID] = append(channelPeers[ch.ChannelID], peer)
+		}
+	}
+	sort.Strings(channelIDs)
+
+	for _, peer := range peers {
+		if peer.Err != nil {
+			continue
+		}
+		for _, channelID := range sortedKeys(orgChannels[peer.MSPID]) {
+			if peer.Channel(channelID) == nil {
+				inconsistencies = append(inconsistencies, Inconsistency{
+					Type:      ChannelNotJoined,
+					Target:    peer.Target,
+					ChannelID: channelID,
+					Details:   fmt.Sprintf("other peers of %s have joined the channel", peer.MSPID),
+				})
+			}
+		}
+	}
+
+	for _, channelID := range channelIDs {
+		inconsistencies = append(inconsistencies, findChaincodeInconsistencies(channelID, channelPeers[channelID])...)
+	}
+	return inconsistencies
+}
+
+func findChaincodeInconsistencies(channelID string, peers []*PeerInventory) []Inconsistency {
+	var inconsistencies []Inconsistency
+
+	// Versions of each instantiated chaincode, along with the peers which reported them
+	versions := make(map[string]map[string][]string)
+	for _, peer := range peers {
+		ch := peer.Channel(channelID)
+		if ch.Err != nil {
+			continue
+		}
+		for _, cc := range ch.InstantiatedChaincodes {
+			if versions[cc.Name] == nil {
+				versions[cc.Name] = make(map[string][]string)
+			}
+			versions[cc.Name][cc.Version] = append(versions[cc.Name][cc.Version], peer.Target)
+		}
+	}
+
+	var ccNames []string
+	for ccName := range versions {
+		ccNames = append(ccNames, ccName)
+	}
+	sort.Strings(ccNames)
+
+	for _, ccName := range ccNames {
+		ccVersions := versions[ccName]
+		if len(ccVersions) > 1 {
+			var details []string
+			for version, targets := range ccVersions {
+				details = append(details, fmt.Sprintf("%s on %v", version, targets))
+			}
+			sort.Strings(details)
+			inconsistencies = append(inconsistencies, Inconsistency{
+				Type:      ChaincodeVersionMismatch,
+				ChannelID: channelID,
+				Chaincode: ccName,
+				Details:   fmt.Sprintf("instantiated versions differ: %v", details),
+			})
+		}
+	}
+
+	for _, peer := range peers {
+		ch := peer.Channel(channelID)
+		if ch.Err != nil {
+			continue
+		}
+		for _, cc := range ch.InstantiatedChaincodes {
+			if !isInstalled(peer.InstalledChaincodes, cc) {
+				inconsistencies = append(inconsistencies, Inconsistency{
+					Type:      ChaincodeNotInstalled,
+					Target:    peer.Target,
+					ChannelID: channelID,
+					Chaincode: cc.Name,
+					Details:   fmt.Sprintf("version %s is instantiated but not installed", cc.Version),
+				})
+			}
+		}
+	}
+	return inconsistencies
+}
+
+func isInstalled(installed []*pb.ChaincodeInfo, cc *pb.ChaincodeInfo) bool {
+	for _, i := range installed {
+		if i.Name == cc.Name && i.Version == cc.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
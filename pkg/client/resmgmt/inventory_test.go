@@ -0,0 +1,144 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	reqContext "context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestQueryInventory(t *testing.T) {
+	v1 := []*pb.ChaincodeInfo{{Name: "examplecc", Path: "path", Version: "v1"}}
+	v2 := []*pb.ChaincodeInfo{{Name: "examplecc", Path: "path", Version: "v2"}}
+
+	peer1 := newInventoryPeer("http://peer1.org1.com", "Org1MSP", []string{"mychannel", "otherchannel"}, v1, v1)
+	peer2 := newInventoryPeer("http://peer2.org1.com", "Org1MSP", []string{"mychannel"}, v1, v1)
+	peer3 := newInventoryPeer("http://peer1.org2.com", "Org2MSP", []string{"mychannel"}, nil, v2)
+	peer4 := newInventoryPeer("http://peer2.org2.com", "Org2MSP", nil, nil, nil)
+	peer4.Error = errors.New("peer unavailable")
+
+	rc := setupRolloutClient(t, "Org1MSP", []fab.Peer{peer1, peer2, peer3, peer4})
+
+	inventory, err := rc.QueryInventory(WithParallelism(2))
+	require.NoError(t, err)
+	require.Len(t, inventory.Peers, 4)
+
+	p1 := inventory.Peers[0]
+	assert.Equal(t, "http://peer1.org1.com", p1.Target)
+	assert.Equal(t, "Org1MSP", p1.MSPID)
+	assert.NoError(t, p1.Err)
+	assert.Equal(t, "v1", p1.InstalledChaincodes[0].Version)
+	require.Len(t, p1.Channels, 2)
+	ch := p1.Channel("otherchannel")
+	require.NotNil(t, ch)
+	assert.NoError(t, ch.Err)
+	assert.Equal(t, uint64(10), ch.Height)
+	assert.Equal(t, []byte("hash"), ch.CurrentBlockHash)
+	assert.Equal(t, "v1", ch.InstantiatedChaincodes[0].Version)
+	assert.Nil(t, p1.Channel("unknownchannel"))
+
+	assert.Error(t, inventory.Peers[3].Err, "expecting error for unavailable peer")
+
+	assert.Equal(t, []Inconsistency{
+		{Type: ChannelNotJoined, Target: "http://peer2.org1.com", ChannelID: "otherchannel", Details: "other peers of Org1MSP have joined the channel"},
+		{Type: ChaincodeVersionMismatch, ChannelID: "mychannel", Chaincode: "examplecc", Details: "instantiated versions differ: [v1 on [http://peer1.org1.com http://peer2.org1.com] v2 on [http://peer1.org2.com]]"},
+		{Type: ChaincodeNotInstalled, Target: "http://peer1.org2.com", ChannelID: "mychannel", Chaincode: "examplecc", Details: "version v2 is instantiated but not installed"},
+	}, inventory.Inconsistencies)
+
+	// Targets
+	inventory, err = rc.QueryInventory(WithTargets(peer1, peer2))
+	require.NoError(t, err)
+	require.Len(t, inventory.Peers, 2)
+	require.Len(t, inventory.Inconsistencies, 1)
+	assert.Equal(t, ChannelNotJoined, inventory.Inconsistencies[0].Type)
+
+	_, err = rc.QueryInventory(WithTargetFilter(&mspFilter{mspID: "Org3MSP"}))
+	assert.Error(t, err, "expecting error for no targets")
+
+	_, err = rc.QueryInventory(WithParallelism(-1))
+	assert.Error(t, err, "expecting error for negative parallelism")
+}
+
+// inventoryPeer responds to the queries of an inventory according to the function invoked
+type inventoryPeer struct {
+	*fcmocks.MockPeer
+	channels     []string
+	installed    []*pb.ChaincodeInfo
+	instantiated []*pb.ChaincodeInfo
+}
+
+func newInventoryPeer(url, mspID string, channels []string, installed, instantiated []*pb.ChaincodeInfo) *inventoryPeer {
+	return &inventoryPeer{
+		MockPeer:     &fcmocks.MockPeer{MockName: url, MockURL: url, MockMSP: mspID, Status: http.StatusOK},
+		channels:     channels,
+		installed:    installed,
+		instantiated: instantiated,
+	}
+}
+
+func (p *inventoryPeer) ProcessTransactionProposal(ctx reqContext.Context, tp fab.ProcessProposalRequest) (*fab.TransactionProposalResponse, error) {
+	if p.Error != nil {
+		return nil, p.Error
+	}
+
+	function, err := proposalFunction(tp.SignedProposal)
+	if err != nil {
+		return nil, err
+	}
+
+	var response proto.Message
+	switch function {
+	case "GetChannels":
+		channels := &pb.ChannelQueryResponse{}
+		for _, channelID := range p.channels {
+			channels.Channels = append(channels.Channels, &pb.ChannelInfo{ChannelId: channelID})
+		}
+		response = channels
+	case "getinstalledchaincodes":
+		response = &pb.ChaincodeQueryResponse{Chaincodes: p.installed}
+	case "getchaincodes":
+		response = &pb.ChaincodeQueryResponse{Chaincodes: p.instantiated}
+	case "GetChainInfo":
+		response = &common.BlockchainInfo{Height: 10, CurrentBlockHash: []byte("hash")}
+	default:
+		return nil, errors.Errorf("unexpected function [%s]", function)
+	}
+
+	payload, err := proto.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	p.Payload = payload
+	return p.MockPeer.ProcessTransactionProposal(ctx, tp)
+}
+
+func proposalFunction(signedProposal *pb.SignedProposal) (string, error) {
+	proposal := &pb.Proposal{}
+	if err := proto.Unmarshal(signedProposal.ProposalBytes, proposal); err != nil {
+		return "", err
+	}
+	payload := &pb.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(proposal.Payload, payload); err != nil {
+		return "", err
+	}
+	cis := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(payload.Input, cis); err != nil {
+		return "", err
+	}
+	return string(cis.ChaincodeSpec.Input.Args[0]), nil
+}
@@ -39,6 +39,7 @@ type chaincodeDeployRequest struct {
 	Name       string
 	Path       string
 	Version    string
+	Lang       pb.ChaincodeSpec_Type
 	Args       [][]byte
 	Policy     *common.SignaturePolicyEnvelope
 	CollConfig []*common.CollectionConfig
@@ -51,8 +52,13 @@ func createChaincodeDeployProposal(txh fab.TransactionHeader, deploy chaincodePr
 	args := [][]byte{}
 	args = append(args, []byte(channelID))
 
+	lang := chaincode.Lang
+	if lang == pb.ChaincodeSpec_UNDEFINED {
+		lang = pb.ChaincodeSpec_GOLANG
+	}
+
 	ccds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{
-		Type: pb.ChaincodeSpec_GOLANG, ChaincodeId: &pb.ChaincodeID{Name: chaincode.Name, Path: chaincode.Path, Version: chaincode.Version},
+		Type: lang, ChaincodeId: &pb.ChaincodeID{Name: chaincode.Name, Path: chaincode.Path, Version: chaincode.Version},
 		Input: &pb.ChaincodeInput{Args: chaincode.Args}}}
 	ccdsBytes, err := protos_utils.Marshal(ccds)
 	if err != nil {
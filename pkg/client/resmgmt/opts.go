@@ -60,6 +60,17 @@ func WithTargetFilter(targetFilter fab.TargetFilter) RequestOption {
 	}
 }
 
+// WithParallelism sets the maximum number of peers that are queried concurrently
+func WithParallelism(parallelism int) RequestOption {
+	return func(ctx context.Client, opts *requestOptions) error {
+		if parallelism < 0 {
+			return errors.New("parallelism must not be negative")
+		}
+		opts.Parallelism = parallelism
+		return nil
+	}
+}
+
 //WithTimeout encapsulates key value pairs of timeout type, timeout duration to Options
 //if not provided, default timeout configuration from config will be used
 func WithTimeout(timeoutType core.TimeoutType, timeout time.Duration) RequestOption {
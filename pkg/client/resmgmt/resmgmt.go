@@ -8,6 +8,7 @@ SPDX-License-Identifier: Apache-2.0
 package resmgmt
 
 import (
+	"bytes"
 	reqContext "context"
 	"io"
 	"io/ioutil"
@@ -23,15 +24,18 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/chconfig"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/signedcds"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/configtxgen"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/configupdate"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
-	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -42,6 +46,9 @@ type InstallCCRequest struct {
 	Path    string
 	Version string
 	Package *api.CCPackage
+	// SignedPackage is installed instead of Package. The name, path and version
+	// of the chaincode are taken from the signed package if not provided.
+	SignedPackage *signedcds.Package
 }
 
 // InstallCCResponse contains install chaincode response status
@@ -56,6 +63,7 @@ type InstantiateCCRequest struct {
 	Name       string
 	Path       string
 	Version    string
+	Lang       pb.ChaincodeSpec_Type // Chaincode language (defaults to Go)
 	Args       [][]byte
 	Policy     *common.SignaturePolicyEnvelope
 	CollConfig []*common.CollectionConfig
@@ -71,6 +79,7 @@ type UpgradeCCRequest struct {
 	Name       string
 	Path       string
 	Version    string
+	Lang       pb.ChaincodeSpec_Type // Chaincode language (defaults to Go)
 	Args       [][]byte
 	Policy     *common.SignaturePolicyEnvelope
 	CollConfig []*common.CollectionConfig
@@ -88,6 +97,7 @@ type requestOptions struct {
 	Orderer       fab.Orderer                        // use specific orderer
 	Timeouts      map[core.TimeoutType]time.Duration //timeout options for resmgmt operations
 	ParentContext reqContext.Context                 //parent grpc context for resmgmt operations
+	Parallelism   int                                // maximum number of peers queried concurrently
 }
 
 //SaveChannelRequest used to save channel request
@@ -95,8 +105,11 @@ type SaveChannelRequest struct {
 	ChannelID         string
 	ChannelConfig     io.Reader             // ChannelConfig data source
 	ChannelConfigPath string                // Convenience option to use the named file as ChannelConfig reader
+	ChannelProfile    *configtxgen.Profile  // Profile used to generate the channel config in-process if no ChannelConfig is provided
 	SigningIdentities []msp.SigningIdentity // Users that sign channel configuration
-	// TODO: support pre-signed signature blocks
+	// Signatures are signatures over the channel configuration that were collected beforehand, for
+	// example from the administrators of other organizations (see configupdate.Pending)
+	Signatures []*common.ConfigSignature
 }
 
 // SaveChannelResponse contains response parameters for Save
@@ -317,7 +330,12 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	// For each peer query if chaincode installed. If cc is installed treat as success with message 'already installed'.
 	// If cc is not installed try to install, and if that fails add to the list with error and peer name.
 
-	err := checkRequiredInstallCCParams(req)
+	req, signedPackage, err := resolveSignedPackage(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkRequiredInstallCCParams(req)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +404,7 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 	reqCtx, cancel := contextImpl.NewRequest(rc.ctx, contextImpl.WithTimeoutType(core.ResMgmt), contextImpl.WithParent(parentReqCtx))
 	defer cancel()
 
-	icr := api.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package}
+	icr := api.InstallChaincodeRequest{Name: req.Name, Path: req.Path, Version: req.Version, Package: req.Package, SignedPackage: signedPackage}
 	transactionProposalResponse, _, err := resource.InstallChaincode(reqCtx, icr, peer.PeersToTxnProcessors(newTargets))
 	for _, v := range transactionProposalResponse {
 		logger.Debugf("Install chaincode '%s' endorser '%s' returned ProposalResponse status:%v", req.Name, v.Endorser, v.Status)
@@ -408,12 +426,40 @@ func (rc *Client) InstallCC(req InstallCCRequest, options ...RequestOption) ([]I
 }
 
 func checkRequiredInstallCCParams(req InstallCCRequest) error {
-	if req.Name == "" || req.Version == "" || req.Path == "" || req.Package == nil {
+	if req.Name == "" || req.Version == "" || req.Path == "" || (req.Package == nil && req.SignedPackage == nil) {
 		return errors.New("Chaincode name, version, path and chaincode package are required")
 	}
 	return nil
 }
 
+// resolveSignedPackage fills in the chaincode name, path and version from the signed package, if
+// provided, and returns the package envelope
+func resolveSignedPackage(req InstallCCRequest) (InstallCCRequest, *common.Envelope, error) {
+	if req.SignedPackage == nil {
+		return req, nil, nil
+	}
+
+	ccID := req.SignedPackage.ChaincodeID()
+	if req.Name == "" {
+		req.Name = ccID.Name
+	}
+	if req.Path == "" {
+		req.Path = ccID.Path
+	}
+	if req.Version == "" {
+		req.Version = ccID.Version
+	}
+	if req.Name != ccID.Name || req.Path != ccID.Path || req.Version != ccID.Version {
+		return req, nil, errors.Errorf("chaincode [%s:%s:%s] doesn't match the signed package [%s:%s:%s]", req.Name, req.Path, req.Version, ccID.Name, ccID.Path, ccID.Version)
+	}
+
+	envelope, err := req.SignedPackage.Envelope()
+	if err != nil {
+		return req, nil, errors.WithMessage(err, "failed to create signed chaincode package envelope")
+	}
+	return req, envelope, nil
+}
+
 // InstantiateCC instantiates chaincode using default settings
 func (rc *Client) InstantiateCC(channelID string, req InstantiateCCRequest, options ...RequestOption) (InstantiateCCResponse, error) {
 
@@ -472,6 +518,64 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		return nil, err
 	}
 
+	target, responseVerifier, err := rc.channelQueryTarget(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := channel.NewLedger(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.PeerResponse)
+	defer cancel()
+
+	responses, err := l.QueryInstantiatedChaincodes(reqCtx, []fab.ProposalProcessor{target}, responseVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// QueryCollectionsConfig queries the private data collection configurations which the given chaincode was
+// instantiated (or last upgraded) with on the given channel.
+// Valid option is WithTarget. If not specified it will query any peer on this channel
+func (rc *Client) QueryCollectionsConfig(channelID string, chaincodeName string, options ...RequestOption) (*common.CollectionConfigPackage, error) {
+	if chaincodeName == "" {
+		return nil, errors.New("chaincode name is required")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	target, responseVerifier, err := rc.channelQueryTarget(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := channel.NewLedger(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.PeerResponse)
+	defer cancel()
+
+	responses, err := l.QueryCollectionsConfig(reqCtx, chaincodeName, []fab.ProposalProcessor{target}, responseVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses[0], nil
+}
+
+// channelQueryTarget returns the target of a channel query, which is the first target in the options
+// or a random channel peer, and the verifier of the response signature
+func (rc *Client) channelQueryTarget(channelID string, opts requestOptions) (fab.ProposalProcessor, channel.ResponseVerifier, error) {
 	var target fab.ProposalProcessor
 	if len(opts.Targets) >= 1 {
 		target = opts.Targets[0]
@@ -479,12 +583,12 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		// discover peers on this channel
 		discovery, err := rc.ctx.DiscoveryProvider().CreateDiscoveryService(channelID)
 		if err != nil {
-			return nil, errors.WithMessage(err, "failed to create channel discovery service")
+			return nil, nil, errors.WithMessage(err, "failed to create channel discovery service")
 		}
 		// default filter will be applied (if any)
 		targets, err := rc.getDefaultTargets(discovery)
 		if err != nil {
-			return nil, errors.WithMessage(err, "failed to get default target for query instantiated chaincodes")
+			return nil, nil, errors.WithMessage(err, "failed to get default target for channel query")
 		}
 
 		// select random channel peer
@@ -492,31 +596,18 @@ func (rc *Client) QueryInstantiatedChaincodes(channelID string, options ...Reque
 		target = targets[randomNumber]
 	}
 
-	l, err := channel.NewLedger(channelID)
-	if err != nil {
-		return nil, err
-	}
-
-	reqCtx, cancel := rc.createRequestContext(opts, core.PeerResponse)
-	defer cancel()
-
 	// Channel service membership is required to verify signature
 	channelService, err := rc.ctx.ChannelProvider().ChannelService(rc.ctx, channelID)
 	if err != nil {
-		return nil, errors.WithMessage(err, "Unable to get channel service")
+		return nil, nil, errors.WithMessage(err, "Unable to get channel service")
 	}
 
 	membership, err := channelService.Membership()
 	if err != nil {
-		return nil, errors.WithMessage(err, "membership creation failed")
+		return nil, nil, errors.WithMessage(err, "membership creation failed")
 	}
 
-	responses, err := l.QueryInstantiatedChaincodes(reqCtx, []fab.ProposalProcessor{target}, &verifier.Signature{Membership: membership})
-	if err != nil {
-		return nil, err
-	}
-
-	return responses[0], nil
+	return target, &verifier.Signature{Membership: membership}, nil
 }
 
 // QueryChannels queries the names of all the channels that a peer has joined.
@@ -587,7 +678,7 @@ func (rc *Client) sendCCProposal(reqCtx reqContext.Context, ccProposalType chain
 	// create a transaction proposal for chaincode deployment
 	deployProposal := chaincodeDeployRequest(req)
 
-	txID, err := txn.NewHeader(rc.ctx, channelID)
+	txID, err := transactor.CreateTransactionHeader()
 	if err != nil {
 		return fab.EmptyTransactionID, errors.WithMessage(err, "create transaction ID failed")
 	}
@@ -704,6 +795,14 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		req.ChannelConfig = configReader
 	}
 
+	if req.ChannelConfig == nil && req.ChannelProfile != nil {
+		configReader, err := channelConfigFromProfile(req.ChannelID, req.ChannelProfile)
+		if err != nil {
+			return SaveChannelResponse{}, err
+		}
+		req.ChannelConfig = configReader
+	}
+
 	if req.ChannelID == "" || req.ChannelConfig == nil {
 		return SaveChannelResponse{}, errors.New("must provide channel ID and channel config")
 	}
@@ -720,6 +819,8 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 				signers = append(signers, id)
 			}
 		}
+	} else if len(req.Signatures) > 0 {
+		logger.Debugf("using %d pre-collected signatures", len(req.Signatures))
 	} else if rc.ctx != nil {
 		signers = append(signers, rc.ctx)
 	} else {
@@ -736,7 +837,7 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 		return SaveChannelResponse{}, errors.WithMessage(err, "extracting channel config failed")
 	}
 
-	var configSignatures []*common.ConfigSignature
+	configSignatures := append([]*common.ConfigSignature{}, req.Signatures...)
 	for _, signer := range signers {
 
 		sigCtx := contextImpl.Client{
@@ -774,6 +875,74 @@ func (rc *Client) SaveChannel(req SaveChannelRequest, options ...RequestOption)
 	return SaveChannelResponse{TransactionID: txID}, nil
 }
 
+// SubmitConfigUpdate submits a channel configuration update whose signatures were collected from the administrators
+// of the organizations. The update is first validated against the modification policies of the channel's current
+// configuration, which is retrieved from the orderer, and is only submitted if the policies are satisfied.
+// Valid request option is WithOrdererID
+func (rc *Client) SubmitConfigUpdate(pending *configupdate.Pending, options ...RequestOption) (SaveChannelResponse, error) {
+	if pending == nil || pending.ChannelID() == "" {
+		return SaveChannelResponse{}, errors.New("must provide pending config update with channel ID")
+	}
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return SaveChannelResponse{}, err
+	}
+
+	orderer, err := rc.requestOrderer(&opts, pending.ChannelID())
+	if err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "failed to find orderer for request")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.OrdererResponse)
+	defer cancel()
+
+	block, err := resource.LastConfigFromOrderer(reqCtx, pending.ChannelID(), orderer)
+	if err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "LastConfigFromOrderer failed")
+	}
+
+	// The builder validates the block; its configuration is the channel's current configuration since it isn't modified
+	current, err := configupdate.NewFromBlock(block)
+	if err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "invalid config block")
+	}
+	if current.ChannelID() != pending.ChannelID() {
+		return SaveChannelResponse{}, errors.Errorf("config block is for channel [%s] instead of [%s]", current.ChannelID(), pending.ChannelID())
+	}
+
+	if err := pending.Validate(rc.ctx.CryptoSuite(), current.Config()); err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "config update validation failed")
+	}
+
+	request := api.CreateChannelRequest{
+		Name:       pending.ChannelID(),
+		Orderer:    orderer,
+		Config:     pending.ConfigUpdate(),
+		Signatures: pending.Signatures(),
+	}
+
+	txID, err := resource.CreateChannel(reqCtx, request)
+	if err != nil {
+		return SaveChannelResponse{}, errors.WithMessage(err, "submitting config update failed")
+	}
+
+	return SaveChannelResponse{TransactionID: txID}, nil
+}
+
+// channelConfigFromProfile generates the channel creation transaction for the given profile
+func channelConfigFromProfile(channelID string, profile *configtxgen.Profile) (io.Reader, error) {
+	envelope, err := configtxgen.NewChannelCreateTx(channelID, profile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "generating channel config from profile failed")
+	}
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of channel config failed")
+	}
+	return bytes.NewReader(envelopeBytes), nil
+}
+
 func loggedClose(c io.Closer) {
 	err := c.Close()
 	if err != nil {
@@ -808,6 +977,32 @@ func (rc *Client) QueryConfigFromOrderer(channelID string, options ...RequestOpt
 
 }
 
+// QueryConfigBlockFromOrderer returns the current configuration block of the channel from the orderer.
+// The configuration in the block may be modified using a configupdate.Builder in order to produce
+// a channel configuration update which is then submitted using SaveChannel.
+// Valid request option is WithOrdererID
+func (rc *Client) QueryConfigBlockFromOrderer(channelID string, options ...RequestOption) (*common.Block, error) {
+
+	opts, err := rc.prepareRequestOpts(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	orderer, err := rc.requestOrderer(&opts, channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to find orderer for request")
+	}
+
+	reqCtx, cancel := rc.createRequestContext(opts, core.OrdererResponse)
+	defer cancel()
+
+	block, err := resource.LastConfigFromOrderer(reqCtx, channelID, orderer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "LastConfigFromOrderer failed")
+	}
+	return block, nil
+}
+
 func (rc *Client) requestOrderer(opts *requestOptions, channelID string) (fab.Orderer, error) {
 	if opts.Orderer != nil {
 		return opts.Orderer, nil
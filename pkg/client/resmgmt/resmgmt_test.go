@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package resmgmt
 
 import (
+	reqContext "context"
 	"fmt"
 	"net"
 	"net/http"
@@ -20,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/discovery/dynamicdiscovery"
 	txnmocks "github.com/hyperledger/fabric-sdk-go/pkg/client/common/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
@@ -28,9 +30,14 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/signedcds"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/collconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/configtxgen"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/configupdate"
 	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/peer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fabsdk/provider/fabpvdr"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
@@ -464,6 +471,40 @@ func TestQueryChannels(t *testing.T) {
 
 }
 
+func TestQueryCollectionsConfig(t *testing.T) {
+
+	rc := setupDefaultResMgmtClient(t)
+
+	builder, err := collconfig.NewBuilder(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = builder.Add(collconfig.Collection{Name: "collection1", Policy: collconfig.MembersPolicy("Org1MSP"), RequiredPeerCount: 1, MaxPeerCount: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	collConfig := &common.CollectionConfigPackage{Config: builder.Build()}
+	responseBytes, err := proto.Marshal(collConfig)
+	if err != nil {
+		t.Fatal("failed to marshal sample response")
+	}
+
+	peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP", Status: http.StatusOK, Payload: responseBytes}
+
+	_, err = rc.QueryCollectionsConfig("mychannel", "", WithTargets(peer))
+	if err == nil {
+		t.Fatal("QueryCollectionsConfig should have failed for missing chaincode name")
+	}
+
+	response, err := rc.QueryCollectionsConfig("mychannel", "examplecc", WithTargets(peer))
+	if err != nil {
+		t.Fatalf("failed to query collections config: %s", err)
+	}
+	if !proto.Equal(collConfig, response) {
+		t.Fatalf("unexpected collections config: %v", response)
+	}
+}
+
 func TestInstallCCWithOpts(t *testing.T) {
 
 	rc := setupDefaultResMgmtClient(t)
@@ -584,6 +625,33 @@ func TestInstallCC(t *testing.T) {
 	}
 }
 
+func TestInstallCCSignedPackage(t *testing.T) {
+	rc := setupDefaultResMgmtClient(t)
+
+	peer := fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com",
+		Status: http.StatusOK, MockRoles: []string{}, MockCert: nil, MockMSP: "Org1MSP"}
+
+	policy := cauthdsl.SignedByMspAdmin("Org1MSP")
+	signedPackage, err := signedcds.New("ID", "path", "v0", &api.CCPackage{Type: 1, Code: []byte("code")}, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Name, path and version are taken from the signed package
+	responses, err := rc.InstallCC(InstallCCRequest{SignedPackage: signedPackage}, WithTargets(&peer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(responses) != 1 || responses[0].Status != http.StatusOK {
+		t.Fatalf("Should have one successful response: %v", responses)
+	}
+
+	_, err = rc.InstallCC(InstallCCRequest{Name: "ID", Version: "v1", SignedPackage: signedPackage}, WithTargets(&peer))
+	if err == nil {
+		t.Fatal("Should have failed for version not matching the signed package")
+	}
+}
+
 func TestInstallCCRequiredParameters(t *testing.T) {
 
 	rc := setupDefaultResMgmtClient(t)
@@ -1269,6 +1337,52 @@ func setupDefaultResMgmtClient(t *testing.T) *Client {
 	return setupResMgmtClient(ctx, nil, t, getDefaultTargetFilterOption())
 }
 
+// TestDynamicDiscovery tests that the client gets its default targets from the dynamic discovery
+// provider, whose services aren't initialized with a channel context
+func TestDynamicDiscovery(t *testing.T) {
+	server, address := fcmocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetPeers(
+		fcmocks.NewDiscoveryPeer("org1", "peer0.org1.example.com:7051", 10),
+		fcmocks.NewDiscoveryPeer("org2", "peer0.org2.example.com:7051", 10),
+	)
+
+	discoveryProvider, err := dynamicdiscovery.New("org1", "user1")
+	if err != nil {
+		t.Fatalf("Failed to create discovery provider: %s", err)
+	}
+	defer discoveryProvider.Close()
+
+	ctx := fcmocks.NewMockContextWithCustomDiscovery(mspmocks.NewMockSigningIdentity("user1", "org1"), discoveryProvider)
+	ctx.SetConfig(&discoveryConfig{Config: fcmocks.NewMockConfig(), address: address})
+	if err := discoveryProvider.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize discovery provider: %s", err)
+	}
+
+	rc := setupResMgmtClient(ctx, nil, t)
+	targets, err := rc.getDefaultTargets(rc.discovery)
+	if err != nil {
+		t.Fatalf("Failed to get default targets: %s", err)
+	}
+	if len(targets) != 1 || targets[0].URL() != "peer0.org1.example.com:7051" {
+		t.Fatalf("Expecting the discovered peer of the client's organisation but got %v", targets)
+	}
+}
+
+// discoveryConfig returns the mock discovery server as the only peer of the network
+type discoveryConfig struct {
+	core.Config
+	address string
+}
+
+func (c *discoveryConfig) NetworkPeers() ([]core.NetworkPeer, error) {
+	return []core.NetworkPeer{{PeerConfig: core.PeerConfig{URL: "grpc://" + c.address}, MSPID: "org1"}}, nil
+}
+
+func (c *discoveryConfig) PeerConfigByURL(url string) (*core.PeerConfig, error) {
+	return nil, errors.Errorf("no peer config for [%s]", url)
+}
+
 func setupResMgmtClient(fabCtx context.Client, discErr error, t *testing.T, opts ...ClientOption) *Client {
 
 	ctx := createClientContext(fabCtx)
@@ -1389,6 +1503,21 @@ func TestSaveChannelSuccess(t *testing.T) {
 	resp, err = cc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelConfigPath: channelConfig}, WithOrdererURL("example.com"))
 	assert.Nil(t, err, "error should be nil")
 	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+
+	// Test valid Save Channel request (success / profile)
+	profile := &configtxgen.Profile{
+		Consortium: "SampleConsortium",
+		Application: &configtxgen.Application{
+			Organizations: []*configtxgen.Organization{{Name: "Org1MSP", ID: "Org1MSP"}},
+		},
+	}
+	resp, err = cc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelProfile: profile}, WithOrdererURL("example.com"))
+	assert.Nil(t, err, "error should be nil")
+	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+
+	// Test invalid profile
+	_, err = cc.SaveChannel(SaveChannelRequest{ChannelID: "mychannel", ChannelProfile: &configtxgen.Profile{}}, WithOrdererURL("example.com"))
+	assert.NotNil(t, err, "Should have failed for profile without application")
 }
 
 func TestSaveChannelFailure(t *testing.T) {
@@ -1494,6 +1623,152 @@ func TestJoinChannelWithInvalidOpts(t *testing.T) {
 
 }
 
+func TestQueryConfigBlockFromOrderer(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(ctx, nil, t)
+
+	builder := &fcmocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: fcmocks.MockConfigGroupBuilder{
+			ModPolicy: "Admins",
+			MSPNames:  []string{"Org1MSP"},
+		},
+		Index:           5,
+		LastConfigIndex: 5,
+	}
+
+	orderer := &mockBlockOrderer{MockOrderer: fcmocks.NewMockOrderer("", nil), block: builder.Build()}
+	defer orderer.Close()
+
+	block, err := rc.QueryConfigBlockFromOrderer("mychannel", WithOrderer(orderer))
+	assert.Nil(t, err, "QueryConfigBlockFromOrderer failed")
+	assert.Equal(t, uint64(5), block.Header.Number)
+
+	// Orderer error
+	orderer.err = errors.New("deliver error")
+	_, err = rc.QueryConfigBlockFromOrderer("mychannel", WithOrderer(orderer))
+	assert.NotNil(t, err, "Should have failed for orderer error")
+}
+
+func TestCreateChaincodeDeployProposalLang(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	txh, err := txn.NewHeader(ctx, "mychannel")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		lang     pb.ChaincodeSpec_Type
+		expected pb.ChaincodeSpec_Type
+	}{
+		{pb.ChaincodeSpec_UNDEFINED, pb.ChaincodeSpec_GOLANG},
+		{pb.ChaincodeSpec_NODE, pb.ChaincodeSpec_NODE},
+		{pb.ChaincodeSpec_JAVA, pb.ChaincodeSpec_JAVA},
+	}
+	for _, test := range tests {
+		req := chaincodeDeployRequest{Name: "cc", Path: "path", Version: "v1", Lang: test.lang, Policy: cauthdsl.SignedByMspMember("Org1MSP")}
+		proposal, err := createChaincodeDeployProposal(txh, InstantiateChaincode, "mychannel", req)
+		assert.Nil(t, err, "create deploy proposal failed")
+
+		payload := &pb.ChaincodeProposalPayload{}
+		assert.Nil(t, proto.Unmarshal(proposal.Proposal.Payload, payload))
+		cis := &pb.ChaincodeInvocationSpec{}
+		assert.Nil(t, proto.Unmarshal(payload.Input, cis))
+		ccds := &pb.ChaincodeDeploymentSpec{}
+		assert.Nil(t, proto.Unmarshal(cis.ChaincodeSpec.Input.Args[2], ccds))
+		assert.Equal(t, test.expected, ccds.ChaincodeSpec.Type)
+	}
+}
+
+func TestSubmitConfigUpdate(t *testing.T) {
+	ctx := setupTestContext("test", "Org1MSP")
+	rc := setupResMgmtClient(ctx, nil, t)
+
+	// Anybody may modify the application group but only members of Org1MSP may modify the orderer group
+	anyone := &common.Policy{
+		Type:  int32(common.Policy_SIGNATURE),
+		Value: marshalOrPanic(cauthdsl.Envelope(cauthdsl.NOutOf(0, nil), nil)),
+	}
+	org1 := &common.Policy{
+		Type:  int32(common.Policy_SIGNATURE),
+		Value: marshalOrPanic(cauthdsl.SignedByMspMember("Org1MSP")),
+	}
+	config := &common.Config{
+		ChannelGroup: &common.ConfigGroup{
+			ModPolicy: "Admins",
+			Groups: map[string]*common.ConfigGroup{
+				"Application": {ModPolicy: "Admins", Policies: map[string]*common.ConfigPolicy{"Admins": {Policy: anyone}}},
+				"Orderer":     {ModPolicy: "Admins", Policies: map[string]*common.ConfigPolicy{"Admins": {Policy: org1}}},
+			},
+		},
+	}
+
+	orderer := &mockBlockOrderer{MockOrderer: fcmocks.NewMockOrderer("", nil), block: newTestConfigBlock(t, config)}
+	defer orderer.Close()
+
+	builder, err := configupdate.New("mychannel", config)
+	assert.Nil(t, err)
+	assert.Nil(t, builder.SetValue([]string{"Application"}, "Capabilities", &common.Capabilities{}))
+	pending, err := builder.Pending()
+	assert.Nil(t, err)
+	assert.Nil(t, pending.Sign(ctx, ctx), "signing of config update failed")
+
+	resp, err := rc.SubmitConfigUpdate(pending, WithOrderer(orderer))
+	assert.Nil(t, err, "SubmitConfigUpdate failed")
+	assert.NotEmpty(t, resp.TransactionID, "transaction ID should be populated")
+
+	builder, err = configupdate.New("mychannel", config)
+	assert.Nil(t, err)
+	assert.Nil(t, builder.SetValue([]string{"Orderer"}, "Capabilities", &common.Capabilities{}))
+	pending, err = builder.Pending()
+	assert.Nil(t, err)
+	assert.Nil(t, pending.Sign(ctx, ctx), "signing of config update failed")
+
+	_, err = rc.SubmitConfigUpdate(pending, WithOrderer(orderer))
+	assert.NotNil(t, err, "Should have failed since the mod policy is not satisfied")
+
+	_, err = rc.SubmitConfigUpdate(nil, WithOrderer(orderer))
+	assert.NotNil(t, err, "Should have failed for nil config update")
+
+	// A malformed config block from the orderer
+	orderer.block.Data.Data = nil
+	_, err = rc.SubmitConfigUpdate(pending, WithOrderer(orderer))
+	assert.NotNil(t, err, "Should have failed for config block without transactions")
+}
+
+func newTestConfigBlock(t *testing.T, config *common.Config) *common.Block {
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: marshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG), ChannelId: "mychannel"}),
+		},
+		Data: marshalOrPanic(&common.ConfigEnvelope{Config: config}),
+	}
+	metadata := make([][]byte, len(common.BlockMetadataIndex_name))
+	metadata[common.BlockMetadataIndex_LAST_CONFIG] = marshalOrPanic(&common.Metadata{Value: marshalOrPanic(&common.LastConfig{Index: 2})})
+
+	return &common.Block{
+		Header:   &common.BlockHeader{Number: 2},
+		Data:     &common.BlockData{Data: [][]byte{marshalOrPanic(&common.Envelope{Payload: marshalOrPanic(payload)})}},
+		Metadata: &common.BlockMetadata{Metadata: metadata},
+	}
+}
+
+// mockBlockOrderer delivers the same block (or error) for every deliver request
+type mockBlockOrderer struct {
+	*fcmocks.MockOrderer
+	block *common.Block
+	err   error
+}
+
+func (o *mockBlockOrderer) SendDeliver(ctx reqContext.Context, envelope *fab.SignedEnvelope) (chan *common.Block, chan error) {
+	blocks := make(chan *common.Block, 1)
+	errs := make(chan error, 1)
+	if o.err != nil {
+		errs <- o.err
+		return blocks, errs
+	}
+	blocks <- o.block
+	close(blocks)
+	return blocks, errs
+}
+
 func TestSaveChannelWithMultipleSigningIdenities(t *testing.T) {
 	grpcServer := grpc.NewServer()
 	defer grpcServer.Stop()
@@ -1541,3 +1816,11 @@ func createClientContext(fabCtx context.Client) context.ClientProvider {
 		return fabCtx, nil
 	}
 }
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,247 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+)
+
+// RolloutCCRequest contains the parameters of a chaincode upgrade rollout
+type RolloutCCRequest struct {
+	// Install is the chaincode package which is installed on the channel peers of every organization
+	Install InstallCCRequest
+	// Upgrade is the upgrade request. The chaincode name, path and version are
+	// taken from the install request if not provided.
+	Upgrade UpgradeCCRequest
+}
+
+// PeerRolloutStatus contains the rollout status of a channel peer
+type PeerRolloutStatus struct {
+	Target string
+	MSPID  string
+	// Installed is true if the chaincode was installed on the peer (or was already installed)
+	Installed bool
+	// Verified is true once the installed chaincodes of the peer were queried and found to include the chaincode
+	Verified bool
+	// Err is the error of the last failed step on the peer, if any
+	Err error
+}
+
+// RolloutStatus contains the status of a rollout on each peer along with the status of the upgrade
+type RolloutStatus struct {
+	Peers []PeerRolloutStatus
+	// Upgraded is true once the upgrade transaction was committed, or if the chaincode was found
+	// to be instantiated with the new version already
+	Upgraded      bool
+	TransactionID fab.TransactionID
+}
+
+// Rollout upgrades a chaincode to a new version across the organizations of a channel.
+// The chaincode is installed on the channel peers of each organization using that organization's
+// client, after which its presence is verified on every peer. The chaincode is then upgraded using
+// the first client, which waits for the upgrade transaction to be committed.
+//
+// If a step fails then Run may be called again to resume the rollout. Peers on which the chaincode
+// was verified are skipped and the upgrade is skipped if the chaincode is already instantiated with
+// the new version, so that a new Rollout may also be used to resume a rollout which failed earlier.
+type Rollout struct {
+	channelID string
+	req       RolloutCCRequest
+	clients   []*Client
+	peers     []*PeerRolloutStatus
+	status    RolloutStatus
+}
+
+// NewRollout returns a rollout of the given chaincode on the channel. A resource management client
+// is required for each organization whose peers the chaincode is installed on.
+func NewRollout(channelID string, req RolloutCCRequest, orgClients ...*Client) (*Rollout, error) {
+	if channelID == "" {
+		return nil, errors.New("must provide channel ID")
+	}
+	if len(orgClients) == 0 {
+		return nil, errors.New("at least one organization client is required")
+	}
+
+	install, _, err := resolveSignedPackage(req.Install)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRequiredInstallCCParams(install); err != nil {
+		return nil, err
+	}
+	req.Install = install
+
+	upgrade, err := resolveUpgradeRequest(install, req.Upgrade)
+	if err != nil {
+		return nil, err
+	}
+	req.Upgrade = upgrade
+
+	return &Rollout{channelID: channelID, req: req, clients: orgClients}, nil
+}
+
+func resolveUpgradeRequest(install InstallCCRequest, upgrade UpgradeCCRequest) (UpgradeCCRequest, error) {
+	if upgrade.Name == "" {
+		upgrade.Name = install.Name
+	}
+	if upgrade.Path == "" {
+		upgrade.Path = install.Path
+	}
+	if upgrade.Version == "" {
+		upgrade.Version = install.Version
+	}
+	if upgrade.Name != install.Name || upgrade.Path != install.Path || upgrade.Version != install.Version {
+		return upgrade, errors.Errorf("upgrade of chaincode [%s:%s:%s] doesn't match the installed chaincode [%s:%s:%s]", upgrade.Name, upgrade.Path, upgrade.Version, install.Name, install.Path, install.Version)
+	}
+	if upgrade.Policy == nil {
+		return upgrade, errors.New("upgrade policy is required")
+	}
+	return upgrade, nil
+}
+
+// Run runs (or resumes) the rollout and returns its status. An error is returned if the chaincode
+// couldn't be installed or verified on all of the peers, in which case the upgrade isn't attempted,
+// or if the upgrade failed.
+// Valid options are WithTimeout and WithParentContext, which apply to each of the requests.
+func (r *Rollout) Run(options ...RequestOption) (*RolloutStatus, error) {
+	errs := multi.Errors{}
+	for _, client := range r.clients {
+		if err := r.install(client, options); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, peer := range r.peers {
+		if peer.Err != nil {
+			errs = append(errs, errors.WithMessage(peer.Err, fmt.Sprintf("rollout failed on peer [%s]", peer.Target)))
+		}
+	}
+	if err := errs.ToError(); err != nil {
+		return r.Status(), errors.WithMessage(err, "chaincode is not installed on all peers")
+	}
+
+	if err := r.upgrade(options); err != nil {
+		return r.Status(), err
+	}
+	return r.Status(), nil
+}
+
+// Status returns the current status of the rollout
+func (r *Rollout) Status() *RolloutStatus {
+	status := r.status
+	status.Peers = make([]PeerRolloutStatus, len(r.peers))
+	for i, peer := range r.peers {
+		status.Peers[i] = *peer
+	}
+	return &status
+}
+
+// install installs the chaincode on the channel peers of the client's organization and verifies that it's installed
+func (r *Rollout) install(client *Client, options []RequestOption) error {
+	discovery, err := client.ctx.DiscoveryProvider().CreateDiscoveryService(r.channelID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create channel discovery service")
+	}
+	targets, err := client.getDefaultTargets(discovery)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed to get channel peers of [%s]", client.ctx.Identifier().MSPID))
+	}
+	if len(targets) == 0 {
+		return errors.Errorf("no channel peers found for [%s]", client.ctx.Identifier().MSPID)
+	}
+
+	opts, err := client.prepareRequestOpts(options...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get opts for rollout")
+	}
+
+	for _, target := range targets {
+		peer := r.peerStatus(target)
+		if peer.Verified {
+			continue
+		}
+		peer.Err = nil
+
+		if !peer.Installed {
+			installOpts := append([]RequestOption{WithTargets(target)}, options...)
+			if _, err := client.InstallCC(r.req.Install, installOpts...); err != nil {
+				peer.Err = errors.WithMessage(err, "install failed")
+				continue
+			}
+			peer.Installed = true
+		}
+
+		reqCtx, cancel := client.createRequestContext(opts, core.PeerResponse)
+		installed, err := client.isChaincodeInstalled(reqCtx, r.req.Install, target)
+		cancel()
+		if err != nil {
+			peer.Err = errors.WithMessage(err, "verification of installed chaincode failed")
+			continue
+		}
+		if !installed {
+			peer.Err = errors.New("chaincode was not found in the installed chaincodes of the peer")
+			continue
+		}
+		peer.Verified = true
+	}
+	return nil
+}
+
+// upgrade upgrades the chaincode unless it's already instantiated with the new version
+func (r *Rollout) upgrade(options []RequestOption) error {
+	if r.status.Upgraded {
+		return nil
+	}
+
+	client := r.clients[0]
+	upgraded, err := r.isUpgraded(client, options)
+	if err != nil {
+		return errors.WithMessage(err, "failed to query instantiated chaincodes")
+	}
+	if upgraded {
+		logger.Debugf("Chaincode [%s:%s] is already instantiated on channel [%s]", r.req.Upgrade.Name, r.req.Upgrade.Version, r.channelID)
+		r.status.Upgraded = true
+		return nil
+	}
+
+	resp, err := client.UpgradeCC(r.channelID, r.req.Upgrade, options...)
+	r.status.TransactionID = resp.TransactionID
+	if err != nil {
+		return errors.WithMessage(err, "upgrade failed")
+	}
+	r.status.Upgraded = true
+	return nil
+}
+
+func (r *Rollout) isUpgraded(client *Client, options []RequestOption) (bool, error) {
+	response, err := client.QueryInstantiatedChaincodes(r.channelID, options...)
+	if err != nil {
+		return false, err
+	}
+	for _, chaincode := range response.Chaincodes {
+		if chaincode.Name == r.req.Upgrade.Name && chaincode.Version == r.req.Upgrade.Version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *Rollout) peerStatus(target fab.Peer) *PeerRolloutStatus {
+	for _, peer := range r.peers {
+		if peer.Target == target.URL() {
+			return peer
+		}
+	}
+	peer := &PeerRolloutStatus{Target: target.URL(), MSPID: target.MSPID()}
+	r.peers = append(r.peers, peer)
+	return peer
+}
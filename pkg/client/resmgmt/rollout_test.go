@@ -0,0 +1,103 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resmgmt
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	txnmocks "github.com/hyperledger/fabric-sdk-go/pkg/client/common/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fcmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestRollout(t *testing.T) {
+	// The same payload answers both the installed and the instantiated chaincodes queries
+	payload, err := proto.Marshal(&pb.ChaincodeQueryResponse{Chaincodes: []*pb.ChaincodeInfo{{Name: "examplecc", Path: "path", Version: "v2"}}})
+	require.NoError(t, err)
+
+	org1Peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.org1.com", MockMSP: "Org1MSP", Status: http.StatusOK, Payload: payload}
+	org2Peer := &fcmocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.org2.com", MockMSP: "Org2MSP", Status: http.StatusOK}
+	peers := []fab.Peer{org1Peer, org2Peer}
+
+	org1Client := setupRolloutClient(t, "Org1MSP", peers)
+	org2Client := setupRolloutClient(t, "Org2MSP", peers)
+
+	req := RolloutCCRequest{
+		Install: InstallCCRequest{Name: "examplecc", Path: "path", Version: "v2", Package: &api.CCPackage{Type: 1, Code: []byte("code")}},
+		Upgrade: UpgradeCCRequest{Policy: cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"})},
+	}
+	rollout, err := NewRollout("mychannel", req, org1Client, org2Client)
+	require.NoError(t, err)
+
+	// The install on the Org2 peer succeeds but the chaincode can't be found afterwards
+	status, err := rollout.Run()
+	assert.Error(t, err)
+	require.Len(t, status.Peers, 2)
+	assert.Equal(t, PeerRolloutStatus{Target: "http://peer1.org1.com", MSPID: "Org1MSP", Installed: true, Verified: true}, status.Peers[0])
+	assert.Equal(t, "Org2MSP", status.Peers[1].MSPID)
+	assert.True(t, status.Peers[1].Installed)
+	assert.False(t, status.Peers[1].Verified)
+	assert.Error(t, status.Peers[1].Err)
+	assert.False(t, status.Upgraded, "upgrade shouldn't be attempted until the chaincode is verified on all peers")
+
+	// Resume: the chaincode is now found on the Org2 peer and is already instantiated with the new version
+	org2Peer.Payload = payload
+	status, err = rollout.Run()
+	require.NoError(t, err)
+	for _, peer := range status.Peers {
+		assert.True(t, peer.Verified)
+		assert.NoError(t, peer.Err)
+	}
+	assert.True(t, status.Upgraded)
+	assert.Equal(t, status, rollout.Status())
+}
+
+func TestRolloutRequiredParameters(t *testing.T) {
+	client := setupRolloutClient(t, "Org1MSP", nil)
+	ccPkg := &api.CCPackage{Type: 1, Code: []byte("code")}
+	policy := cauthdsl.SignedByMspMember("Org1MSP")
+	install := InstallCCRequest{Name: "examplecc", Path: "path", Version: "v2", Package: ccPkg}
+
+	_, err := NewRollout("", RolloutCCRequest{Install: install, Upgrade: UpgradeCCRequest{Policy: policy}}, client)
+	assert.Error(t, err, "expecting error for missing channel ID")
+
+	_, err = NewRollout("mychannel", RolloutCCRequest{Install: install, Upgrade: UpgradeCCRequest{Policy: policy}})
+	assert.Error(t, err, "expecting error for missing clients")
+
+	_, err = NewRollout("mychannel", RolloutCCRequest{Install: InstallCCRequest{Name: "examplecc"}, Upgrade: UpgradeCCRequest{Policy: policy}}, client)
+	assert.Error(t, err, "expecting error for missing install parameters")
+
+	_, err = NewRollout("mychannel", RolloutCCRequest{Install: install}, client)
+	assert.Error(t, err, "expecting error for missing policy")
+
+	_, err = NewRollout("mychannel", RolloutCCRequest{Install: install, Upgrade: UpgradeCCRequest{Version: "v3", Policy: policy}}, client)
+	assert.Error(t, err, "expecting error for mismatched version")
+
+	// No channel peers for the organization
+	rollout, err := NewRollout("mychannel", RolloutCCRequest{Install: install, Upgrade: UpgradeCCRequest{Policy: policy}}, client)
+	require.NoError(t, err)
+	_, err = rollout.Run()
+	assert.Error(t, err)
+}
+
+func setupRolloutClient(t *testing.T, mspID string, peers []fab.Peer) *Client {
+	discoveryProvider, err := txnmocks.NewMockDiscoveryProvider(nil, peers)
+	require.NoError(t, err)
+
+	ctx := fcmocks.NewMockContextWithCustomDiscovery(mspmocks.NewMockSigningIdentity("admin", mspID), discoveryProvider)
+	ctx.SetConfig(getNetworkConfig(t))
+	return setupResMgmtClient(ctx, nil, t)
+}
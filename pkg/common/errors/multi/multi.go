@@ -43,6 +43,15 @@ func Append(errs error, err error) error {
 	return append(m, err)
 }
 
+// Fill sets err as the error at every index of errs, starting at the given index, and returns errs.
+// It is used by batch operations that return one error per request.
+func Fill(errs []error, from int, err error) []error {
+	for i := from; i < len(errs); i++ {
+		errs[i] = err
+	}
+	return errs
+}
+
 // ToError converts Errors to the error interface
 // returns nil if no errors are present, a single error object if only one is present
 func (errs Errors) ToError() error {
@@ -61,3 +61,17 @@ func TestToError(t *testing.T) {
 	errs = append(errs, testErr)
 	assert.Equal(t, errs, errs.ToError())
 }
+
+func TestFill(t *testing.T) {
+	testErr := fmt.Errorf("test")
+	testErr2 := fmt.Errorf("test2")
+
+	errs := Fill(make([]error, 3), 0, testErr)
+	assert.Equal(t, []error{testErr, testErr, testErr}, errs)
+
+	errs = Fill(errs, 1, testErr2)
+	assert.Equal(t, []error{testErr, testErr2, testErr2}, errs)
+
+	errs = Fill(errs, 3, testErr)
+	assert.Equal(t, []error{testErr, testErr2, testErr2}, errs)
+}
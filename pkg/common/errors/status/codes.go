@@ -51,6 +51,9 @@ const (
 	// ChaincodeError is for errors returned by Chaincode
 	ChaincodeError Code = 10
 
+	// EndorsementPolicyFailure is returned when the endorsements received by the SDK do not satisfy the chaincode's endorsement policy
+	EndorsementPolicyFailure Code = 11
+
 	// NoMatchingCertificateAuthorityEntity is if entityMatchers are unable to find any matchingCertificateAuthority
 	NoMatchingCertificateAuthorityEntity Code = 21
 
@@ -78,6 +81,7 @@ var CodeName = map[int32]string{
 	8:  "SIGNATURE_VERIFICATION_FAILED",
 	9:  "MISSING_ENDORSEMENT",
 	10: "CHAINCODE_ERROR",
+	11: "ENDORSEMENT_POLICY_FAILURE",
 	21: "NO_MATCHING_CERTIFICATE_AUTHORITY_ENTITY",
 	22: "NO_MATCHING_PEER_ENTITY",
 	23: "NO_MATCHING_ORDERER_ENTITY",
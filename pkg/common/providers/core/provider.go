@@ -19,6 +19,7 @@ import (
 type Config interface {
 	Client() (*ClientConfig, error)
 	CAConfig(org string) (*CAConfig, error)
+	CAConfigByID(caID string) (*CAConfig, error)
 	CAServerCertPems(org string) ([]string, error)
 	CAServerCertPaths(org string) ([]string, error)
 	CAClientKeyPem(org string) (string, error)
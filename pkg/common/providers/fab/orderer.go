@@ -20,6 +20,12 @@ type Orderer interface {
 	SendDeliver(ctx reqContext.Context, envelope *SignedEnvelope) (chan *common.Block, chan error)
 }
 
+// BatchBroadcaster is implemented by orderers that are able to broadcast several envelopes over
+// a single stream. The returned slices hold the status, or the error, of each envelope in order.
+type BatchBroadcaster interface {
+	SendBroadcastBatch(ctx reqContext.Context, envelopes []*SignedEnvelope) ([]*common.Status, []error)
+}
+
 // A SignedEnvelope can can be sent to an orderer for broadcasting
 type SignedEnvelope struct {
 	Payload   []byte
@@ -24,6 +24,18 @@ type Sender interface {
 	SendTransaction(tx *Transaction) (*TransactionResponse, error)
 }
 
+// BatchSender provides the ability for several transactions to be sent to the orderer together.
+// The returned slices hold the response, or the error, of each transaction in order.
+type BatchSender interface {
+	SendTransactionBatch(txs []*Transaction) ([]*TransactionResponse, []error)
+}
+
+// SignedEnvelopeSender provides the ability for a transaction envelope that was signed outside of the SDK
+// to be sent to the orderer.
+type SignedEnvelopeSender interface {
+	SendSignedEnvelope(envelope *SignedEnvelope) (*TransactionResponse, error)
+}
+
 // The Transaction object created from an endorsed proposal.
 type Transaction struct {
 	Proposal    *TransactionProposal
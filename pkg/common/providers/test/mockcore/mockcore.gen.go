@@ -102,6 +102,19 @@ func (mr *MockConfigMockRecorder) CAConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAConfig", reflect.TypeOf((*MockConfig)(nil).CAConfig), arg0)
 }
 
+// CAConfigByID mocks base method
+func (m *MockConfig) CAConfigByID(arg0 string) (*core.CAConfig, error) {
+	ret := m.ctrl.Call(m, "CAConfigByID", arg0)
+	ret0, _ := ret[0].(*core.CAConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CAConfigByID indicates an expected call of CAConfigByID
+func (mr *MockConfigMockRecorder) CAConfigByID(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CAConfigByID", reflect.TypeOf((*MockConfig)(nil).CAConfigByID), arg0)
+}
+
 // CAKeyStorePath mocks base method
 func (m *MockConfig) CAKeyStorePath() string {
 	ret := m.ctrl.Call(m, "CAKeyStorePath")
@@ -25,6 +25,26 @@ type Client struct {
 	msp.SigningIdentity
 }
 
+// credentialsSnapshotter is implemented by signing identities whose enrollment certificate and
+// private key may be replaced while they are in use, e.g. when the certificate is renewed
+type credentialsSnapshotter interface {
+	Snapshot() msp.SigningIdentity
+}
+
+// identitySnapshotter is implemented by the client contexts which can take a snapshot of their identity
+type identitySnapshotter interface {
+	identitySnapshot() msp.SigningIdentity
+}
+
+// identitySnapshot returns a snapshot of the signing identity of the client, or nil if
+// the credentials of the identity are never replaced
+func (c *Client) identitySnapshot() msp.SigningIdentity {
+	if identity, ok := c.SigningIdentity.(credentialsSnapshotter); ok {
+		return identity.Snapshot()
+	}
+	return nil
+}
+
 //Channel supplies the configuration for channel context client
 type Channel struct {
 	context.Client
@@ -59,6 +79,70 @@ func (c *Channel) ChannelID() string {
 	return c.channelID
 }
 
+// identitySnapshot returns a snapshot of the signing identity of the channel's client context
+func (c *Channel) identitySnapshot() msp.SigningIdentity {
+	if client, ok := c.Client.(identitySnapshotter); ok {
+		return client.identitySnapshot()
+	}
+	return nil
+}
+
+// requestClient is the client context of a request. It holds a snapshot of the credentials of the
+// client's identity, taken when the request was created, so that the creator serialized into the
+// request always matches the key which signs it, even if the credentials are renewed meanwhile.
+type requestClient struct {
+	context.Client
+	identity msp.SigningIdentity
+}
+
+// Identifier returns the identifier of the identity
+func (c *requestClient) Identifier() *msp.IdentityIdentifier {
+	return c.identity.Identifier()
+}
+
+// Verify a signature over some message using the identity as reference
+func (c *requestClient) Verify(msg []byte, sig []byte) error {
+	return c.identity.Verify(msg, sig)
+}
+
+// Serialize converts the identity to bytes
+func (c *requestClient) Serialize() ([]byte, error) {
+	return c.identity.Serialize()
+}
+
+// EnrollmentCertificate returns the enrollment certificate of the identity
+func (c *requestClient) EnrollmentCertificate() []byte {
+	return c.identity.EnrollmentCertificate()
+}
+
+// Sign the message
+func (c *requestClient) Sign(msg []byte) ([]byte, error) {
+	return c.identity.Sign(msg)
+}
+
+// PublicVersion returns the public parts of the identity
+func (c *requestClient) PublicVersion() msp.Identity {
+	return c.identity.PublicVersion()
+}
+
+// PrivateKey returns the private key of the identity
+func (c *requestClient) PrivateKey() core.Key {
+	return c.identity.PrivateKey()
+}
+
+// newRequestClient returns the client context to be used within a request
+func newRequestClient(client context.Client) context.Client {
+	c, ok := client.(identitySnapshotter)
+	if !ok {
+		return client
+	}
+	identity := c.identitySnapshot()
+	if identity == nil {
+		return client
+	}
+	return &requestClient{Client: client, identity: identity}
+}
+
 //Provider implementation of Providers interface
 type Provider struct {
 	config            core.Config
@@ -284,7 +368,8 @@ type requestContextOpts struct {
 	parentContext reqContext.Context
 }
 
-// NewRequest creates a request-scoped context.
+// NewRequest creates a request-scoped context. If the credentials of the client's identity may be
+// renewed, the client context of the request signs with a snapshot of the credentials taken here.
 func NewRequest(client context.Client, options ...ReqContextOptions) (reqContext.Context, reqContext.CancelFunc) {
 
 	//'-1' to get default config timeout when timeout options not passed
@@ -309,7 +394,7 @@ func NewRequest(client context.Client, options ...ReqContextOptions) (reqContext
 	}
 
 	ctx := reqContext.WithValue(parentContext, reqContextCommManager, client.InfraProvider().CommManager())
-	ctx = reqContext.WithValue(ctx, reqContextClient, client)
+	ctx = reqContext.WithValue(ctx, reqContextClient, newRequestClient(client))
 	ctx, cancel := reqContext.WithTimeout(ctx, timeout)
 
 	return ctx, cancel
@@ -0,0 +1,67 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package context
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+)
+
+func TestRequestIdentitySnapshot(t *testing.T) {
+	user := mockmsp.NewMockSigningIdentity("user1", "Org1MSP")
+	ctx := fabmocks.NewMockContext(user)
+	identity := &renewableIdentity{SigningIdentity: user, cert: []byte("cert1")}
+	client := &Client{Providers: ctx, SigningIdentity: identity}
+
+	reqCtx, cancel := NewRequest(client)
+	defer cancel()
+
+	identity.cert = []byte("cert2")
+
+	reqClient, ok := RequestClientContext(reqCtx)
+	if !ok {
+		t.Fatal("Expected client context in request context")
+	}
+	if !bytes.Equal(reqClient.EnrollmentCertificate(), []byte("cert1")) {
+		t.Fatalf("Expected the request to keep the credentials of the identity when the request was created")
+	}
+	if !bytes.Equal(client.EnrollmentCertificate(), []byte("cert2")) {
+		t.Fatalf("Expected the client to have the renewed credentials")
+	}
+
+	// The requests created within a request keep the same credentials
+	childCtx, childCancel := NewRequest(reqClient, WithParent(reqCtx))
+	defer childCancel()
+
+	identity.cert = []byte("cert3")
+
+	childClient, ok := RequestClientContext(childCtx)
+	if !ok {
+		t.Fatal("Expected client context in request context")
+	}
+	if !bytes.Equal(childClient.EnrollmentCertificate(), []byte("cert1")) {
+		t.Fatalf("Expected the child request to keep the credentials of the parent request")
+	}
+}
+
+// renewableIdentity is a signing identity whose enrollment certificate may be replaced
+type renewableIdentity struct {
+	msp.SigningIdentity
+	cert []byte
+}
+
+func (i *renewableIdentity) EnrollmentCertificate() []byte {
+	return i.cert
+}
+
+func (i *renewableIdentity) Snapshot() msp.SigningIdentity {
+	return &renewableIdentity{SigningIdentity: i.SigningIdentity, cert: i.cert}
+}
@@ -308,6 +308,27 @@ func (c *Config) CAConfig(org string) (*core.CAConfig, error) {
 	return &caConfig, nil
 }
 
+// CAConfigByID returns the configuration of the CA with the given ID,
+// which is its key in the certificateAuthorities section of the config.
+func (c *Config) CAConfigByID(caID string) (*core.CAConfig, error) {
+	config, err := c.NetworkConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	caConfig, ok := config.CertificateAuthorities[strings.ToLower(caID)]
+	if !ok {
+		logger.Debugf("Could not find Certificate Authority for [%s], trying with Entity Matchers", caID)
+		matchingCAConfig, _, err := c.tryMatchingCAConfig(strings.ToLower(caID))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("CA Server Name %s not found", caID))
+		}
+		return matchingCAConfig, nil
+	}
+
+	return &caConfig, nil
+}
+
 // CAServerCertPems Read configuration option for the server certificates
 // will send a list of cert pem contents directly from the config bytes array
 func (c *Config) CAServerCertPems(org string) ([]string, error) {
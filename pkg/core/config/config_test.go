@@ -98,6 +98,19 @@ func TestCAConfig(t *testing.T) {
 		t.Fatal("Get CA Config failed")
 	}
 
+	//Testing CAConfigByID
+	caConfigByID, err := configImpl.CAConfigByID("local.ca.org1.example.com")
+	if err != nil || caConfigByID.URL != caConfig.URL {
+		t.Fatalf("Get CA Config by ID failed %s", err)
+	}
+	caConfigByID, err = configImpl.CAConfigByID("ca.org1.example.com")
+	if err != nil || caConfigByID.URL != "ca.org1.example.com:7054" {
+		t.Fatalf("Get CA Config by ID with entity matcher failed %s", err)
+	}
+	if _, err = configImpl.CAConfigByID("invalid.ca"); err == nil {
+		t.Fatal("Get CA Config by ID should fail for unknown CA")
+	}
+
 	// Test User Store Path
 	if vConfig.GetString("client.credentialStore.path") != configImpl.CredentialStorePath() {
 		t.Fatalf("Incorrect User Store path")
@@ -0,0 +1,314 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockdecoder decodes blocks, as returned by ledger queries and block events,
+// into typed structures.
+package blockdecoder
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/rwset"
+	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// Block is a decoded block
+type Block struct {
+	Number       uint64         `json:"number"`
+	PreviousHash []byte         `json:"previous_hash,omitempty"`
+	DataHash     []byte         `json:"data_hash,omitempty"`
+	ChannelID    string         `json:"channel_id,omitempty"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+// Transaction is a decoded transaction of a block
+type Transaction struct {
+	Index          int        `json:"index"`
+	TxID           string     `json:"tx_id"`
+	ChannelID      string     `json:"channel_id"`
+	Type           string     `json:"type"`
+	Timestamp      *time.Time `json:"timestamp,omitempty"`
+	Creator        *Identity  `json:"creator,omitempty"`
+	ValidationCode string     `json:"validation_code"`
+	IsValid        bool       `json:"is_valid"`
+	Actions        []*Action  `json:"actions,omitempty"`
+	// Error is set if the transaction could not be decoded, in which case
+	// only its index and validation code are known
+	Error string `json:"error,omitempty"`
+}
+
+// Identity is a serialized identity, e.g. the creator or an endorser of a transaction
+type Identity struct {
+	MSPID       string `json:"msp_id"`
+	Certificate string `json:"certificate"`
+}
+
+// Action is a chaincode action of an endorser transaction
+type Action struct {
+	ChaincodeName    string          `json:"chaincode_name"`
+	ChaincodeVersion string          `json:"chaincode_version,omitempty"`
+	Function         string          `json:"function,omitempty"`
+	Args             [][]byte        `json:"args,omitempty"`
+	Response         *Response       `json:"response,omitempty"`
+	RWSet            *rwset.TxRWSet  `json:"rwset,omitempty"`
+	Event            *ChaincodeEvent `json:"event,omitempty"`
+	Endorsers        []*Identity     `json:"endorsers,omitempty"`
+}
+
+// Response is the response of the chaincode that was invoked by an action
+type Response struct {
+	Status  int32  `json:"status"`
+	Message string `json:"message,omitempty"`
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// ChaincodeEvent is the event set by the chaincode that was invoked by an action
+type ChaincodeEvent struct {
+	ChaincodeID string `json:"chaincode_id"`
+	TxID        string `json:"tx_id"`
+	EventName   string `json:"event_name"`
+	Payload     []byte `json:"payload,omitempty"`
+}
+
+// Decode decodes all the transactions of the given block. A transaction which
+// cannot be decoded doesn't fail the block: the decoding error is recorded on
+// that transaction and the rest of the block is still decoded.
+func Decode(block *cb.Block) (*Block, error) {
+	if block == nil || block.Header == nil {
+		return nil, errors.New("block header is required")
+	}
+
+	decoded := &Block{
+		Number:       block.Header.Number,
+		PreviousHash: block.Header.PreviousHash,
+		DataHash:     block.Header.DataHash,
+		Transactions: []*Transaction{},
+	}
+
+	if block.Data == nil {
+		return decoded, nil
+	}
+
+	var txFilter ledgerutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFilter = ledgerutil.TxValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	for i, data := range block.Data.Data {
+		validationCode := pb.TxValidationCode_VALID
+		if i < len(txFilter) {
+			validationCode = txFilter.Flag(i)
+		}
+
+		tx, err := DecodeTransaction(data, validationCode)
+		if err != nil {
+			logger.Warnf("error decoding transaction %d of block %d: %s", i, block.Header.Number, err)
+			tx = &Transaction{
+				ValidationCode: validationCode.String(),
+				IsValid:        validationCode == pb.TxValidationCode_VALID,
+				Error:          err.Error(),
+			}
+		} else {
+			decoded.ChannelID = tx.ChannelID
+		}
+		tx.Index = i
+		decoded.Transactions = append(decoded.Transactions, tx)
+	}
+
+	return decoded, nil
+}
+
+// DecodeTransaction decodes a transaction envelope of a block
+func DecodeTransaction(data []byte, validationCode pb.TxValidationCode) (*Transaction, error) {
+	env, err := utils.GetEnvelopeFromBlock(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting Envelope from block")
+	}
+
+	payload, err := utils.GetPayload(env)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting Payload from envelope")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is missing")
+	}
+
+	channelHeader, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting ChannelHeader from payload")
+	}
+
+	tx := &Transaction{
+		TxID:           channelHeader.TxId,
+		ChannelID:      channelHeader.ChannelId,
+		Type:           cb.HeaderType(channelHeader.Type).String(),
+		ValidationCode: validationCode.String(),
+		IsValid:        validationCode == pb.TxValidationCode_VALID,
+	}
+
+	if channelHeader.Timestamp != nil {
+		ts, err := ptypes.Timestamp(channelHeader.Timestamp)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid timestamp in ChannelHeader")
+		}
+		tx.Timestamp = &ts
+	}
+
+	if len(payload.Header.SignatureHeader) > 0 {
+		signatureHeader, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return nil, errors.Wrap(err, "error extracting SignatureHeader from payload")
+		}
+		tx.Creator, err = newIdentity(signatureHeader.Creator)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid creator")
+		}
+	}
+
+	if cb.HeaderType(channelHeader.Type) == cb.HeaderType_ENDORSER_TRANSACTION {
+		tx.Actions, err = decodeActions(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tx, nil
+}
+
+// JSON returns the JSON rendering of the block. The rendering is stable: the same block
+// always renders to the same bytes.
+func (b *Block) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+func decodeActions(data []byte) ([]*Action, error) {
+	tx, err := utils.GetTransaction(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling transaction payload")
+	}
+
+	var actions []*Action
+	for _, txAction := range tx.Actions {
+		action, err := decodeAction(txAction)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func decodeAction(txAction *pb.TransactionAction) (*Action, error) {
+	chaincodeActionPayload, err := utils.GetChaincodeActionPayload(txAction.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action payload")
+	}
+	if chaincodeActionPayload.Action == nil {
+		return nil, errors.New("chaincode endorsed action is missing")
+	}
+
+	action := &Action{}
+
+	if err := decodeInvocation(action, chaincodeActionPayload.ChaincodeProposalPayload); err != nil {
+		return nil, err
+	}
+
+	for _, endorsement := range chaincodeActionPayload.Action.Endorsements {
+		endorser, err := newIdentity(endorsement.Endorser)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid endorser")
+		}
+		action.Endorsers = append(action.Endorsers, endorser)
+	}
+
+	propRespPayload, err := utils.GetProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling response payload")
+	}
+	ccAction, err := utils.GetChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chaincode action")
+	}
+
+	if ccAction.ChaincodeId != nil {
+		action.ChaincodeName = ccAction.ChaincodeId.Name
+		action.ChaincodeVersion = ccAction.ChaincodeId.Version
+	}
+
+	if ccAction.Response != nil {
+		action.Response = &Response{Status: ccAction.Response.Status, Message: ccAction.Response.Message, Payload: ccAction.Response.Payload}
+	}
+
+	if len(ccAction.Results) > 0 {
+		action.RWSet, err = rwset.FromChaincodeAction(ccAction)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ccEvent, err := utils.GetChaincodeEvents(ccAction.Events)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting chaincode events")
+	}
+	if ccEvent != nil && (ccEvent.ChaincodeId != "" || ccEvent.EventName != "") {
+		action.Event = &ChaincodeEvent{ChaincodeID: ccEvent.ChaincodeId, TxID: ccEvent.TxId, EventName: ccEvent.EventName, Payload: ccEvent.Payload}
+	}
+
+	return action, nil
+}
+
+// decodeInvocation sets the chaincode, function and arguments of the action from the chaincode proposal payload
+func decodeInvocation(action *Action, proposalPayload []byte) error {
+	if len(proposalPayload) == 0 {
+		return nil
+	}
+
+	cpp, err := utils.GetChaincodeProposalPayload(proposalPayload)
+	if err != nil {
+		return errors.Wrap(err, "error unmarshalling chaincode proposal payload")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(cpp.Input, cis); err != nil {
+		return errors.Wrap(err, "error unmarshalling chaincode invocation spec")
+	}
+	if cis.ChaincodeSpec == nil {
+		return nil
+	}
+
+	if cis.ChaincodeSpec.ChaincodeId != nil {
+		action.ChaincodeName = cis.ChaincodeSpec.ChaincodeId.Name
+	}
+	if cis.ChaincodeSpec.Input != nil && len(cis.ChaincodeSpec.Input.Args) > 0 {
+		action.Function = string(cis.ChaincodeSpec.Input.Args[0])
+		action.Args = cis.ChaincodeSpec.Input.Args[1:]
+	}
+	return nil
+}
+
+func newIdentity(serializedID []byte) (*Identity, error) {
+	if len(serializedID) == 0 {
+		return nil, nil
+	}
+
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedID, sID); err != nil {
+		return nil, errors.Wrap(err, "could not deserialize a SerializedIdentity")
+	}
+	return &Identity{MSPID: sID.Mspid, Certificate: string(sID.IdBytes)}, nil
+}
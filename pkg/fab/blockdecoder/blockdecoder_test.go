@@ -0,0 +1,213 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockdecoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+const channelID = "mychannel"
+
+func TestDecodeEventBlock(t *testing.T) {
+	block := mocks.NewBlock(channelID,
+		mocks.NewTransactionWithCCEvent("txid1", pb.TxValidationCode_VALID, "ccid", "event1", []byte("payload1")),
+		mocks.NewTransaction("txid2", pb.TxValidationCode_MVCC_READ_CONFLICT, cb.HeaderType_ENDORSER_TRANSACTION),
+		mocks.NewTransaction("txid3", pb.TxValidationCode_VALID, cb.HeaderType_CONFIG),
+	)
+
+	decoded, err := Decode(block)
+	if !assert.Nil(t, err, "Decode failed") {
+		return
+	}
+	assert.Equal(t, channelID, decoded.ChannelID)
+	if !assert.Equal(t, 3, len(decoded.Transactions)) {
+		return
+	}
+
+	tx := decoded.Transactions[0]
+	assert.Equal(t, "txid1", tx.TxID)
+	assert.Equal(t, "ENDORSER_TRANSACTION", tx.Type)
+	assert.True(t, tx.IsValid)
+	assert.Equal(t, "VALID", tx.ValidationCode)
+	if assert.Equal(t, 1, len(tx.Actions)) {
+		assert.Equal(t, &ChaincodeEvent{ChaincodeID: "ccid", TxID: "txid1", EventName: "event1", Payload: []byte("payload1")}, tx.Actions[0].Event)
+	}
+
+	tx = decoded.Transactions[1]
+	assert.Equal(t, 1, tx.Index)
+	assert.False(t, tx.IsValid)
+	assert.Equal(t, "MVCC_READ_CONFLICT", tx.ValidationCode)
+
+	tx = decoded.Transactions[2]
+	assert.Equal(t, "CONFIG", tx.Type)
+	assert.Nil(t, tx.Actions, "only endorser transactions have actions")
+}
+
+func TestDecodeEndorserTransaction(t *testing.T) {
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: 5, PreviousHash: []byte("prev"), DataHash: []byte("data")},
+		Data:   &cb.BlockData{Data: [][]byte{newEndorserTransaction(t)}},
+	}
+
+	decoded, err := Decode(block)
+	if !assert.Nil(t, err, "Decode failed") {
+		return
+	}
+	assert.Equal(t, uint64(5), decoded.Number)
+	assert.Equal(t, []byte("prev"), decoded.PreviousHash)
+	if !assert.Equal(t, 1, len(decoded.Transactions)) {
+		return
+	}
+
+	tx := decoded.Transactions[0]
+	assert.Equal(t, "txid", tx.TxID)
+	assert.True(t, tx.IsValid, "transactions without a validation flag are valid")
+	assert.Equal(t, &Identity{MSPID: "Org1MSP", Certificate: "creatorcert"}, tx.Creator)
+	if assert.NotNil(t, tx.Timestamp) {
+		assert.Equal(t, int64(1500000000), tx.Timestamp.Unix())
+	}
+	if !assert.Equal(t, 1, len(tx.Actions)) {
+		return
+	}
+
+	action := tx.Actions[0]
+	assert.Equal(t, "examplecc", action.ChaincodeName)
+	assert.Equal(t, "v1", action.ChaincodeVersion)
+	assert.Equal(t, "move", action.Function)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, action.Args)
+	assert.Equal(t, &Response{Status: 200, Payload: []byte("result")}, action.Response)
+	assert.Equal(t, []*Identity{{MSPID: "Org1MSP", Certificate: "peer0cert"}, {MSPID: "Org2MSP", Certificate: "peer1cert"}}, action.Endorsers)
+	assert.Nil(t, action.Event)
+	if assert.NotNil(t, action.RWSet) {
+		ns := action.RWSet.Namespace("examplecc")
+		if assert.NotNil(t, ns) {
+			assert.Equal(t, "a", ns.Writes[0].Key)
+		}
+	}
+
+	rendered, err := decoded.JSON()
+	assert.Nil(t, err, "JSON failed")
+	again, err := decoded.JSON()
+	assert.Nil(t, err, "JSON failed")
+	assert.True(t, bytes.Equal(rendered, again), "expected stable JSON rendering")
+
+	var parsed map[string]interface{}
+	assert.Nil(t, json.Unmarshal(rendered, &parsed), "expected valid JSON")
+	assert.EqualValues(t, 5, parsed["number"])
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode(nil)
+	assert.NotNil(t, err, "expected error for nil block")
+
+	block := mocks.NewBlock(channelID,
+		mocks.NewTransaction("txid1", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION),
+		mocks.NewTransaction("txid2", pb.TxValidationCode_VALID, cb.HeaderType_ENDORSER_TRANSACTION),
+	)
+	block.Data.Data = append([][]byte{[]byte("invalid")}, block.Data.Data...)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = append([]byte{byte(pb.TxValidationCode_BAD_PAYLOAD)}, block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER]...)
+
+	decoded, err := Decode(block)
+	if !assert.Nil(t, err, "an invalid transaction should not fail the block") {
+		return
+	}
+	assert.Equal(t, channelID, decoded.ChannelID)
+	if assert.Equal(t, 3, len(decoded.Transactions)) {
+		tx := decoded.Transactions[0]
+		assert.NotEmpty(t, tx.Error, "expected error for invalid envelope")
+		assert.Equal(t, "BAD_PAYLOAD", tx.ValidationCode)
+		assert.False(t, tx.IsValid)
+		for i, txID := range []string{"txid1", "txid2"} {
+			tx = decoded.Transactions[i+1]
+			assert.Empty(t, tx.Error)
+			assert.Equal(t, i+1, tx.Index)
+			assert.Equal(t, txID, tx.TxID)
+		}
+	}
+
+	decoded, err = Decode(&cb.Block{Header: &cb.BlockHeader{Number: 1}})
+	assert.Nil(t, err, "Decode failed")
+	assert.Equal(t, 0, len(decoded.Transactions))
+}
+
+func newEndorserTransaction(t *testing.T) []byte {
+	rwSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{NameSpace: "examplecc", KvRwSet: &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte("10")}}}},
+		},
+	}
+	results, err := rwSet.ToProtoBytes()
+	assert.Nil(t, err)
+
+	ccAction := &pb.ChaincodeAction{
+		Results:     results,
+		Response:    &pb.Response{Status: 200, Payload: []byte("result")},
+		ChaincodeId: &pb.ChaincodeID{Name: "examplecc", Version: "v1"},
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "examplecc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("move"), []byte("a"), []byte("b")}},
+		},
+	}
+
+	ccActionPayload := &pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: marshal(t, &pb.ChaincodeProposalPayload{Input: marshal(t, cis)}),
+		Action: &pb.ChaincodeEndorsedAction{
+			ProposalResponsePayload: marshal(t, &pb.ProposalResponsePayload{Extension: marshal(t, ccAction)}),
+			Endorsements: []*pb.Endorsement{
+				{Endorser: serializedIdentity(t, "Org1MSP", "peer0cert")},
+				{Endorser: serializedIdentity(t, "Org2MSP", "peer1cert")},
+			},
+		},
+	}
+
+	tx := &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: marshal(t, ccActionPayload)}}}
+
+	channelHeader := &cb.ChannelHeader{
+		Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+		ChannelId: channelID,
+		TxId:      "txid",
+	}
+	channelHeader.Timestamp, err = ptypes.TimestampProto(time.Unix(1500000000, 0))
+	assert.Nil(t, err)
+
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader:   marshal(t, channelHeader),
+			SignatureHeader: marshal(t, &cb.SignatureHeader{Creator: serializedIdentity(t, "Org1MSP", "creatorcert")}),
+		},
+		Data: marshal(t, tx),
+	}
+
+	return marshal(t, &cb.Envelope{Payload: marshal(t, payload)})
+}
+
+func serializedIdentity(t *testing.T, mspID, cert string) []byte {
+	return marshal(t, &msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)})
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	assert.Nil(t, err)
+	return b
+}
@@ -0,0 +1,46 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockheight
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel"
+	"github.com/pkg/errors"
+)
+
+// LedgerQuerier queries the ledger height of a peer using QueryInfo
+type LedgerQuerier struct {
+	ctx    context.Client
+	ledger *channel.Ledger
+}
+
+// NewLedgerQuerier returns a Querier which queries the height of the given channel's ledger
+func NewLedgerQuerier(ctx context.Client, channelID string) (*LedgerQuerier, error) {
+	ledger, err := channel.NewLedger(channelID)
+	if err != nil {
+		return nil, err
+	}
+	return &LedgerQuerier{ctx: ctx, ledger: ledger}, nil
+}
+
+// QueryHeight queries the ledger height of the given peer
+func (q *LedgerQuerier) QueryHeight(peer fab.Peer) (uint64, error) {
+	reqCtx, cancel := contextImpl.NewRequest(q.ctx, contextImpl.WithTimeoutType(core.PeerResponse))
+	defer cancel()
+
+	responses, err := q.ledger.QueryInfo(reqCtx, []fab.ProposalProcessor{peer}, nil)
+	if err != nil {
+		return 0, errors.WithMessage(err, "QueryInfo failed")
+	}
+	if len(responses) == 0 || responses[0].BCI == nil {
+		return 0, errors.New("no blockchain info returned")
+	}
+	return responses[0].BCI.Height, nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockheight tracks the ledger height of peers so that peers which are lagging
+// behind may be excluded when choosing peers for queries and events.
+package blockheight
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+const (
+	defaultMaxBlocksBehind = 5
+	defaultRefreshInterval = 5 * time.Second
+)
+
+// Querier queries the ledger height of a peer
+type Querier interface {
+	QueryHeight(peer fab.Peer) (uint64, error)
+}
+
+// Tracker tracks the ledger height of peers. Heights are updated from block events (see Listen)
+// and, if a Querier is provided, by querying in the background the peers whose height has not been
+// updated recently. Peers are only filtered on the heights already known, so a slow or unreachable
+// peer never delays the selection of peers.
+// Tracker is a fab.TargetFilter so it may be used to filter the targets of ledger queries.
+type Tracker struct {
+	mutex           sync.RWMutex
+	heights         map[string]*height
+	queried         map[string]time.Time
+	refreshing      map[string]bool
+	maxBlocksBehind uint64
+	refreshInterval time.Duration
+	querier         Querier
+	wg              sync.WaitGroup
+}
+
+type height struct {
+	value   uint64
+	updated time.Time
+}
+
+// Opt is a Tracker option
+type Opt func(*Tracker)
+
+// WithMaxBlocksBehind sets the maximum number of blocks that a peer may be behind the
+// tallest peer before it is excluded
+func WithMaxBlocksBehind(value uint64) Opt {
+	return func(t *Tracker) {
+		t.maxBlocksBehind = value
+	}
+}
+
+// WithQuerier sets the Querier used to query the height of peers whose height is unknown or out of date
+func WithQuerier(value Querier) Opt {
+	return func(t *Tracker) {
+		t.querier = value
+	}
+}
+
+// WithRefreshInterval sets the time after which the height of a peer is considered out of date
+// and is queried again (only applicable if a Querier was provided)
+func WithRefreshInterval(value time.Duration) Opt {
+	return func(t *Tracker) {
+		t.refreshInterval = value
+	}
+}
+
+// NewTracker returns a new block height tracker
+func NewTracker(opts ...Opt) *Tracker {
+	t := &Tracker{
+		heights:         make(map[string]*height),
+		queried:         make(map[string]time.Time),
+		refreshing:      make(map[string]bool),
+		maxBlocksBehind: defaultMaxBlocksBehind,
+		refreshInterval: defaultRefreshInterval,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Update sets the ledger height of the peer at the given URL. The height is
+// only updated if it's greater than the height already known for the peer.
+func (t *Tracker) Update(url string, value uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.update(endpoint.ToAddress(url), value)
+}
+
+func (t *Tracker) update(address string, value uint64) {
+	h, ok := t.heights[address]
+	if !ok {
+		t.heights[address] = &height{value: value, updated: time.Now()}
+		return
+	}
+	if value > h.value {
+		h.value = value
+	}
+	h.updated = time.Now()
+}
+
+// Height returns the ledger height of the given peer and true if the height is known
+func (t *Tracker) Height(peer fab.Peer) (uint64, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	h, ok := t.heights[endpoint.ToAddress(peer.URL())]
+	if !ok {
+		return 0, false
+	}
+	return h.value, true
+}
+
+// Listen updates the heights of peers from the filtered block events received from the given
+// event service. The event service must be unregistered from, using the returned registration,
+// in order to stop listening.
+func (t *Tracker) Listen(eventService fab.EventService) (fab.Registration, error) {
+	reg, eventch, err := eventService.RegisterFilteredBlockEvent()
+	if err != nil {
+		return nil, errors.WithMessage(err, "registering for filtered block events failed")
+	}
+
+	go func() {
+		for event := range eventch {
+			if event.FilteredBlock == nil {
+				continue
+			}
+			// The height of the ledger is one more than the number of the last block
+			t.Update(event.SourceURL, event.FilteredBlock.Number+1)
+		}
+		logger.Debugf("Stopped listening for block events")
+	}()
+
+	return reg, nil
+}
+
+// Filter returns the peers which are no more than the maximum number of blocks behind the tallest
+// of the given peers. Peers whose height is unknown are included.
+func (t *Tracker) Filter(peers []fab.Peer) []fab.Peer {
+	t.refresh(peers)
+
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	maxHeight := t.maxHeight(peers)
+
+	var filtered []fab.Peer
+	for _, peer := range peers {
+		if t.accept(peer, maxHeight) {
+			filtered = append(filtered, peer)
+		} else {
+			logger.Debugf("Excluding peer [%s] since it is more than %d blocks behind height %d", peer.URL(), t.maxBlocksBehind, maxHeight)
+		}
+	}
+	return filtered
+}
+
+// Accept returns true if the given peer is no more than the maximum number of blocks behind the
+// tallest peer that is being tracked, or if its height is unknown.
+func (t *Tracker) Accept(peer fab.Peer) bool {
+	t.refresh([]fab.Peer{peer})
+
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	var maxHeight uint64
+	for _, h := range t.heights {
+		if h.value > maxHeight {
+			maxHeight = h.value
+		}
+	}
+	return t.accept(peer, maxHeight)
+}
+
+func (t *Tracker) accept(peer fab.Peer, maxHeight uint64) bool {
+	h, ok := t.heights[endpoint.ToAddress(peer.URL())]
+	if !ok {
+		return true
+	}
+	return h.value+t.maxBlocksBehind >= maxHeight
+}
+
+func (t *Tracker) maxHeight(peers []fab.Peer) uint64 {
+	var maxHeight uint64
+	for _, peer := range peers {
+		if h, ok := t.heights[endpoint.ToAddress(peer.URL())]; ok && h.value > maxHeight {
+			maxHeight = h.value
+		}
+	}
+	return maxHeight
+}
+
+// refresh starts querying, in the background, the height of the given peers whose height is
+// unknown or out of date. A peer is not queried again until the refresh interval has passed
+// since the last attempt, whether or not the attempt succeeded.
+func (t *Tracker) refresh(peers []fab.Peer) {
+	if t.querier == nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	for _, peer := range peers {
+		address := endpoint.ToAddress(peer.URL())
+		if t.refreshing[address] {
+			continue
+		}
+		if h, ok := t.heights[address]; ok && now.Sub(h.updated) < t.refreshInterval {
+			continue
+		}
+		if queried, ok := t.queried[address]; ok && now.Sub(queried) < t.refreshInterval {
+			continue
+		}
+
+		t.refreshing[address] = true
+		t.queried[address] = now
+		t.wg.Add(1)
+		go t.queryHeight(peer, address)
+	}
+}
+
+func (t *Tracker) queryHeight(peer fab.Peer, address string) {
+	defer t.wg.Done()
+
+	value, err := t.querier.QueryHeight(peer)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.refreshing, address)
+	if err != nil {
+		logger.Warnf("Unable to query the block height of peer [%s]: %s", peer.URL(), err)
+		return
+	}
+	t.update(address, value)
+}
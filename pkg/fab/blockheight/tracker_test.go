@@ -0,0 +1,153 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockheight
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+var (
+	peer1 = fabmocks.NewMockPeer("peer1", "grpcs://peer1.example.com:7051")
+	peer2 = fabmocks.NewMockPeer("peer2", "grpcs://peer2.example.com:7051")
+	peer3 = fabmocks.NewMockPeer("peer3", "grpcs://peer3.example.com:7051")
+)
+
+func TestFilter(t *testing.T) {
+	tracker := NewTracker(WithMaxBlocksBehind(2))
+
+	peers := []fab.Peer{peer1, peer2, peer3}
+	assert.Equal(t, peers, tracker.Filter(peers), "peers with unknown heights should be included")
+
+	tracker.Update("peer1.example.com:7051", 10)
+	tracker.Update("grpcs://peer2.example.com:7051", 8)
+	tracker.Update("peer3.example.com:7051", 7)
+
+	assert.Equal(t, []fab.Peer{peer1, peer2}, tracker.Filter(peers))
+	assert.True(t, tracker.Accept(peer2))
+	assert.False(t, tracker.Accept(peer3))
+
+	// The tallest of the given peers is used
+	assert.Equal(t, []fab.Peer{peer2, peer3}, tracker.Filter([]fab.Peer{peer2, peer3}))
+
+	// Heights never decrease
+	tracker.Update("peer1.example.com:7051", 5)
+	height, ok := tracker.Height(peer1)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), height)
+
+	tracker.Update("peer3.example.com:7051", 9)
+	assert.Equal(t, peers, tracker.Filter(peers))
+}
+
+func TestQuerier(t *testing.T) {
+	querier := &mockQuerier{heights: map[fab.Peer]uint64{peer1: 20, peer2: 3}, errs: map[fab.Peer]error{peer3: errors.New("unavailable")}}
+	tracker := NewTracker(WithQuerier(querier), WithRefreshInterval(time.Hour))
+
+	// Heights are queried in the background, so the peers are filtered on the known heights
+	peers := []fab.Peer{peer1, peer2, peer3}
+	assert.Equal(t, peers, tracker.Filter(peers))
+	tracker.wg.Wait()
+	assert.Equal(t, 3, querier.count())
+	assert.Equal(t, []fab.Peer{peer1, peer3}, tracker.Filter(peers))
+
+	// Heights are not queried again until the refresh interval expires, even if the query failed
+	tracker.Filter(peers)
+	tracker.wg.Wait()
+	assert.Equal(t, 3, querier.count())
+
+	tracker = NewTracker(WithQuerier(querier), WithRefreshInterval(0))
+	tracker.Filter([]fab.Peer{peer1})
+	tracker.wg.Wait()
+	tracker.Filter([]fab.Peer{peer1})
+	tracker.wg.Wait()
+	assert.Equal(t, 5, querier.count())
+}
+
+func TestQuerierNotBlocking(t *testing.T) {
+	querier := &mockQuerier{delay: make(chan struct{}), heights: map[fab.Peer]uint64{peer1: 20, peer2: 3}}
+	tracker := NewTracker(WithQuerier(querier), WithRefreshInterval(0))
+
+	peers := []fab.Peer{peer1, peer2}
+	assert.Equal(t, peers, tracker.Filter(peers), "selection should not wait for the height queries")
+	assert.True(t, tracker.Accept(peer2))
+
+	// Queries in progress are not duplicated
+	close(querier.delay)
+	tracker.wg.Wait()
+	assert.Equal(t, 2, querier.count())
+	assert.False(t, tracker.Accept(peer2))
+}
+
+func TestListen(t *testing.T) {
+	eventService := &mockEventService{eventch: make(chan *fab.FilteredBlockEvent)}
+	tracker := NewTracker()
+
+	_, err := tracker.Listen(eventService)
+	assert.Nil(t, err)
+
+	eventService.eventch <- &fab.FilteredBlockEvent{FilteredBlock: &pb.FilteredBlock{Number: 41}, SourceURL: "peer1.example.com:7051"}
+	close(eventService.eventch)
+
+	assert.True(t, waitForHeight(tracker, peer1, 42), "expected height of peer1 to be updated from block event")
+}
+
+func waitForHeight(tracker *Tracker, peer fab.Peer, expected uint64) bool {
+	for i := 0; i < 100; i++ {
+		if height, ok := tracker.Height(peer); ok && height == expected {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+type mockQuerier struct {
+	mutex   sync.Mutex
+	delay   chan struct{}
+	heights map[fab.Peer]uint64
+	errs    map[fab.Peer]error
+	queries int
+}
+
+func (q *mockQuerier) QueryHeight(peer fab.Peer) (uint64, error) {
+	if q.delay != nil {
+		<-q.delay
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.queries++
+	if err, ok := q.errs[peer]; ok {
+		return 0, err
+	}
+	return q.heights[peer], nil
+}
+
+func (q *mockQuerier) count() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.queries
+}
+
+type mockEventService struct {
+	fabmocks.MockEventService
+	eventch chan *fab.FilteredBlockEvent
+}
+
+func (s *mockEventService) RegisterFilteredBlockEvent() (fab.Registration, <-chan *fab.FilteredBlockEvent, error) {
+	return "registration", s.eventch, nil
+}
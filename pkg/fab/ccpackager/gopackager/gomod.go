@@ -0,0 +1,209 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gopackager
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const goModFile = "go.mod"
+
+// goModule contains the parts of a go.mod file required to locate the source of dependencies
+type goModule struct {
+	dir      string
+	path     string
+	requires map[string]string       // module path -> version
+	replaces map[string]*replacement // module path -> replacement
+}
+
+// replacement is the target of a replace directive. Either dir (a local
+// replacement) or path and version (a module replacement) are set.
+type replacement struct {
+	dir     string
+	path    string
+	version string
+}
+
+// findGoModule returns the module containing dir, or nil if dir is not in a module
+func findGoModule(dir string) (*goModule, error) {
+	for d := dir; ; {
+		goMod := filepath.Join(d, goModFile)
+		if _, err := os.Stat(goMod); err == nil {
+			return parseGoModule(d, goMod)
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return nil, nil
+		}
+		d = parent
+	}
+}
+
+func parseGoModule(dir, goMod string) (*goModule, error) {
+	b, err := ioutil.ReadFile(goMod)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading %s failed", goMod)
+	}
+
+	m := &goModule{
+		dir:      dir,
+		requires: make(map[string]string),
+		replaces: make(map[string]*replacement),
+	}
+
+	block := ""
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if block != "" {
+			if fields[0] == ")" {
+				block = ""
+				continue
+			}
+			m.addDirective(dir, block, fields)
+			continue
+		}
+
+		if len(fields) == 2 && fields[1] == "(" {
+			block = fields[0]
+			continue
+		}
+		m.addDirective(dir, fields[0], fields[1:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading %s failed", goMod)
+	}
+
+	if m.path == "" {
+		return nil, errors.Errorf("no module path in %s", goMod)
+	}
+	return m, nil
+}
+
+func (m *goModule) addDirective(dir, verb string, args []string) {
+	for i := range args {
+		args[i] = strings.Trim(args[i], "\"`")
+	}
+
+	switch verb {
+	case "module":
+		if len(args) == 1 {
+			m.path = args[0]
+		}
+	case "require":
+		if len(args) == 2 {
+			m.requires[args[0]] = args[1]
+		}
+	case "replace":
+		arrow := indexOf(args, "=>")
+		if arrow < 1 || arrow == len(args)-1 {
+			return
+		}
+		target := args[arrow+1:]
+		if len(target) == 1 && isLocalPath(target[0]) {
+			if !filepath.IsAbs(target[0]) {
+				target[0] = filepath.Join(dir, target[0])
+			}
+			m.replaces[args[0]] = &replacement{dir: target[0]}
+		} else if len(target) == 2 {
+			m.replaces[args[0]] = &replacement{path: target[0], version: target[1]}
+		}
+	}
+}
+
+// packageDir returns the directory of the given package if it belongs to the
+// main module or to one of its requirements. modCache is the module cache directory.
+func (m *goModule) packageDir(importPath, modCache string) (string, bool) {
+	if rel, ok := trimModulePath(importPath, m.path); ok {
+		return filepath.Join(m.dir, rel), true
+	}
+
+	// Find the longest required module path that is a prefix of the import path
+	modPath, rel := "", ""
+	for p := range m.requires {
+		if r, ok := trimModulePath(importPath, p); ok && len(p) > len(modPath) {
+			modPath, rel = p, r
+		}
+	}
+	if modPath == "" {
+		return "", false
+	}
+
+	if r, ok := m.replaces[modPath]; ok {
+		if r.dir != "" {
+			return filepath.Join(r.dir, rel), true
+		}
+		return filepath.Join(modCache, escapeModulePath(r.path)+"@"+escapeModulePath(r.version), rel), true
+	}
+
+	version := m.requires[modPath]
+	return filepath.Join(modCache, escapeModulePath(modPath)+"@"+escapeModulePath(version), rel), true
+}
+
+// trimModulePath returns the path of the package relative to the module if the package is in the module
+func trimModulePath(importPath, modPath string) (string, bool) {
+	if importPath == modPath {
+		return "", true
+	}
+	if strings.HasPrefix(importPath, modPath+"/") {
+		return filepath.FromSlash(importPath[len(modPath)+1:]), true
+	}
+	return "", false
+}
+
+// escapeModulePath escapes upper case letters as in the module cache ("!" followed by the lower case letter)
+func escapeModulePath(path string) string {
+	var b bytes.Buffer
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// moduleCacheDir returns the module cache directory
+func moduleCacheDir(goPaths []string) string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if len(goPaths) == 0 {
+		return ""
+	}
+	return filepath.Join(goPaths[0], "pkg", "mod")
+}
+
+func isLocalPath(path string) bool {
+	return filepath.IsAbs(path) || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") || path == "." || path == ".."
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -7,29 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package gopackager
 
 import (
-	"archive/tar"
-	"bytes"
-	"compress/gzip"
 	"go/build"
-	"io"
 	"os"
 	"path"
 	"path/filepath"
-	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/tarball"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
 	"github.com/pkg/errors"
 
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 )
 
-// Descriptor ...
-type Descriptor struct {
-	name string
-	fqp  string
-}
-
 // A list of file extensions that should be packaged into the .tar.gz.
 // Files with all other file extenstions will be excluded to minimize the size
 // of the install payload.
@@ -37,8 +27,35 @@ var keep = []string{".c", ".h", ".s", ".go", ".yaml", ".json"}
 
 var logger = logging.NewLogger("fabsdk/fab")
 
-// NewCCPackage creates new go lang chaincode package
-func NewCCPackage(chaincodePath string, goPath string) (*api.CCPackage, error) {
+// options holds the packaging options
+type options struct {
+	dependencies bool
+	metadata     bool
+}
+
+// Option describes a functional parameter for NewCCPackage
+type Option func(opts *options)
+
+// WithDependencies packages the imported packages which are not in the chaincode source tree,
+// as NewCCPackageFromDir does. By default only the chaincode source tree is packaged.
+func WithDependencies() Option {
+	return func(opts *options) {
+		opts.dependencies = true
+	}
+}
+
+// WithMetadata packages the CouchDB index definitions in the META-INF directory of the chaincode
+// under the META-INF prefix, where the peer deploys them, as NewCCPackageFromDir does. Any other
+// file in the META-INF directory results in an error. By default the META-INF directory is
+// packaged with the chaincode source.
+func WithMetadata() Option {
+	return func(opts *options) {
+		opts.metadata = true
+	}
+}
+
+// NewCCPackage creates new go lang chaincode package from the chaincode path in the GOPATH
+func NewCCPackage(chaincodePath string, goPath string, opts ...Option) (*api.CCPackage, error) {
 
 	if chaincodePath == "" {
 		return nil, errors.New("chaincode path must be provided")
@@ -58,15 +75,85 @@ func NewCCPackage(chaincodePath string, goPath string) (*api.CCPackage, error) {
 
 	logger.Debugf("projDir variable=%s", projDir)
 
-	// We generate the tar in two phases: First grab a list of descriptors,
-	// and then pack them into an archive.  While the two phases aren't
-	// strictly necessary yet, they pave the way for the future where we
-	// will need to assemble sources from multiple packages
-	descriptors, err := findSource(gp, projDir)
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return newCCPackage(chaincodePath, projDir, []string{gp}, o)
+}
+
+// NewCCPackageFromDir creates a new go lang chaincode package from the source in the given directory,
+// which does not need to be in the GOPATH. The chaincode is packaged under the given chaincode path,
+// i.e. its import path, which must also be used in the install and instantiate requests. If the
+// chaincode path is empty then it is derived from the go.mod file of the enclosing module.
+//
+// Imported packages which are not part of the standard library are resolved, in order, from
+// the vendor directories enclosing the importing package, the enclosing module and its
+// requirements (using the module cache) and the GOPATH, and are included in the package.
+// Fabric packages, such as the chaincode shim, and the packages vendored by Fabric are provided
+// by the peer's build environment and are not included, nor are packages which can't be resolved.
+func NewCCPackageFromDir(chaincodePath string, srcDir string) (*api.CCPackage, error) {
+	if srcDir == "" {
+		return nil, errors.New("chaincode source directory must be provided")
+	}
+
+	srcDir, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving chaincode source directory failed")
+	}
+
+	if chaincodePath == "" {
+		mod, err := findGoModule(srcDir)
+		if err != nil {
+			return nil, err
+		}
+		if mod == nil {
+			return nil, errors.New("chaincode path must be provided if the source is not in a Go module")
+		}
+		rel, err := filepath.Rel(mod.dir, srcDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "resolving chaincode path failed")
+		}
+		chaincodePath = path.Join(mod.path, filepath.ToSlash(rel))
+	}
+
+	return newCCPackage(chaincodePath, srcDir, filepath.SplitList(build.Default.GOPATH), options{dependencies: true, metadata: true})
+}
+
+func newCCPackage(chaincodePath, srcDir string, goPaths []string, o options) (*api.CCPackage, error) {
+	if _, err := os.Stat(srcDir); err != nil {
+		return nil, errors.Wrap(err, "chaincode source directory not found")
+	}
+
+	// Package the chaincode source tree
+	descriptors, err := findSource(chaincodePath, srcDir, o.metadata)
 	if err != nil {
 		return nil, err
 	}
-	tarBytes, err := generateTarGz(descriptors)
+
+	if o.dependencies {
+		// Add the dependencies which are not in the chaincode source tree
+		r, err := newResolver(chaincodePath, srcDir, goPaths)
+		if err != nil {
+			return nil, err
+		}
+		deps, err := r.resolve()
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, deps...)
+	}
+
+	if o.metadata {
+		metadata, err := tarball.FindMetadata(srcDir)
+		if err != nil {
+			return nil, err
+		}
+		descriptors = append(descriptors, metadata...)
+	}
+
+	tarBytes, err := tarball.Generate(descriptors)
 	if err != nil {
 		return nil, err
 	}
@@ -76,37 +163,53 @@ func NewCCPackage(chaincodePath string, goPath string) (*api.CCPackage, error) {
 	return ccPkg, nil
 }
 
-// -------------------------------------------------------------------------
-// findSource(goPath, filePath)
-// -------------------------------------------------------------------------
-// Given an input 'filePath', recursively parse the filesystem for any files
-// that fit the criteria for being valid golang source (ISREG + (*.(go|c|h)))
-// As a convenience, we also formulate a tar-friendly "name" for each file
-// based on relative position to 'goPath'.
-// -------------------------------------------------------------------------
-func findSource(goPath string, filePath string) ([]*Descriptor, error) {
-	var descriptors []*Descriptor
-	err := filepath.Walk(filePath,
-		func(path string, fileInfo os.FileInfo, err error) error {
+// findSource returns the descriptors of the source files in the chaincode source tree, named
+// after the chaincode path. The META-INF directory is packaged as source unless skipMetadata
+// is set, in which case tarball.FindSource leaves it out.
+func findSource(chaincodePath, srcDir string, skipMetadata bool) ([]*tarball.Descriptor, error) {
+	if skipMetadata {
+		descriptors, err := tarball.FindSource(srcDir, nil, skipFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range descriptors {
+			rel, err := filepath.Rel(tarball.SourceDir, d.Name)
 			if err != nil {
-				return err
+				return nil, errors.Wrap(err, "resolving package name failed")
 			}
-			if fileInfo.Mode().IsRegular() && isSource(path) {
-				relPath, err := filepath.Rel(goPath, path)
-				if err != nil {
-					return err
-				}
-				descriptors = append(descriptors, &Descriptor{name: relPath, fqp: path})
-			}
-			return nil
+			d.Name = filepath.Join(tarball.SourceDir, filepath.FromSlash(chaincodePath), rel)
+		}
+		return descriptors, nil
+	}
 
+	var descriptors []*tarball.Descriptor
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() || !isSource(path) {
+			return nil
+		}
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		descriptors = append(descriptors, &tarball.Descriptor{
+			Name: filepath.Join(tarball.SourceDir, filepath.FromSlash(chaincodePath), rel),
+			Path: path,
 		})
+		return nil
+	})
 	if err != nil {
-		return descriptors, err
+		return nil, errors.Wrapf(err, "reading source from %s failed", srcDir)
 	}
 	return descriptors, nil
 }
 
+func skipFile(rel string, info os.FileInfo) bool {
+	return !isSource(rel)
+}
+
 // -------------------------------------------------------------------------
 // isSource(path)
 // -------------------------------------------------------------------------
@@ -124,68 +227,6 @@ func isSource(filePath string) bool {
 	return false
 }
 
-// -------------------------------------------------------------------------
-// generateTarGz(descriptors)
-// -------------------------------------------------------------------------
-// creates an .tar.gz stream from the provided descriptor entries
-// -------------------------------------------------------------------------
-func generateTarGz(descriptors []*Descriptor) ([]byte, error) {
-	// set up the gzip writer
-	var codePackage bytes.Buffer
-	gw := gzip.NewWriter(&codePackage)
-	tw := tar.NewWriter(gw)
-	for _, v := range descriptors {
-		logger.Debugf("generateTarGz for %s", v.fqp)
-		err := packEntry(tw, gw, v)
-		if err != nil {
-			closeStream(tw, gw)
-			return nil, errors.Wrap(err, "packEntry failed")
-		}
-	}
-	closeStream(tw, gw)
-	return codePackage.Bytes(), nil
-
-}
-
-func closeStream(tw *tar.Writer, gw *gzip.Writer) {
-	tw.Close()
-	gw.Close()
-}
-
-func packEntry(tw *tar.Writer, gw *gzip.Writer, descriptor *Descriptor) error {
-	file, err := os.Open(descriptor.fqp)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-	if stat, err := file.Stat(); err == nil {
-
-		// now lets create the header as needed for this file within the tarball
-		header := new(tar.Header)
-		header.Name = descriptor.name
-		header.Size = stat.Size()
-		header.Mode = int64(stat.Mode())
-		// Use a deterministic "zero-time" for all date fields
-		header.ModTime = time.Time{}
-		header.AccessTime = time.Time{}
-		header.ChangeTime = time.Time{}
-		// write the header to the tarball archive
-		if err := tw.WriteHeader(header); err != nil {
-			return err
-		}
-
-		// copy the file data to the tarball
-
-		if _, err := io.Copy(tw, file); err != nil {
-			return err
-		}
-		tw.Flush()
-		gw.Flush()
-
-	}
-	return nil
-}
-
 // defaultGoPath returns the system's default GOPATH. If the system
 // has multiple GOPATHs then the first is used.
 func defaultGoPath() string {
@@ -10,10 +10,17 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"go/build"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
 )
 
 // Test golang ChainCode packaging
@@ -83,28 +90,207 @@ func TestBadPackagePathGoLangCC(t *testing.T) {
 
 // Test isSource set to true for any go readable files used in ChainCode packaging
 func TestIsSourcePath(t *testing.T) {
+	prevKeep := keep
 	keep = []string{}
+	// reset keep
+	defer func() { keep = prevKeep }()
+
 	isSrcVal := isSource("../")
 
 	if isSrcVal {
 		t.Fatalf("error expected when calling isSource %v", isSrcVal)
 	}
+}
+
+// Test packaging of chaincode outside the GOPATH with its dependencies
+func TestNewCCPackageFromDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "gocc")
+	if err != nil {
+		t.Fatalf("error creating temp dir %v", err)
+	}
+	defer os.RemoveAll(root)
 
-	// reset keep
-	keep = []string{".go", ".c", ".h"}
+	testutil.WriteFiles(t, root, map[string]string{
+		"cc/go.mod": "module example.com/cc\n\nrequire (\n\texample.com/moddep v1.0.0\n\texample.com/local v0.0.0\n)\n\nreplace example.com/local => ../local\n",
+		"cc/main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/cc/sub"
+	"example.com/dep"
+	"example.com/local/pkg"
+	"example.com/moddep/x"
+	"example.com/vendored"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+`,
+		"cc/main_test.go":                                                         "package main\n\nimport \"example.com/testonly\"\n",
+		"cc/sub/sub.go":                                                           "package sub\n",
+		"cc/vendor/example.com/vendored/v.go":                                     "package vendored\n",
+		"cc/META-INF/statedb/couchdb/indexes/i.json":                              `{"index":{"fields":["owner"]}}`,
+		"local/pkg/p.go":                                                          "package pkg\n",
+		"modcache/example.com/moddep@v1.0.0/x/x.go":                               "package x\n",
+		"modcache/example.com/moddep@v1.0.0/y/y.go":                               "package y\n",
+		"gopath/src/example.com/dep/dep.go":                                       "package dep\n\nimport \"example.com/dep2\"\n",
+		"gopath/src/example.com/dep2/dep2.go":                                     "package dep2\n",
+		"gopath/src/example.com/testonly/testonly.go":                             "package testonly\n",
+		"gopath/src/github.com/hyperledger/fabric/core/chaincode/shim/shim.go":    "package shim\n",
+		"gopath/src/github.com/hyperledger/fabric/protos/ledger/queryresult/q.go": "package queryresult\n",
+		"gopath/src/github.com/hyperledger/fabric/vendor/github.com/golang/protobuf/proto/p.go": "package proto\n",
+		"gopath/src/github.com/golang/protobuf/proto/p.go":                                      "package proto\n",
+	})
+
+	defer setGoEnv(filepath.Join(root, "gopath"), filepath.Join(root, "modcache"))()
+
+	ccPackage, err := NewCCPackageFromDir("", filepath.Join(root, "cc"))
+	if err != nil {
+		t.Fatalf("error from NewCCPackageFromDir %v", err)
+	}
+
+	expected := map[string]bool{
+		"src/example.com/cc/main.go":                          true,
+		"src/example.com/cc/main_test.go":                     true,
+		"src/example.com/cc/sub/sub.go":                       true,
+		"src/example.com/cc/vendor/example.com/vendored/v.go": true,
+		"src/example.com/local/pkg/p.go":                      true,
+		"src/example.com/moddep/x/x.go":                       true,
+		"src/example.com/dep/dep.go":                          true,
+		"src/example.com/dep2/dep2.go":                        true,
+		"META-INF/statedb/couchdb/indexes/i.json":             true,
+	}
+	names := testutil.TarballNames(t, ccPackage.Code)
+	if len(names) != len(expected) {
+		t.Fatalf("unexpected package contents %v", names)
+	}
+	for _, name := range names {
+		if !expected[name] {
+			t.Fatalf("unexpected file %s in package", name)
+		}
+	}
+
+	if _, err := NewCCPackageFromDir("", filepath.Join(root, "gopath/src/example.com/dep")); err == nil {
+		t.Fatal("chaincode path must be required outside of a module")
+	}
+	if _, err := NewCCPackageFromDir("example.com/cc", ""); err == nil {
+		t.Fatal("source directory must be required")
+	}
 }
 
-// Test packEntry and generateTarGz with empty file Descriptor
-func TestEmptyPackEntry(t *testing.T) {
-	emptyDescriptor := &Descriptor{"NewFile", ""}
-	err := packEntry(nil, nil, emptyDescriptor)
-	if err == nil {
-		t.Fatal("packEntry call with empty descriptor info must throw an error")
+// Test packaging of chaincode in the GOPATH layout, which only includes the chaincode source tree
+// unless the dependencies and metadata are requested
+func TestNewCCPackageGoPathLayout(t *testing.T) {
+	goPath, err := ioutil.TempDir("", "gopath")
+	if err != nil {
+		t.Fatalf("error creating temp dir %v", err)
 	}
+	defer os.RemoveAll(goPath)
 
-	_, err = generateTarGz([]*Descriptor{emptyDescriptor})
-	if err == nil {
-		t.Fatal("generateTarGz call with empty descriptor info must throw an error")
+	testutil.WriteFiles(t, goPath, map[string]string{
+		"src/example.com/cc/main.go":                                 "package main\n\nimport \"example.com/dep\"\n",
+		"src/example.com/cc/README.md":                               "readme",
+		"src/example.com/cc/META-INF/statedb/couchdb/indexes/i.json": `{"index":{"fields":["owner"]}}`,
+		"src/example.com/cc/META-INF/config.yaml":                    "key: value\n",
+		"src/example.com/dep/dep.go":                                 "package dep\n",
+	})
+
+	ccPackage, err := NewCCPackage("example.com/cc", goPath)
+	if err != nil {
+		t.Fatalf("error from NewCCPackage %v", err)
+	}
+	expected := []string{
+		"src/example.com/cc/META-INF/config.yaml",
+		"src/example.com/cc/META-INF/statedb/couchdb/indexes/i.json",
+		"src/example.com/cc/main.go",
 	}
+	checkPackageNames(t, ccPackage.Code, expected)
 
+	// The META-INF directory only supports index definitions when the metadata is packaged
+	if _, err := NewCCPackage("example.com/cc", goPath, WithMetadata()); err == nil {
+		t.Fatal("expected error for unsupported metadata file")
+	}
+	if err := os.Remove(filepath.Join(goPath, "src/example.com/cc/META-INF/config.yaml")); err != nil {
+		t.Fatalf("error removing file %v", err)
+	}
+
+	ccPackage, err = NewCCPackage("example.com/cc", goPath, WithMetadata(), WithDependencies())
+	if err != nil {
+		t.Fatalf("error from NewCCPackage %v", err)
+	}
+	expected = []string{
+		"META-INF/statedb/couchdb/indexes/i.json",
+		"src/example.com/cc/main.go",
+		"src/example.com/dep/dep.go",
+	}
+	checkPackageNames(t, ccPackage.Code, expected)
+}
+
+// Test parsing of go.mod files
+func TestGoModule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gomod")
+	if err != nil {
+		t.Fatalf("error creating temp dir %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testutil.WriteFiles(t, dir, map[string]string{
+		"go.mod": `module example.com/Main // comment
+
+require example.com/A v1.2.0
+require (
+	example.com/b v0.1.0 // indirect
+	example.com/b/c v0.2.0
+)
+
+replace example.com/b => example.com/fork v0.3.0
+`,
+	})
+
+	mod, err := findGoModule(filepath.Join(dir, "sub"))
+	if err != nil || mod == nil {
+		t.Fatalf("error from findGoModule %v", err)
+	}
+
+	tests := map[string]string{
+		"example.com/Main/pkg": filepath.Join(dir, "pkg"),
+		"example.com/A/x":      filepath.Join("cache", "example.com/!a@v1.2.0", "x"),
+		"example.com/b/x":      filepath.Join("cache", "example.com/fork@v0.3.0", "x"),
+		"example.com/b/c/x":    filepath.Join("cache", "example.com/b/c@v0.2.0", "x"),
+	}
+	for imp, expected := range tests {
+		d, ok := mod.packageDir(imp, "cache")
+		if !ok || d != expected {
+			t.Fatalf("unexpected directory %s for %s", d, imp)
+		}
+	}
+	if _, ok := mod.packageDir("example.com/other", "cache"); ok {
+		t.Fatal("package not in module requirements must not be found")
+	}
+}
+
+func setGoEnv(goPath, modCache string) func() {
+	prevGoPath := build.Default.GOPATH
+	prevModCache, modCacheSet := os.LookupEnv("GOMODCACHE")
+
+	build.Default.GOPATH = goPath
+	os.Setenv("GOMODCACHE", modCache)
+
+	return func() {
+		build.Default.GOPATH = prevGoPath
+		if modCacheSet {
+			os.Setenv("GOMODCACHE", prevModCache)
+		} else {
+			os.Unsetenv("GOMODCACHE")
+		}
+	}
+}
+
+func checkPackageNames(t *testing.T, code []byte, expected []string) {
+	names := testutil.TarballNames(t, code)
+	sort.Strings(names)
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected package contents %v, got %v", expected, names)
+	}
 }
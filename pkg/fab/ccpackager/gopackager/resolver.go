@@ -0,0 +1,307 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gopackager
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/tarball"
+	"github.com/pkg/errors"
+)
+
+const (
+	vendorDir = "vendor"
+
+	// fabricPath is the import path of Fabric, whose packages, including the chaincode shim,
+	// and vendored dependencies are provided by the peer's chaincode build environment
+	fabricPath = "github.com/hyperledger/fabric"
+)
+
+// resolver finds the packages imported by the chaincode, directly or indirectly,
+// which are not in the chaincode source tree
+type resolver struct {
+	chaincodePath string
+	srcDir        string
+	goPaths       []string
+	module        *goModule
+	modCache      string
+	visited       map[string]bool // package directories which have been resolved
+}
+
+// pkg is a package to be resolved
+type pkg struct {
+	importPath string
+	dir        string
+}
+
+func newResolver(chaincodePath, srcDir string, goPaths []string) (*resolver, error) {
+	module, err := findGoModule(srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resolver{
+		chaincodePath: chaincodePath,
+		srcDir:        srcDir,
+		goPaths:       goPaths,
+		module:        module,
+		modCache:      moduleCacheDir(goPaths),
+		visited:       make(map[string]bool),
+	}, nil
+}
+
+// resolve returns the descriptors of the source files of the dependencies which are
+// not in the chaincode source tree
+func (r *resolver) resolve() ([]*tarball.Descriptor, error) {
+	queue, err := r.chaincodePackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var descriptors []*tarball.Descriptor
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+
+		if r.visited[p.dir] {
+			continue
+		}
+		r.visited[p.dir] = true
+
+		if !r.inSourceTree(p.dir) {
+			logger.Debugf("packaging dependency %s from %s", p.importPath, p.dir)
+			files, err := sourceFiles(p.dir)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range files {
+				descriptors = append(descriptors, &tarball.Descriptor{
+					Name: filepath.Join(tarball.SourceDir, filepath.FromSlash(p.importPath), f),
+					Path: filepath.Join(p.dir, f),
+				})
+			}
+		}
+
+		imports, err := packageImports(p.dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, imp := range imports {
+			if isStandardPackage(imp) || isFabricPackage(imp) || r.isFabricVendored(imp) {
+				continue
+			}
+			dir, ok := r.locate(imp, p.dir)
+			if !ok {
+				logger.Debugf("imported package %s not found, it must be provided by the build environment", imp)
+				continue
+			}
+			if isFabricVendorDir(dir) {
+				continue
+			}
+			queue = append(queue, pkg{importPath: imp, dir: dir})
+		}
+	}
+
+	return descriptors, nil
+}
+
+// chaincodePackages returns the packages in the chaincode source tree, excluding vendored packages
+func (r *resolver) chaincodePackages() ([]pkg, error) {
+	var packages []pkg
+	err := filepath.Walk(r.srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != r.srcDir && (info.Name() == vendorDir || info.Name() == tarball.MetadataDir || info.Name() == "testdata") {
+			return filepath.SkipDir
+		}
+		if !hasGoFiles(path) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.srcDir, path)
+		if err != nil {
+			return err
+		}
+		packages = append(packages, pkg{importPath: filepath.ToSlash(filepath.Join(r.chaincodePath, rel)), dir: path})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading packages from %s failed", r.srcDir)
+	}
+	return packages, nil
+}
+
+// locate returns the directory of the imported package
+func (r *resolver) locate(importPath, fromDir string) (string, bool) {
+	if dir, ok := r.locateVendored(importPath, fromDir); ok {
+		return dir, true
+	}
+
+	if r.module != nil && r.modCache != "" {
+		if dir, ok := r.module.packageDir(importPath, r.modCache); ok && hasGoFiles(dir) {
+			return dir, true
+		}
+	}
+
+	for _, gp := range r.goPaths {
+		dir := filepath.Join(gp, "src", filepath.FromSlash(importPath))
+		if hasGoFiles(dir) {
+			return dir, true
+		}
+	}
+
+	return "", false
+}
+
+// locateVendored searches the vendor directories enclosing the importing package, up to the
+// root of its module or GOPATH
+func (r *resolver) locateVendored(importPath, fromDir string) (string, bool) {
+	for d := fromDir; ; {
+		dir := filepath.Join(d, vendorDir, filepath.FromSlash(importPath))
+		if hasGoFiles(dir) {
+			return dir, true
+		}
+
+		if r.isRoot(d) {
+			return "", false
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return "", false
+		}
+		d = parent
+	}
+}
+
+func (r *resolver) isRoot(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, goModFile)); err == nil {
+		return true
+	}
+	for _, gp := range r.goPaths {
+		if dir == filepath.Join(gp, "src") {
+			return true
+		}
+	}
+	return false
+}
+
+// isFabricVendored returns true if the package is vendored by the Fabric source in the GOPATH
+func (r *resolver) isFabricVendored(importPath string) bool {
+	for _, gp := range r.goPaths {
+		dir := filepath.Join(gp, "src", filepath.FromSlash(fabricPath), vendorDir, filepath.FromSlash(importPath))
+		if hasGoFiles(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resolver) inSourceTree(dir string) bool {
+	rel, err := filepath.Rel(r.srcDir, dir)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// packageImports returns the imports of the (non-test) Go files in the given directory.
+// Build constraints are ignored so that the imports for all platforms are included.
+func packageImports(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading directory %s failed", dir)
+	}
+
+	var imports []string
+	seen := make(map[string]bool)
+	for _, f := range files {
+		if !isGoFile(f) {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s failed", path)
+		}
+		for _, spec := range file.Imports {
+			imp, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid import in %s", path)
+			}
+			if !seen[imp] {
+				seen[imp] = true
+				imports = append(imports, imp)
+			}
+		}
+	}
+	return imports, nil
+}
+
+// sourceFiles returns the names of the source files in the given directory (not including sub-directories)
+func sourceFiles(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading directory %s failed", dir)
+	}
+
+	var names []string
+	for _, f := range files {
+		if f.Mode().IsRegular() && isSource(f.Name()) {
+			names = append(names, f.Name())
+		}
+	}
+	return names, nil
+}
+
+func hasGoFiles(dir string) bool {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, f := range files {
+		if isGoFile(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func isGoFile(f os.FileInfo) bool {
+	return f.Mode().IsRegular() && strings.HasSuffix(f.Name(), ".go") && !strings.HasSuffix(f.Name(), "_test.go")
+}
+
+// isFabricPackage returns true for Fabric packages, which are provided by the peer's chaincode
+// build environment
+func isFabricPackage(importPath string) bool {
+	return importPath == fabricPath || strings.HasPrefix(importPath, fabricPath+"/")
+}
+
+// isFabricVendorDir returns true if the package directory is in the vendor directory of Fabric
+func isFabricVendorDir(dir string) bool {
+	fabricVendor := string(filepath.Separator) + filepath.Join(filepath.FromSlash(fabricPath), vendorDir) + string(filepath.Separator)
+	return strings.Contains(dir, fabricVendor)
+}
+
+// isStandardPackage returns true for packages of the standard library (and cgo), whose
+// import paths, unlike those of other packages, don't start with a domain name
+func isStandardPackage(importPath string) bool {
+	if importPath == "C" {
+		return true
+	}
+	elem := importPath
+	if i := strings.Index(importPath, "/"); i >= 0 {
+		elem = importPath[:i]
+	}
+	return !strings.Contains(elem, ".")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package javapackager packages Java chaincode for installation
+package javapackager
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	"github.com/pkg/errors"
+
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// Build files of which at least one must be present in the root of the project
+var buildFiles = []string{"build.gradle", "pom.xml"}
+
+// Build output directories and compiled files are not packaged since the peer builds the chaincode
+var (
+	excludedDirs      = map[string]bool{"target": true, "build": true, "out": true}
+	excludedFileTypes = map[string]bool{".class": true}
+)
+
+// NewCCPackage creates a new Java chaincode package from the Gradle or Maven project in the given
+// directory. Build output is not packaged. State database indexes are packaged from the META-INF
+// directory.
+func NewCCPackage(chaincodePath string) (*api.CCPackage, error) {
+	if chaincodePath == "" {
+		return nil, errors.New("chaincode path must be provided")
+	}
+
+	if !hasBuildFile(chaincodePath) {
+		return nil, errors.Errorf("one of %v is required in chaincode path", buildFiles)
+	}
+
+	descriptors, err := tarball.FindSource(chaincodePath, skipDir, skipFile)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := tarball.FindMetadata(chaincodePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tarBytes, err := tarball.Generate(append(descriptors, metadata...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CCPackage{Type: pb.ChaincodeSpec_JAVA, Code: tarBytes}, nil
+}
+
+func hasBuildFile(chaincodePath string) bool {
+	for _, f := range buildFiles {
+		if _, err := os.Stat(filepath.Join(chaincodePath, f)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func skipDir(rel string, info os.FileInfo) bool {
+	return excludedDirs[info.Name()]
+}
+
+func skipFile(rel string, info os.FileInfo) bool {
+	return excludedFileTypes[filepath.Ext(rel)]
+}
@@ -0,0 +1,73 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package javapackager
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// Test Java chaincode packaging
+func TestNewCCPackage(t *testing.T) {
+	dir := testutil.NewTestDir(t, "javacc", map[string]string{
+		"build.gradle":                       "// build",
+		"src/main/java/example/Example.java": "// chaincode",
+		"build/libs/example.jar":             "jar",
+		"target/classes/Example.class":       "class",
+		"src/main/java/example/Other.class":  "class",
+		"META-INF/statedb/couchdb/collections/coll1/indexes/indexOwner.json": `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+	})
+	defer os.RemoveAll(dir)
+
+	ccPackage, err := NewCCPackage(dir)
+	if err != nil {
+		t.Fatalf("error from NewCCPackage %v", err)
+	}
+	if ccPackage.Type != pb.ChaincodeSpec_JAVA {
+		t.Fatalf("unexpected package type %s", ccPackage.Type)
+	}
+
+	expected := map[string]bool{
+		"src/build.gradle":                       true,
+		"src/src/main/java/example/Example.java": true,
+		"META-INF/statedb/couchdb/collections/coll1/indexes/indexOwner.json": true,
+	}
+	names := testutil.TarballNames(t, ccPackage.Code)
+	if len(names) != len(expected) {
+		t.Fatalf("unexpected package contents %v", names)
+	}
+	for _, name := range names {
+		if !expected[name] {
+			t.Fatalf("unexpected file %s in package", name)
+		}
+	}
+}
+
+// Test Java chaincode packaging with invalid input
+func TestNewCCPackageErrors(t *testing.T) {
+	if _, err := NewCCPackage(""); err == nil {
+		t.Fatal("Package empty chaincode path must return an error")
+	}
+
+	dir := testutil.NewTestDir(t, "javacc", map[string]string{"src/main/java/example/Example.java": "// chaincode"})
+	defer os.RemoveAll(dir)
+	if _, err := NewCCPackage(dir); err == nil {
+		t.Fatal("Package without build file must return an error")
+	}
+
+	dir = testutil.NewTestDir(t, "javacc", map[string]string{
+		"pom.xml": "<project/>",
+		"META-INF/statedb/couchdb/indexes/index.json": `{"name":"noFields"}`,
+	})
+	defer os.RemoveAll(dir)
+	if _, err := NewCCPackage(dir); err == nil {
+		t.Fatal("Package with invalid index definition must return an error")
+	}
+}
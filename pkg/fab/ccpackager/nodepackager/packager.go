@@ -0,0 +1,58 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package nodepackager packages Node.js chaincode for installation
+package nodepackager
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/ccpackager/tarball"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	"github.com/pkg/errors"
+
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+const (
+	packageFile    = "package.json"
+	nodeModulesDir = "node_modules"
+)
+
+// NewCCPackage creates a new Node.js chaincode package from the project in the given directory.
+// The dependencies (node_modules) are not packaged since they are installed by the peer when the
+// chaincode is built. State database indexes are packaged from the META-INF directory.
+func NewCCPackage(chaincodePath string) (*api.CCPackage, error) {
+	if chaincodePath == "" {
+		return nil, errors.New("chaincode path must be provided")
+	}
+
+	if _, err := os.Stat(filepath.Join(chaincodePath, packageFile)); err != nil {
+		return nil, errors.Wrapf(err, "%s not found in chaincode path", packageFile)
+	}
+
+	descriptors, err := tarball.FindSource(chaincodePath, skipDir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := tarball.FindMetadata(chaincodePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tarBytes, err := tarball.Generate(append(descriptors, metadata...))
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CCPackage{Type: pb.ChaincodeSpec_NODE, Code: tarBytes}, nil
+}
+
+func skipDir(rel string, info os.FileInfo) bool {
+	return info.Name() == nodeModulesDir
+}
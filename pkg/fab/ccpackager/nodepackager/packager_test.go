@@ -0,0 +1,74 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package nodepackager
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+// Test Node.js chaincode packaging
+func TestNewCCPackage(t *testing.T) {
+	dir := testutil.NewTestDir(t, "nodecc", map[string]string{
+		"package.json":                  `{"name": "example_cc"}`,
+		"index.js":                      "// chaincode",
+		"lib/util.js":                   "// util",
+		"node_modules/fabric-shim/x.js": "// dependency",
+		"lib/node_modules/dep/y.js":     "// nested dependency",
+		"META-INF/statedb/couchdb/indexes/indexOwner.json": `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+	})
+	defer os.RemoveAll(dir)
+
+	ccPackage, err := NewCCPackage(dir)
+	if err != nil {
+		t.Fatalf("error from NewCCPackage %v", err)
+	}
+	if ccPackage.Type != pb.ChaincodeSpec_NODE {
+		t.Fatalf("unexpected package type %s", ccPackage.Type)
+	}
+
+	expected := map[string]bool{
+		"src/package.json": true,
+		"src/index.js":     true,
+		"src/lib/util.js":  true,
+		"META-INF/statedb/couchdb/indexes/indexOwner.json": true,
+	}
+	names := testutil.TarballNames(t, ccPackage.Code)
+	if len(names) != len(expected) {
+		t.Fatalf("unexpected package contents %v", names)
+	}
+	for _, name := range names {
+		if !expected[name] {
+			t.Fatalf("unexpected file %s in package", name)
+		}
+	}
+}
+
+// Test Node.js chaincode packaging with invalid input
+func TestNewCCPackageErrors(t *testing.T) {
+	if _, err := NewCCPackage(""); err == nil {
+		t.Fatal("Package empty chaincode path must return an error")
+	}
+
+	dir := testutil.NewTestDir(t, "nodecc", map[string]string{"index.js": "// chaincode"})
+	defer os.RemoveAll(dir)
+	if _, err := NewCCPackage(dir); err == nil {
+		t.Fatal("Package without package.json must return an error")
+	}
+
+	dir = testutil.NewTestDir(t, "nodecc", map[string]string{
+		"package.json":            `{"name": "example_cc"}`,
+		"META-INF/other/file.txt": "text",
+	})
+	defer os.RemoveAll(dir)
+	if _, err := NewCCPackage(dir); err == nil {
+		t.Fatal("Package with invalid metadata must return an error")
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package signedcds creates signed chaincode deployment packages, i.e. chaincode deployment specs (CDS)
+// with an instantiation policy and the endorsements of the chaincode owners.
+package signedcds
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// Package is a signed chaincode deployment package (a SignedChaincodeDeploymentSpec). The instantiation
+// policy of the package restricts who may instantiate the chaincode on a channel. It is serialized as a
+// CHAINCODE_PACKAGE envelope (the format produced by 'peer chaincode package -s' and 'peer chaincode signpackage')
+// so that it may be passed to the owners in each organization, signed independently and then merged before
+// being installed.
+type Package struct {
+	cds          *pb.ChaincodeDeploymentSpec
+	cdsBytes     []byte
+	policy       *common.SignaturePolicyEnvelope
+	policyBytes  []byte
+	endorsements []*pb.Endorsement
+}
+
+// New creates a package, without owner endorsements, for the given chaincode and instantiation policy
+func New(name, path, version string, ccPkg *api.CCPackage, policy *common.SignaturePolicyEnvelope) (*Package, error) {
+	if name == "" || path == "" || version == "" {
+		return nil, errors.New("chaincode name, path and version are required")
+	}
+	if ccPkg == nil {
+		return nil, errors.New("chaincode package is required")
+	}
+	if policy == nil {
+		return nil, errors.New("instantiation policy is required")
+	}
+
+	cds := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        ccPkg.Type,
+			ChaincodeId: &pb.ChaincodeID{Name: name, Path: path, Version: version},
+		},
+		CodePackage: ccPkg.Code,
+	}
+	cdsBytes, err := proto.Marshal(cds)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of chaincode deployment spec failed")
+	}
+	policyBytes, err := proto.Marshal(policy)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of instantiation policy failed")
+	}
+
+	return &Package{
+		cds:         cds,
+		cdsBytes:    cdsBytes,
+		policy:      policy,
+		policyBytes: policyBytes,
+	}, nil
+}
+
+// Parse parses a package which was serialized using Bytes. A package created
+// by 'peer chaincode package -s' may also be parsed.
+func Parse(envelopeBytes []byte) (*Package, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return nil, errors.Wrap(err, "unmarshal envelope failed")
+	}
+	return FromEnvelope(envelope)
+}
+
+// FromEnvelope returns the package contained in the given CHAINCODE_PACKAGE envelope
+func FromEnvelope(envelope *common.Envelope) (*Package, error) {
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, errors.Wrap(err, "unmarshal payload failed")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is missing")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, errors.Wrap(err, "unmarshal channel header failed")
+	}
+	if common.HeaderType(channelHeader.Type) != common.HeaderType_CHAINCODE_PACKAGE {
+		return nil, errors.Errorf("expecting envelope of type CHAINCODE_PACKAGE but got %s", common.HeaderType(channelHeader.Type))
+	}
+
+	signedCDS := &pb.SignedChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(payload.Data, signedCDS); err != nil {
+		return nil, errors.Wrap(err, "unmarshal signed chaincode deployment spec failed")
+	}
+	cds := &pb.ChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(signedCDS.ChaincodeDeploymentSpec, cds); err != nil {
+		return nil, errors.Wrap(err, "unmarshal chaincode deployment spec failed")
+	}
+	if cds.ChaincodeSpec == nil || cds.ChaincodeSpec.ChaincodeId == nil {
+		return nil, errors.New("chaincode deployment spec is missing the chaincode ID")
+	}
+	policy := &common.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(signedCDS.InstantiationPolicy, policy); err != nil {
+		return nil, errors.Wrap(err, "unmarshal instantiation policy failed")
+	}
+
+	p := &Package{
+		cds:         cds,
+		cdsBytes:    signedCDS.ChaincodeDeploymentSpec,
+		policy:      policy,
+		policyBytes: signedCDS.InstantiationPolicy,
+	}
+	p.AddEndorsements(signedCDS.OwnerEndorsements...)
+	return p, nil
+}
+
+// ChaincodeID returns the name, path and version of the chaincode
+func (p *Package) ChaincodeID() *pb.ChaincodeID {
+	return p.cds.ChaincodeSpec.ChaincodeId
+}
+
+// ChaincodeDeploymentSpec returns the chaincode deployment spec
+func (p *Package) ChaincodeDeploymentSpec() *pb.ChaincodeDeploymentSpec {
+	return p.cds
+}
+
+// InstantiationPolicy returns the instantiation policy
+func (p *Package) InstantiationPolicy() *common.SignaturePolicyEnvelope {
+	return p.policy
+}
+
+// OwnerEndorsements returns the owner endorsements collected so far
+func (p *Package) OwnerEndorsements() []*pb.Endorsement {
+	return p.endorsements
+}
+
+// Sign adds the endorsement of the given owner, or of the identity of the given context if owner is nil.
+// The owner signs the chaincode deployment spec, the instantiation policy and its own serialized identity.
+func (p *Package) Sign(ctx context.Client, owner msp.SigningIdentity) error {
+	if owner == nil {
+		owner = ctx
+	}
+
+	endorser, err := owner.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "failed to serialize owner identity")
+	}
+
+	signature, err := ctx.SigningManager().Sign(p.signedBytes(endorser), owner.PrivateKey())
+	if err != nil {
+		return errors.WithMessage(err, "signing of chaincode package failed")
+	}
+
+	p.AddEndorsements(&pb.Endorsement{Endorser: endorser, Signature: signature})
+	return nil
+}
+
+// AddEndorsements adds the given owner endorsements. An endorsement from an owner which has already
+// endorsed the package is ignored.
+func (p *Package) AddEndorsements(endorsements ...*pb.Endorsement) {
+	for _, endorsement := range endorsements {
+		if p.endorsedBy(endorsement.Endorser) {
+			logger.Debugf("Ignoring duplicate owner endorsement")
+			continue
+		}
+		p.endorsements = append(p.endorsements, endorsement)
+	}
+}
+
+// Merge adds the owner endorsements of the given packages, which must contain the same
+// chaincode deployment spec and instantiation policy
+func (p *Package) Merge(others ...*Package) error {
+	for _, other := range others {
+		if !bytes.Equal(other.cdsBytes, p.cdsBytes) {
+			return errors.New("cannot merge endorsements of a different chaincode deployment spec")
+		}
+		if !bytes.Equal(other.policyBytes, p.policyBytes) {
+			return errors.New("cannot merge endorsements of a package with a different instantiation policy")
+		}
+		p.AddEndorsements(other.endorsements...)
+	}
+	return nil
+}
+
+// Envelope returns the package as an (unsigned) CHAINCODE_PACKAGE envelope, as sent in an install proposal
+func (p *Package) Envelope() (*common.Envelope, error) {
+	signedCDS := &pb.SignedChaincodeDeploymentSpec{
+		ChaincodeDeploymentSpec: p.cdsBytes,
+		InstantiationPolicy:     p.policyBytes,
+		OwnerEndorsements:       p.endorsements,
+	}
+	data, err := proto.Marshal(signedCDS)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of signed chaincode deployment spec failed")
+	}
+
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_CHAINCODE_PACKAGE),
+		Timestamp: ptypes.TimestampNow(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of channel header failed")
+	}
+	signatureHeader, err := proto.Marshal(&common.SignatureHeader{})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of signature header failed")
+	}
+
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader, SignatureHeader: signatureHeader},
+		Data:   data,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of payload failed")
+	}
+
+	return &common.Envelope{Payload: payload}, nil
+}
+
+// Bytes serializes the package, including its owner endorsements
+func (p *Package) Bytes() ([]byte, error) {
+	envelope, err := p.Envelope()
+	if err != nil {
+		return nil, err
+	}
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of envelope failed")
+	}
+	return envelopeBytes, nil
+}
+
+// signedBytes returns the bytes signed by an owner: the chaincode deployment
+// spec, the instantiation policy and the owner's serialized identity
+func (p *Package) signedBytes(endorser []byte) []byte {
+	b := make([]byte, 0, len(p.cdsBytes)+len(p.policyBytes)+len(endorser))
+	b = append(b, p.cdsBytes...)
+	b = append(b, p.policyBytes...)
+	return append(b, endorser...)
+}
+
+func (p *Package) endorsedBy(endorser []byte) bool {
+	for _, endorsement := range p.endorsements {
+		if bytes.Equal(endorsement.Endorser, endorser) {
+			return true
+		}
+	}
+	return false
+}
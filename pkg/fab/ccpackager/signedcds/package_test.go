@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package signedcds
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource/api"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndMerge(t *testing.T) {
+	policy := cauthdsl.SignedByAnyAdmin([]string{"Org1MSP", "Org2MSP"})
+	ccPkg := &api.CCPackage{Type: pb.ChaincodeSpec_GOLANG, Code: []byte("code")}
+
+	pkg, err := New("examplecc", "github.com/example_cc", "v1", ccPkg, policy)
+	require.NoError(t, err)
+	assert.Equal(t, "examplecc", pkg.ChaincodeID().Name)
+	assert.Equal(t, []byte("code"), pkg.ChaincodeDeploymentSpec().CodePackage)
+	assert.True(t, proto.Equal(policy, pkg.InstantiationPolicy()))
+
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP"))
+	org1Admin := newTestIdentity("Org1MSP")
+	org2Admin := newTestIdentity("Org2MSP")
+
+	require.NoError(t, pkg.Sign(ctx, org1Admin))
+	require.NoError(t, pkg.Sign(ctx, org1Admin))
+	assert.Len(t, pkg.OwnerEndorsements(), 1, "expecting duplicate endorsement to be ignored")
+	assert.Equal(t, []byte("Org1MSP"), pkg.OwnerEndorsements()[0].Endorser)
+
+	// Serialize and sign elsewhere
+	pkgBytes, err := pkg.Bytes()
+	require.NoError(t, err)
+	other, err := Parse(pkgBytes)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(pkg.ChaincodeDeploymentSpec(), other.ChaincodeDeploymentSpec()))
+	assert.Len(t, other.OwnerEndorsements(), 1)
+	require.NoError(t, other.Sign(ctx, org2Admin))
+	assert.Len(t, other.OwnerEndorsements(), 2)
+
+	require.NoError(t, pkg.Merge(other))
+	assert.Len(t, pkg.OwnerEndorsements(), 2)
+
+	different, err := New("examplecc", "github.com/example_cc", "v2", ccPkg, policy)
+	require.NoError(t, err)
+	assert.Error(t, pkg.Merge(different), "expecting error merging a different chaincode")
+	different, err = New("examplecc", "github.com/example_cc", "v1", ccPkg, cauthdsl.SignedByMspAdmin("Org1MSP"))
+	require.NoError(t, err)
+	assert.Error(t, pkg.Merge(different), "expecting error merging a different instantiation policy")
+}
+
+func TestEnvelope(t *testing.T) {
+	pkg, err := New("examplecc", "github.com/example_cc", "v1", &api.CCPackage{Code: []byte("code")}, cauthdsl.AcceptAllPolicy)
+	require.NoError(t, err)
+
+	envelope, err := pkg.Envelope()
+	require.NoError(t, err)
+
+	payload := &common.Payload{}
+	require.NoError(t, proto.Unmarshal(envelope.Payload, payload))
+	channelHeader := &common.ChannelHeader{}
+	require.NoError(t, proto.Unmarshal(payload.Header.ChannelHeader, channelHeader))
+	assert.Equal(t, int32(common.HeaderType_CHAINCODE_PACKAGE), channelHeader.Type)
+
+	signedCDS := &pb.SignedChaincodeDeploymentSpec{}
+	require.NoError(t, proto.Unmarshal(payload.Data, signedCDS))
+	cds := &pb.ChaincodeDeploymentSpec{}
+	require.NoError(t, proto.Unmarshal(signedCDS.ChaincodeDeploymentSpec, cds))
+	assert.Equal(t, "examplecc", cds.ChaincodeSpec.ChaincodeId.Name)
+
+	// Envelopes of other types are rejected
+	payloadBytes, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: marshalOrPanic(&common.ChannelHeader{Type: int32(common.HeaderType_CONFIG_UPDATE)})},
+		Data:   payload.Data,
+	})
+	require.NoError(t, err)
+	_, err = FromEnvelope(&common.Envelope{Payload: payloadBytes})
+	assert.Error(t, err)
+
+	_, err = Parse([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestNewRequiredParameters(t *testing.T) {
+	ccPkg := &api.CCPackage{Code: []byte("code")}
+
+	_, err := New("", "path", "v1", ccPkg, cauthdsl.AcceptAllPolicy)
+	assert.Error(t, err)
+	_, err = New("examplecc", "path", "v1", nil, cauthdsl.AcceptAllPolicy)
+	assert.Error(t, err)
+	_, err = New("examplecc", "path", "v1", ccPkg, nil)
+	assert.Error(t, err)
+}
+
+// testIdentity is a signing identity which serializes to its MSP ID
+type testIdentity struct {
+	*mspmocks.MockSigningIdentity
+	mspID string
+}
+
+func newTestIdentity(mspID string) msp.SigningIdentity {
+	return &testIdentity{MockSigningIdentity: mspmocks.NewMockSigningIdentity("admin", mspID), mspID: mspID}
+}
+
+func (i *testIdentity) Serialize() ([]byte, error) {
+	return []byte(i.mspID), nil
+}
+
+func marshalOrPanic(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
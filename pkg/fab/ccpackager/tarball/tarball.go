@@ -0,0 +1,218 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tarball provides the functionality shared by the chaincode packagers: the generation of
+// the .tar.gz code package and the inclusion of the chaincode metadata (META-INF) directory.
+package tarball
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MetadataDir is the name of the chaincode metadata directory, located in the root
+	// directory of the chaincode. Its contents are packaged under the same name.
+	MetadataDir = "META-INF"
+
+	// SourceDir is the directory under which the chaincode source is packaged
+	SourceDir = "src"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// Descriptor describes a file to be included in a code package
+type Descriptor struct {
+	Name string // Name of the file within the package
+	Path string // Path of the file on the file system
+}
+
+// Generate creates a .tar.gz stream from the provided descriptors. Deterministic
+// headers are used so that the same files always result in the same package.
+func Generate(descriptors []*Descriptor) ([]byte, error) {
+	var codePackage bytes.Buffer
+	gw := gzip.NewWriter(&codePackage)
+	tw := tar.NewWriter(gw)
+
+	for _, d := range descriptors {
+		logger.Debugf("packaging %s as %s", d.Path, d.Name)
+		if err := packEntry(tw, d); err != nil {
+			closeStream(tw, gw)
+			return nil, errors.Wrapf(err, "packaging of %s failed", d.Path)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing tar writer failed")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing gzip writer failed")
+	}
+	return codePackage.Bytes(), nil
+}
+
+func closeStream(tw *tar.Writer, gw *gzip.Writer) {
+	tw.Close()
+	gw.Close()
+}
+
+func packEntry(tw *tar.Writer, descriptor *Descriptor) error {
+	file, err := os.Open(descriptor.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	header := &tar.Header{
+		Name: filepath.ToSlash(descriptor.Name),
+		Size: stat.Size(),
+		Mode: int64(stat.Mode()),
+		// Use a deterministic "zero-time" for all date fields
+		ModTime:    time.Time{},
+		AccessTime: time.Time{},
+		ChangeTime: time.Time{},
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// FindSource returns the descriptors of the regular files in the given root directory, named
+// relative to the root and prefixed with SourceDir. The metadata directory is not included and
+// directories for which skipDir returns true are skipped, as are files for which skipFile
+// returns true. Either function may be nil.
+func FindSource(root string, skipDir func(rel string, info os.FileInfo) bool, skipFile func(rel string, info os.FileInfo) bool) ([]*Descriptor, error) {
+	var descriptors []*Descriptor
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if rel == MetadataDir || (rel != "." && skipDir != nil && skipDir(rel, info)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !info.Mode().IsRegular() || (skipFile != nil && skipFile(rel, info)) {
+			return nil
+		}
+
+		descriptors = append(descriptors, &Descriptor{Name: filepath.Join(SourceDir, rel), Path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading source from %s failed", root)
+	}
+
+	return descriptors, nil
+}
+
+// FindMetadata returns the descriptors of the files in the metadata (META-INF) directory of the
+// given chaincode directory. The directory is optional. Only state database index definitions
+// are allowed, i.e. JSON files in statedb/couchdb/indexes or in
+// statedb/couchdb/collections/<collection>/indexes.
+func FindMetadata(chaincodeDir string) ([]*Descriptor, error) {
+	metadataPath := filepath.Join(chaincodeDir, MetadataDir)
+	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var descriptors []*Descriptor
+	err := filepath.Walk(metadataPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(chaincodeDir, path)
+		if err != nil {
+			return err
+		}
+		if err := validateMetadataFile(rel, path); err != nil {
+			return err
+		}
+
+		descriptors = append(descriptors, &Descriptor{Name: rel, Path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading metadata from %s failed", metadataPath)
+	}
+
+	return descriptors, nil
+}
+
+// validateMetadataFile checks that the file is an index definition in one of the supported locations
+func validateMetadataFile(rel, path string) error {
+	dir := filepath.ToSlash(filepath.Dir(rel))
+	if !isIndexDir(dir) {
+		return errors.Errorf("metadata file [%s] is not in a supported directory", rel)
+	}
+	if filepath.Ext(rel) != ".json" {
+		return errors.Errorf("index definition [%s] must be a JSON file", rel)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var index struct {
+		Index *struct {
+			Fields []interface{} `json:"fields"`
+		} `json:"index"`
+	}
+	if err := json.Unmarshal(b, &index); err != nil {
+		return errors.Wrapf(err, "index definition [%s] is not valid JSON", rel)
+	}
+	if index.Index == nil || len(index.Index.Fields) == 0 {
+		return errors.Errorf("index definition [%s] must specify the index fields", rel)
+	}
+
+	return nil
+}
+
+func isIndexDir(dir string) bool {
+	const couchDBDir = MetadataDir + "/statedb/couchdb/"
+
+	if dir == couchDBDir+"indexes" {
+		return true
+	}
+
+	collection := strings.TrimPrefix(dir, couchDBDir+"collections/")
+	if collection == dir {
+		return false
+	}
+	parts := strings.Split(collection, "/")
+	return len(parts) == 2 && parts[0] != "" && parts[1] == "indexes"
+}
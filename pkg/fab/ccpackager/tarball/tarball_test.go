@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tarball
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
+)
+
+// Test packEntry and Generate with empty file Descriptor
+func TestEmptyPackEntry(t *testing.T) {
+	emptyDescriptor := &Descriptor{"NewFile", ""}
+	err := packEntry(nil, emptyDescriptor)
+	if err == nil {
+		t.Fatal("packEntry call with empty descriptor info must throw an error")
+	}
+
+	_, err = Generate([]*Descriptor{emptyDescriptor})
+	if err == nil {
+		t.Fatal("Generate call with empty descriptor info must throw an error")
+	}
+}
+
+// Test the locations allowed for index definitions
+func TestIsIndexDir(t *testing.T) {
+	valid := []string{
+		"META-INF/statedb/couchdb/indexes",
+		"META-INF/statedb/couchdb/collections/coll1/indexes",
+	}
+	for _, dir := range valid {
+		if !isIndexDir(dir) {
+			t.Fatalf("expected %s to be a valid index directory", dir)
+		}
+	}
+
+	invalid := []string{
+		"META-INF",
+		"META-INF/statedb/couchdb",
+		"META-INF/statedb/couchdb/indexes/sub",
+		"META-INF/statedb/couchdb/collections/indexes",
+		"META-INF/statedb/couchdb/collections/coll1/other",
+		"META-INF/statedb/leveldb/indexes",
+	}
+	for _, dir := range invalid {
+		if isIndexDir(dir) {
+			t.Fatalf("expected %s to be an invalid index directory", dir)
+		}
+	}
+}
+
+// Test that Generate packages the files under their names, with deterministic headers
+func TestGenerate(t *testing.T) {
+	dir := testutil.NewTestDir(t, "tarball", map[string]string{"main.go": "package main", "lib/lib.go": "package lib"})
+	defer os.RemoveAll(dir)
+
+	descriptors := []*Descriptor{
+		{Name: "src/cc/main.go", Path: filepath.Join(dir, "main.go")},
+		{Name: "src/cc/lib/lib.go", Path: filepath.Join(dir, "lib", "lib.go")},
+	}
+	codePackage, err := Generate(descriptors)
+	if err != nil {
+		t.Fatalf("Generate failed: %s", err)
+	}
+
+	entries := testutil.ReadTarball(t, codePackage)
+	if len(entries) != 2 || entries["src/cc/main.go"] != "package main" || entries["src/cc/lib/lib.go"] != "package lib" {
+		t.Fatalf("unexpected package entries: %v", entries)
+	}
+
+	again, err := Generate(descriptors)
+	if err != nil {
+		t.Fatalf("Generate failed: %s", err)
+	}
+	if !bytes.Equal(codePackage, again) {
+		t.Fatal("expected the same files to result in the same package")
+	}
+}
+
+// Test that FindSource skips the metadata directory and the directories and files that are filtered out
+func TestFindSource(t *testing.T) {
+	dir := testutil.NewTestDir(t, "tarball", map[string]string{
+		"main.go":          "package main",
+		"README.md":        "readme",
+		"lib/lib.go":       "package lib",
+		"testdata/data.go": "package testdata",
+		"META-INF/statedb/couchdb/indexes/index.json": `{"index":{"fields":["a"]}}`,
+	})
+	defer os.RemoveAll(dir)
+
+	skipDir := func(rel string, info os.FileInfo) bool { return info.Name() == "testdata" }
+	skipFile := func(rel string, info os.FileInfo) bool { return filepath.Ext(rel) != ".go" }
+	descriptors, err := FindSource(dir, skipDir, skipFile)
+	if err != nil {
+		t.Fatalf("FindSource failed: %s", err)
+	}
+	expected := []string{filepath.Join(SourceDir, "lib", "lib.go"), filepath.Join(SourceDir, "main.go")}
+	if names := descriptorNames(descriptors); strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected source files %v, got %v", expected, names)
+	}
+
+	descriptors, err = FindSource(dir, nil, nil)
+	if err != nil {
+		t.Fatalf("FindSource failed: %s", err)
+	}
+	if len(descriptors) != 4 {
+		t.Fatalf("expected all source files outside of the metadata directory, got %v", descriptorNames(descriptors))
+	}
+
+	if _, err := FindSource(filepath.Join(dir, "missing"), nil, nil); err == nil {
+		t.Fatal("expected error for missing source directory")
+	}
+}
+
+// Test that FindMetadata only accepts index definitions in the supported directories
+func TestFindMetadata(t *testing.T) {
+	dir := testutil.NewTestDir(t, "tarball", map[string]string{
+		"META-INF/statedb/couchdb/indexes/index.json":                   `{"index":{"fields":["a"]}}`,
+		"META-INF/statedb/couchdb/collections/coll1/indexes/index.json": `{"index":{"fields":["b"]}}`,
+	})
+	defer os.RemoveAll(dir)
+
+	descriptors, err := FindMetadata(dir)
+	if err != nil {
+		t.Fatalf("FindMetadata failed: %s", err)
+	}
+	expected := []string{
+		filepath.Join(MetadataDir, "statedb", "couchdb", "collections", "coll1", "indexes", "index.json"),
+		filepath.Join(MetadataDir, "statedb", "couchdb", "indexes", "index.json"),
+	}
+	if names := descriptorNames(descriptors); strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected metadata files %v, got %v", expected, names)
+	}
+
+	noMetadata := testutil.NewTestDir(t, "tarball", map[string]string{"main.go": "package main"})
+	defer os.RemoveAll(noMetadata)
+	descriptors, err = FindMetadata(noMetadata)
+	if err != nil || len(descriptors) != 0 {
+		t.Fatalf("expected no metadata without metadata directory, got %v, %v", descriptors, err)
+	}
+
+	invalid := map[string]string{
+		"META-INF/other/index.json":                    `{"index":{"fields":["a"]}}`,
+		"META-INF/statedb/couchdb/indexes/index.txt":   `{"index":{"fields":["a"]}}`,
+		"META-INF/statedb/couchdb/indexes/index.json":  `{"index":`,
+		"META-INF/statedb/couchdb/indexes/fields.json": `{"index":{"fields":[]}}`,
+	}
+	for name, content := range invalid {
+		invalidDir := testutil.NewTestDir(t, "tarball", map[string]string{name: content})
+		_, err := FindMetadata(invalidDir)
+		os.RemoveAll(invalidDir)
+		if err == nil {
+			t.Fatalf("expected error for metadata file %s", name)
+		}
+	}
+}
+
+func descriptorNames(descriptors []*Descriptor) []string {
+	var names []string
+	for _, d := range descriptors {
+		names = append(names, d.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -25,8 +25,9 @@ import (
 var logger = logging.NewLogger("fabsdk/fab")
 
 const (
-	lscc           = "lscc"
-	lsccChaincodes = "getchaincodes"
+	lscc            = "lscc"
+	lsccChaincodes  = "getchaincodes"
+	lsccCollections = "getcollectionsconfig"
 )
 
 // Ledger is a client that provides access to the underlying ledger of a channel.
@@ -198,6 +199,33 @@ func (c *Ledger) QueryInstantiatedChaincodes(reqCtx reqContext.Context, targets
 	return responses, errs
 }
 
+// QueryCollectionsConfig queries the private data collection configurations of the given chaincode
+// on this channel. This query will be made to specified targets.
+func (c *Ledger) QueryCollectionsConfig(reqCtx reqContext.Context, chaincodeName string, targets []fab.ProposalProcessor, verifier ResponseVerifier) ([]*common.CollectionConfigPackage, error) {
+	cir := createCollectionsConfigInvokeRequest(chaincodeName)
+	tprs, errs := queryChaincode(reqCtx, c.chName, cir, targets, verifier)
+
+	responses := []*common.CollectionConfigPackage{}
+	for _, tpr := range tprs {
+		r, err := createCollectionsConfigQueryResponse(tpr)
+		if err != nil {
+			errs = multi.Append(errs, errors.WithMessage(err, "From target: "+tpr.Endorser))
+		} else {
+			responses = append(responses, r)
+		}
+	}
+	return responses, errs
+}
+
+func createCollectionsConfigQueryResponse(tpr *fab.TransactionProposalResponse) (*common.CollectionConfigPackage, error) {
+	response := common.CollectionConfigPackage{}
+	err := proto.Unmarshal(tpr.ProposalResponse.GetResponse().Payload, &response)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal of transaction proposal response failed")
+	}
+	return &response, nil
+}
+
 func createChaincodeQueryResponse(tpr *fab.TransactionProposalResponse) (*pb.ChaincodeQueryResponse, error) {
 	response := pb.ChaincodeQueryResponse{}
 	err := proto.Unmarshal(tpr.ProposalResponse.GetResponse().Payload, &response)
@@ -282,3 +310,12 @@ func createChaincodeInvokeRequest() fab.ChaincodeInvokeRequest {
 	}
 	return cir
 }
+
+func createCollectionsConfigInvokeRequest(chaincodeName string) fab.ChaincodeInvokeRequest {
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lscc,
+		Fcn:         lsccCollections,
+		Args:        [][]byte{[]byte(chaincodeName)},
+	}
+	return cir
+}
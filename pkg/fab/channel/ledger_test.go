@@ -313,3 +313,21 @@ func (tv *TestVerifier) Verify(response *fab.TransactionProposalResponse) error
 func (tv *TestVerifier) Match(response []*fab.TransactionProposalResponse) error {
 	return tv.matchErr
 }
+
+func TestQueryCollectionsConfig(t *testing.T) {
+	channel, _ := setupTestLedger()
+	peer := mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com", MockRoles: []string{}, MockCert: nil, Status: 200}
+
+	reqCtx, cancel := context.NewRequest(setupContext(), context.WithTimeout(10*time.Second))
+	defer cancel()
+
+	res, err := channel.QueryCollectionsConfig(reqCtx, "examplecc", []fab.ProposalProcessor{&peer}, nil)
+	if err != nil || len(res) != 1 {
+		t.Fatalf("Test QueryCollectionsConfig failed: %v", err)
+	}
+
+	cir := createCollectionsConfigInvokeRequest("examplecc")
+	if cir.Fcn != lsccCollections || len(cir.Args) != 1 || string(cir.Args[0]) != "examplecc" {
+		t.Fatalf("unexpected collections config invoke request: %v", cir)
+	}
+}
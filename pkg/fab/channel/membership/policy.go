@@ -0,0 +1,234 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package membership
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// PolicyEvaluator evaluates endorsements against a chaincode's endorsement policy using the channel's MSPs
+type PolicyEvaluator struct {
+	mspManager msp.MSPManager
+}
+
+// SignedData contains a signature over some data along with the serialized identity of the signer
+type SignedData struct {
+	Data      []byte
+	Identity  []byte
+	Signature []byte
+}
+
+// NewPolicyEvaluator returns a PolicyEvaluator for the MSPs of the given channel configuration
+func NewPolicyEvaluator(cs core.CryptoSuite, cfg fab.ChannelCfg) (*PolicyEvaluator, error) {
+	return NewPolicyEvaluatorForMSPs(cs, cfg.MSPs())
+}
+
+// NewPolicyEvaluatorForMSPs returns a PolicyEvaluator for the given MSPs
+func NewPolicyEvaluatorForMSPs(cs core.CryptoSuite, mspConfigs []*mb.MSPConfig) (*PolicyEvaluator, error) {
+	mspManager := msp.NewMSPManager()
+	msps, err := loadMSPs(mspConfigs, cs)
+	if err != nil {
+		return nil, errors.WithMessage(err, "load MSPs from config failed")
+	}
+	if err := mspManager.Setup(msps); err != nil {
+		return nil, errors.WithMessage(err, "MSPManager Setup failed")
+	}
+	return &PolicyEvaluator{mspManager: mspManager}, nil
+}
+
+// Evaluate verifies the endorsement of each proposal response and checks that the endorsers satisfy the policy.
+// A status error naming the principals that were not satisfied is returned if the policy is not met.
+func (e *PolicyEvaluator) Evaluate(policy *common.SignaturePolicyEnvelope, responses []*fab.TransactionProposalResponse) error {
+	if policy == nil || policy.Rule == nil {
+		return errors.New("signature policy is required")
+	}
+
+	var identities []msp.Identity
+	for _, r := range responses {
+		endorsement := r.ProposalResponse.GetEndorsement()
+		if endorsement == nil {
+			return errors.WithStack(status.New(status.EndorserClientStatus, status.MissingEndorsement.ToInt32(), "missing endorsement in proposal response", []interface{}{r.Endorser}))
+		}
+
+		id, err := e.mspManager.DeserializeIdentity(endorsement.Endorser)
+		if err != nil {
+			return errors.WithStack(status.New(status.EndorserClientStatus, status.SignatureVerificationFailed.ToInt32(), "the endorser identity is not valid", []interface{}{r.Endorser, err.Error()}))
+		}
+
+		digest := append(append([]byte{}, r.ProposalResponse.GetPayload()...), endorsement.Endorser...)
+		if err := id.Verify(digest, endorsement.Signature); err != nil {
+			return errors.WithStack(status.New(status.EndorserClientStatus, status.SignatureVerificationFailed.ToInt32(), "the endorser's signature over the proposal response is not valid", []interface{}{r.Endorser, err.Error()}))
+		}
+
+		identities = append(identities, id)
+	}
+
+	missing, err := e.evaluate(policy, identities)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errors.WithStack(status.New(status.EndorserClientStatus, status.EndorsementPolicyFailure.ToInt32(),
+			fmt.Sprintf("endorsement policy not satisfied, missing principals [%s]", strings.Join(missing, ", ")), nil))
+	}
+	return nil
+}
+
+// EvaluateSignedData verifies each signature and checks that the signers satisfy the policy. Signatures whose
+// identity is not valid are ignored, as is done by the orderer when evaluating signature sets.
+func (e *PolicyEvaluator) EvaluateSignedData(policy *common.SignaturePolicyEnvelope, signedData []*SignedData) error {
+	if policy == nil || policy.Rule == nil {
+		return errors.New("signature policy is required")
+	}
+
+	var identities []msp.Identity
+	seen := make(map[string]bool)
+	for _, sd := range signedData {
+		if seen[string(sd.Identity)] {
+			// The same identity may only be counted once
+			continue
+		}
+		id, err := e.mspManager.DeserializeIdentity(sd.Identity)
+		if err != nil {
+			logger.Warnf("Ignoring signature since the identity could not be deserialized: %s", err)
+			continue
+		}
+		if err := id.Validate(); err != nil {
+			logger.Warnf("Ignoring signature since the identity is not valid: %s", err)
+			continue
+		}
+		if err := id.Verify(sd.Data, sd.Signature); err != nil {
+			logger.Warnf("Ignoring signature since it could not be verified: %s", err)
+			continue
+		}
+		seen[string(sd.Identity)] = true
+		identities = append(identities, id)
+	}
+
+	missing, err := e.evaluate(policy, identities)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("signature policy not satisfied, missing principals [%s]", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// evaluate evaluates the policy against the given (verified) identities and returns
+// the names of the principals that were not satisfied, if any
+func (e *PolicyEvaluator) evaluate(policy *common.SignaturePolicyEnvelope, identities []msp.Identity) ([]string, error) {
+	eval := &policyEvaluation{identities: identities, principals: policy.Identities}
+	ok, missingPrincipals, err := eval.evaluate(policy.Rule, make([]bool, len(identities)))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	var missing []string
+	for p := range missingPrincipals {
+		missing = append(missing, p)
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// policyEvaluation holds the state of the evaluation of a policy rule tree
+type policyEvaluation struct {
+	identities []msp.Identity
+	principals []*mb.MSPPrincipal
+}
+
+// evaluate walks the rule tree the same way the committing peer does: every identity
+// satisfies at most one principal and the identities used by a satisfied n-of-m rule
+// are consumed. If the rule isn't satisfied, the principals of the rules which left it
+// unsatisfied are returned.
+func (e *policyEvaluation) evaluate(rule *common.SignaturePolicy, used []bool) (bool, map[string]bool, error) {
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(e.principals) {
+			return false, nil, errors.Errorf("identity index out of range, requested %d, but identities length is %d", t.SignedBy, len(e.principals))
+		}
+		principal := e.principals[t.SignedBy]
+		for i, id := range e.identities {
+			if used[i] {
+				continue
+			}
+			if id.SatisfiesPrincipal(principal) == nil {
+				used[i] = true
+				return true, nil, nil
+			}
+		}
+		return false, map[string]bool{principalName(principal): true}, nil
+
+	case *common.SignaturePolicy_NOutOf_:
+		verified := int32(0)
+		missing := make(map[string]bool)
+		_used := make([]bool, len(used))
+		copy(_used, used)
+		for _, r := range t.NOutOf.Rules {
+			ok, ruleMissing, err := e.evaluate(r, _used)
+			if err != nil {
+				return false, nil, err
+			}
+			if ok {
+				verified++
+				continue
+			}
+			for p := range ruleMissing {
+				missing[p] = true
+			}
+		}
+		if verified >= t.NOutOf.N {
+			copy(used, _used)
+			return true, nil, nil
+		}
+		return false, missing, nil
+
+	default:
+		return false, nil, errors.Errorf("unknown signature policy type: %T", t)
+	}
+}
+
+// principalName returns a human readable representation of the principal, e.g. Org1MSP.member
+func principalName(principal *mb.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "invalid role principal"
+		}
+		return fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return "invalid organization unit principal"
+		}
+		return fmt.Sprintf("%s.%s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+	case mb.MSPPrincipal_IDENTITY:
+		sID := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, sID); err != nil {
+			return "invalid identity principal"
+		}
+		return fmt.Sprintf("%s.identity", sID.Mspid)
+	default:
+		return principal.PrincipalClassification.String()
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package membership
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyEvaluator(t *testing.T) {
+	org1CA, org1Key := testutil.GenerateCA(t, "org1")
+	org2CA, org2Key := testutil.GenerateCA(t, "org2")
+
+	cfg := mocks.NewMockChannelCfg("")
+	cfg.MockMSPs = []*mb.MSPConfig{
+		buildPolicyMSPConfig("Org1MSP", org1CA),
+		buildPolicyMSPConfig("Org2MSP", org2CA),
+	}
+	evaluator, err := NewPolicyEvaluator(mocks.NewMockProviderContext().CryptoSuite(), cfg)
+	assert.Nil(t, err, "NewPolicyEvaluator failed")
+
+	org1Peer := endorsedResponse(t, "Org1MSP", testutil.GenerateCert(t, "peer0.org1", org1CA, org1Key))
+	org1Peer2 := endorsedResponse(t, "Org1MSP", testutil.GenerateCert(t, "peer1.org1", org1CA, org1Key))
+	org2Peer := endorsedResponse(t, "Org2MSP", testutil.GenerateCert(t, "peer0.org2", org2CA, org2Key))
+
+	org1Member := cauthdsl.SignedByMspMember("Org1MSP").Identities[0]
+	org2Member := cauthdsl.SignedByMspMember("Org2MSP").Identities[0]
+
+	andPolicy := &cb.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)),
+		Identities: []*mb.MSPPrincipal{org1Member, org2Member},
+	}
+
+	err = evaluator.Evaluate(andPolicy, []*fab.TransactionProposalResponse{org1Peer, org2Peer})
+	assert.Nil(t, err, "expected AND policy to be satisfied")
+
+	err = evaluator.Evaluate(cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"}), []*fab.TransactionProposalResponse{org2Peer})
+	assert.Nil(t, err, "expected OR policy to be satisfied")
+
+	// Two endorsements from the same org do not satisfy the second principal
+	err = evaluator.Evaluate(andPolicy, []*fab.TransactionProposalResponse{org1Peer, org1Peer2})
+	assert.NotNil(t, err, "expected AND policy not to be satisfied")
+	statusError, ok := status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.EndorsementPolicyFailure.ToInt32(), statusError.Code)
+	assert.True(t, strings.Contains(statusError.Message, "Org2MSP.member"), "expected missing principal in error: %s", statusError.Message)
+	assert.False(t, strings.Contains(statusError.Message, "Org1MSP.member"), "unexpected principal in error: %s", statusError.Message)
+
+	// The same endorsement can only be used once
+	twoOfOrg1 := &cb.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.NOutOf(2, []*cb.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(0)}),
+		Identities: []*mb.MSPPrincipal{org1Member},
+	}
+	assert.NotNil(t, evaluator.Evaluate(twoOfOrg1, []*fab.TransactionProposalResponse{org1Peer}))
+	assert.Nil(t, evaluator.Evaluate(twoOfOrg1, []*fab.TransactionProposalResponse{org1Peer, org1Peer2}))
+
+	// Endorsers from unknown MSPs are rejected
+	unknown := endorsedResponse(t, "Org3MSP", testutil.GenerateCert(t, "peer0.org1", org1CA, org1Key))
+	err = evaluator.Evaluate(andPolicy, []*fab.TransactionProposalResponse{unknown})
+	statusError, ok = status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.SignatureVerificationFailed.ToInt32(), statusError.Code)
+
+	// Only the principals of the rules which left the policy unsatisfied are reported
+	org3Member := cauthdsl.SignedByMspMember("Org3MSP").Identities[0]
+	nestedPolicy := &cb.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.And(cauthdsl.Or(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)), cauthdsl.SignedBy(2)),
+		Identities: []*mb.MSPPrincipal{org1Member, org2Member, org3Member},
+	}
+	for _, responses := range [][]*fab.TransactionProposalResponse{{org1Peer}, {org1Peer, org2Peer}} {
+		err = evaluator.Evaluate(nestedPolicy, responses)
+		statusError, ok = status.FromError(err)
+		assert.True(t, ok, "expected status error")
+		assert.EqualValues(t, status.EndorsementPolicyFailure.ToInt32(), statusError.Code)
+		assert.True(t, strings.HasSuffix(statusError.Message, "missing principals [Org3MSP.member]"), "unexpected missing principals in error: %s", statusError.Message)
+	}
+
+	// Missing endorsement
+	org1Peer.ProposalResponse.Endorsement = nil
+	err = evaluator.Evaluate(andPolicy, []*fab.TransactionProposalResponse{org1Peer})
+	statusError, ok = status.FromError(err)
+	assert.True(t, ok, "expected status error")
+	assert.EqualValues(t, status.MissingEndorsement.ToInt32(), statusError.Code)
+}
+
+func TestEvaluateSignedData(t *testing.T) {
+	org1CA, org1Key := testutil.GenerateCA(t, "org1")
+	org2CA, org2Key := testutil.GenerateCA(t, "org2")
+
+	evaluator, err := NewPolicyEvaluatorForMSPs(mocks.NewMockProviderContext().CryptoSuite(), []*mb.MSPConfig{
+		buildPolicyMSPConfig("Org1MSP", org1CA),
+		buildPolicyMSPConfig("Org2MSP", org2CA),
+	})
+	assert.Nil(t, err, "NewPolicyEvaluatorForMSPs failed")
+
+	org1Admin := signedData(t, "Org1MSP", testutil.GenerateCert(t, "admin.org1", org1CA, org1Key))
+	org1User := signedData(t, "Org1MSP", testutil.GenerateCert(t, "user.org1", org1CA, org1Key))
+	org2Admin := signedData(t, "Org2MSP", testutil.GenerateCert(t, "admin.org2", org2CA, org2Key))
+
+	org1Member := cauthdsl.SignedByMspMember("Org1MSP").Identities[0]
+	org2Member := cauthdsl.SignedByMspMember("Org2MSP").Identities[0]
+	andPolicy := &cb.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.And(cauthdsl.SignedBy(0), cauthdsl.SignedBy(1)),
+		Identities: []*mb.MSPPrincipal{org1Member, org2Member},
+	}
+
+	assert.Nil(t, evaluator.EvaluateSignedData(andPolicy, []*SignedData{org1Admin, org2Admin}))
+
+	err = evaluator.EvaluateSignedData(andPolicy, []*SignedData{org1Admin})
+	assert.NotNil(t, err, "expected AND policy not to be satisfied")
+	assert.True(t, strings.Contains(err.Error(), "Org2MSP.member"), "expected missing principal in error: %s", err)
+
+	// The same identity is only counted once
+	twoOfOrg1 := &cb.SignaturePolicyEnvelope{
+		Rule:       cauthdsl.NOutOf(2, []*cb.SignaturePolicy{cauthdsl.SignedBy(0), cauthdsl.SignedBy(0)}),
+		Identities: []*mb.MSPPrincipal{org1Member},
+	}
+	assert.NotNil(t, evaluator.EvaluateSignedData(twoOfOrg1, []*SignedData{org1Admin, org1Admin}))
+	assert.Nil(t, evaluator.EvaluateSignedData(twoOfOrg1, []*SignedData{org1Admin, org1User}))
+
+	// Signatures from unknown MSPs are ignored
+	unknown := signedData(t, "Org3MSP", testutil.GenerateCert(t, "admin.org3", org1CA, org1Key))
+	assert.NotNil(t, evaluator.EvaluateSignedData(andPolicy, []*SignedData{org1Admin, unknown}))
+}
+
+func signedData(t *testing.T, mspID string, cert []byte) *SignedData {
+	identity, err := proto.Marshal(&mb.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	assert.Nil(t, err)
+	return &SignedData{Data: []byte("data"), Identity: identity, Signature: []byte("signature")}
+}
+
+func endorsedResponse(t *testing.T, mspID string, cert []byte) *fab.TransactionProposalResponse {
+	endorser, err := proto.Marshal(&mb.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	assert.Nil(t, err)
+
+	return &fab.TransactionProposalResponse{
+		Endorser: mspID,
+		ProposalResponse: &pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200},
+			Payload:     []byte("payload"),
+			Endorsement: &pb.Endorsement{Endorser: endorser, Signature: []byte("signature")},
+		},
+	}
+}
+
+func buildPolicyMSPConfig(name string, root []byte) *mb.MSPConfig {
+	config := buildfabricMSPConfig(name, root)
+	config.RevocationList = nil
+	return &mb.MSPConfig{Config: marshalOrPanic(config)}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
@@ -157,3 +158,31 @@ func (t *Transactor) SendTransaction(tx *fab.Transaction) (*fab.TransactionRespo
 
 	return txn.Send(reqCtx, tx, t.orderers)
 }
+
+// SendTransactionBatch sends several transactions to the chain’s orderer service together. The response, or
+// the error, of each transaction is returned in the order the transactions were given.
+func (t *Transactor) SendTransactionBatch(txs []*fab.Transaction) ([]*fab.TransactionResponse, []error) {
+	ctx, ok := contextImpl.RequestClientContext(t.reqCtx)
+	if !ok {
+		errs := make([]error, len(txs))
+		return make([]*fab.TransactionResponse, len(txs)), multi.Fill(errs, 0, errors.New("failed get client context from reqContext for SendTransactionBatch"))
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeoutType(core.OrdererResponse), contextImpl.WithParent(t.reqCtx))
+	defer cancel()
+
+	return txn.SendBatch(reqCtx, txs, t.orderers)
+}
+
+// SendSignedEnvelope sends a transaction envelope that was signed outside of the SDK to the chain’s orderer service.
+func (t *Transactor) SendSignedEnvelope(envelope *fab.SignedEnvelope) (*fab.TransactionResponse, error) {
+	ctx, ok := contextImpl.RequestClientContext(t.reqCtx)
+	if !ok {
+		return nil, errors.New("failed get client context from reqContext for SendSignedEnvelope")
+	}
+
+	reqCtx, cancel := contextImpl.NewRequest(ctx, contextImpl.WithTimeoutType(core.OrdererResponse), contextImpl.WithParent(t.reqCtx))
+	defer cancel()
+
+	return txn.SendSignedEnvelope(reqCtx, envelope, t.orderers)
+}
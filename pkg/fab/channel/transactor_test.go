@@ -46,6 +46,27 @@ func TestTransaction(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTransactionBatch(t *testing.T) {
+	transactor := createTransactor(t)
+
+	var txs []*fab.Transaction
+	for i := 0; i < 3; i++ {
+		tp := createTransactionProposal(t, transactor)
+		tpr := createTransactionProposalResponse(t, transactor, tp)
+
+		tx, err := txn.New(fab.TransactionRequest{Proposal: tp, ProposalResponses: tpr})
+		assert.Nil(t, err)
+		txs = append(txs, tx)
+	}
+
+	responses, errs := transactor.SendTransactionBatch(txs)
+	assert.Len(t, responses, len(txs))
+	for i := range txs {
+		assert.Nil(t, errs[i])
+		assert.NotNil(t, responses[i])
+	}
+}
+
 func TestTransactionBadStatus(t *testing.T) {
 	transactor := createTransactor(t)
 	tp := createTransactionProposal(t, transactor)
@@ -0,0 +1,139 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package collconfig builds the private data collection configurations of a chaincode,
+// as provided when the chaincode is instantiated or upgraded.
+package collconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// Collection names are restricted to the characters accepted by the peer
+var collectionNameRegexp = regexp.MustCompile("^[A-Za-z0-9_-]+$")
+
+// Collection is the configuration of a static private data collection
+type Collection struct {
+	// Name of the collection, which must be unique within the chaincode
+	Name string
+	// Policy which defines the member organizations of the collection, e.g. "OR('Org1MSP.member', 'Org2MSP.member')".
+	// MembersPolicy may be used to create the policy from a list of MSP IDs.
+	Policy string
+	// RequiredPeerCount is the minimum number of peers that private data must be disseminated to on endorsement
+	RequiredPeerCount int32
+	// MaxPeerCount is the maximum number of peers that private data is disseminated to on endorsement
+	MaxPeerCount int32
+	// BlockToLive is the number of blocks after which private data is purged (zero to keep it forever)
+	BlockToLive uint64
+}
+
+// Builder builds and validates the collection configurations of a chaincode
+type Builder struct {
+	mspIDs      map[string]bool
+	collections []*common.CollectionConfig
+	names       map[string]bool
+}
+
+// NewBuilder returns a new Builder. The member organizations of the collections are validated against
+// the MSPs of the given channel configuration. If the channel configuration is nil then the member
+// organizations are not validated.
+func NewBuilder(channelCfg fab.ChannelCfg) (*Builder, error) {
+	b := &Builder{names: make(map[string]bool)}
+	if channelCfg == nil {
+		return b, nil
+	}
+
+	b.mspIDs = make(map[string]bool)
+	for _, mspConfig := range channelCfg.MSPs() {
+		fabricConfig := &mb.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			return nil, errors.Wrap(err, "unmarshal FabricMSPConfig from channel config failed")
+		}
+		b.mspIDs[fabricConfig.Name] = true
+	}
+	return b, nil
+}
+
+// Add validates the given collection and adds it to the configurations
+func (b *Builder) Add(c Collection) error {
+	policy, err := b.validate(c)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid collection [%s]", c.Name))
+	}
+
+	b.names[c.Name] = true
+	b.collections = append(b.collections, &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name: c.Name,
+				MemberOrgsPolicy: &common.CollectionPolicyConfig{
+					Payload: &common.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy},
+				},
+				RequiredPeerCount: c.RequiredPeerCount,
+				MaximumPeerCount:  c.MaxPeerCount,
+				BlockToLive:       c.BlockToLive,
+			},
+		},
+	})
+	return nil
+}
+
+// Build returns the collection configurations added so far, as required by
+// the CollConfig field of the instantiate and upgrade requests
+func (b *Builder) Build() []*common.CollectionConfig {
+	return b.collections
+}
+
+func (b *Builder) validate(c Collection) (*common.SignaturePolicyEnvelope, error) {
+	if !collectionNameRegexp.MatchString(c.Name) {
+		return nil, errors.New("collection name must be non-empty and contain only letters, digits, '_' and '-'")
+	}
+	if b.names[c.Name] {
+		return nil, errors.New("duplicate collection name")
+	}
+	if c.RequiredPeerCount < 0 {
+		return nil, errors.New("required peer count must not be negative")
+	}
+	if c.MaxPeerCount < c.RequiredPeerCount {
+		return nil, errors.Errorf("maximum peer count (%d) must not be less than the required peer count (%d)", c.MaxPeerCount, c.RequiredPeerCount)
+	}
+
+	policy, err := cauthdsl.FromString(c.Policy)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid member orgs policy")
+	}
+	for _, principal := range policy.Identities {
+		if principal.PrincipalClassification != mb.MSPPrincipal_ROLE {
+			return nil, errors.Errorf("member orgs policy principals must be roles, got %s", principal.PrincipalClassification)
+		}
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return nil, errors.Wrap(err, "unmarshal MSP role failed")
+		}
+		if b.mspIDs != nil && !b.mspIDs[role.MspIdentifier] {
+			return nil, errors.Errorf("member org [%s] is not a member of the channel", role.MspIdentifier)
+		}
+	}
+	return policy, nil
+}
+
+// MembersPolicy returns a policy which is satisfied by a member of any of the given MSPs
+func MembersPolicy(mspIDs ...string) string {
+	members := make([]string, len(mspIDs))
+	for i, mspID := range mspIDs {
+		members[i] = fmt.Sprintf("'%s.member'", mspID)
+	}
+	return fmt.Sprintf("OR(%s)", strings.Join(members, ", "))
+}
@@ -0,0 +1,86 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package collconfig
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	b, err := NewBuilder(newTestChannelCfg(t, "Org1MSP", "Org2MSP"))
+	require.NoError(t, err)
+
+	require.NoError(t, b.Add(Collection{
+		Name:              "collection1",
+		Policy:            MembersPolicy("Org1MSP", "Org2MSP"),
+		RequiredPeerCount: 1,
+		MaxPeerCount:      3,
+		BlockToLive:       100,
+	}))
+	require.NoError(t, b.Add(Collection{Name: "collection2", Policy: "OR('Org1MSP.member')"}))
+
+	configs := b.Build()
+	require.Len(t, configs, 2)
+
+	config := configs[0].GetStaticCollectionConfig()
+	require.NotNil(t, config)
+	assert.Equal(t, "collection1", config.Name)
+	assert.Equal(t, int32(1), config.RequiredPeerCount)
+	assert.Equal(t, int32(3), config.MaximumPeerCount)
+	assert.Equal(t, uint64(100), config.BlockToLive)
+	expectedPolicy, err := cauthdsl.FromString("OR('Org1MSP.member', 'Org2MSP.member')")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(expectedPolicy, config.MemberOrgsPolicy.GetSignaturePolicy()))
+}
+
+func TestBuilderValidation(t *testing.T) {
+	b, err := NewBuilder(newTestChannelCfg(t, "Org1MSP"))
+	require.NoError(t, err)
+	require.NoError(t, b.Add(Collection{Name: "collection1", Policy: MembersPolicy("Org1MSP")}))
+
+	invalid := []Collection{
+		{Name: "", Policy: MembersPolicy("Org1MSP")},
+		{Name: "invalid name", Policy: MembersPolicy("Org1MSP")},
+		{Name: "collection1", Policy: MembersPolicy("Org1MSP")},
+		{Name: "collection2", Policy: MembersPolicy("Org1MSP"), RequiredPeerCount: -1},
+		{Name: "collection2", Policy: MembersPolicy("Org1MSP"), RequiredPeerCount: 2, MaxPeerCount: 1},
+		{Name: "collection2", Policy: "invalid"},
+		{Name: "collection2", Policy: MembersPolicy("Org2MSP")},
+	}
+	for _, c := range invalid {
+		assert.Error(t, b.Add(c), "expecting error for invalid collection %v", c)
+	}
+	assert.Len(t, b.Build(), 1)
+
+	assert.NoError(t, b.Add(Collection{Name: "private_Collection-2", Policy: MembersPolicy("Org1MSP")}))
+
+	// Member orgs aren't validated without a channel config
+	b, err = NewBuilder(nil)
+	require.NoError(t, err)
+	assert.NoError(t, b.Add(Collection{Name: "collection1", Policy: MembersPolicy("Org2MSP")}))
+}
+
+func TestMembersPolicy(t *testing.T) {
+	assert.Equal(t, "OR('Org1MSP.member', 'Org2MSP.member')", MembersPolicy("Org1MSP", "Org2MSP"))
+}
+
+func newTestChannelCfg(t *testing.T, mspIDs ...string) *mocks.MockChannelCfg {
+	cfg := mocks.NewMockChannelCfg("mychannel")
+	for _, mspID := range mspIDs {
+		config, err := proto.Marshal(&mb.FabricMSPConfig{Name: mspID})
+		require.NoError(t, err)
+		cfg.MockMSPs = append(cfg.MockMSPs, &mb.MSPConfig{Config: config})
+	}
+	return cfg
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtxgen
+
+import (
+	"encoding/hex"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	msgVersion = int32(1)
+	epoch      = 0
+)
+
+// NewChannelCreateTx returns the unsigned channel creation transaction for the given channel, as
+// generated by 'configtxgen -outputCreateChannelTx'. The profile must specify the consortium and
+// the application organizations of the channel. The marshalled envelope may be used as the channel
+// config of resmgmt.SaveChannelRequest.
+func NewChannelCreateTx(channelID string, profile *Profile) (*common.Envelope, error) {
+	configUpdate, err := NewChannelCreateConfigUpdate(channelID, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of config update failed")
+	}
+
+	data, err := proto.Marshal(&common.ConfigUpdateEnvelope{ConfigUpdate: configUpdateBytes})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of config update envelope failed")
+	}
+
+	return newEnvelope(common.HeaderType_CONFIG_UPDATE, channelID, data)
+}
+
+// NewChannelCreateConfigUpdate returns the config update which creates the given channel
+func NewChannelCreateConfigUpdate(channelID string, profile *Profile) (*common.ConfigUpdate, error) {
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+	if profile == nil || profile.Application == nil {
+		return nil, errors.New("profile with application section is required")
+	}
+	if profile.Consortium == "" {
+		return nil, errors.New("profile with consortium is required")
+	}
+
+	applicationGroup, err := newApplicationTemplate(profile.Application)
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating application group failed")
+	}
+
+	readSet := newConfigGroup()
+	readSet.Values[channelConfig.ConsortiumKey] = &common.ConfigValue{}
+	readSet.Groups[applicationGroupKey] = newConfigGroup()
+	for name := range applicationGroup.Groups {
+		readSet.Groups[applicationGroupKey].Groups[name] = newConfigGroup()
+	}
+
+	writeSet := proto.Clone(readSet).(*common.ConfigGroup)
+	applicationGroup.Version = 1
+	writeSet.Groups[applicationGroupKey] = applicationGroup
+	if err := addValue(writeSet, channelConfig.ConsortiumKey, &common.Consortium{Name: profile.Consortium}, ""); err != nil {
+		return nil, err
+	}
+
+	return &common.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet:   readSet,
+		WriteSet:  writeSet,
+	}, nil
+}
+
+// NewGenesisBlock returns the genesis block of the given channel, as generated by
+// 'configtxgen -outputBlock'. The profile must specify the orderer section.
+func NewGenesisBlock(channelID string, profile *Profile) (*common.Block, error) {
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+	if profile == nil || profile.Orderer == nil {
+		return nil, errors.New("profile with orderer section is required")
+	}
+
+	channelGroup, err := NewChannelGroup(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&common.ConfigEnvelope{Config: &common.Config{ChannelGroup: channelGroup}})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of config envelope failed")
+	}
+
+	envelope, err := newEnvelope(common.HeaderType_CONFIG, channelID, data)
+	if err != nil {
+		return nil, err
+	}
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of envelope failed")
+	}
+
+	block := &common.Block{
+		Header: &common.BlockHeader{Number: 0},
+		Data:   &common.BlockData{Data: [][]byte{envelopeBytes}},
+		Metadata: &common.BlockMetadata{
+			Metadata: make([][]byte, len(common.BlockMetadataIndex_name)),
+		},
+	}
+	block.Header.DataHash = util.ComputeSHA256(util.ConcatenateBytes(block.Data.Data...))
+
+	lastConfigIndex, err := proto.Marshal(&common.LastConfig{Index: 0})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of last config failed")
+	}
+	lastConfig, err := proto.Marshal(&common.Metadata{Value: lastConfigIndex})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of last config metadata failed")
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_LAST_CONFIG] = lastConfig
+
+	return block, nil
+}
+
+// newEnvelope returns an unsigned envelope with the given data. Genesis blocks and channel creation
+// transactions are not signed, so the signature header contains a nonce only.
+func newEnvelope(headerType common.HeaderType, channelID string, data []byte) (*common.Envelope, error) {
+	nonce, err := crypto.GetRandomNonce()
+	if err != nil {
+		return nil, errors.WithMessage(err, "nonce creation failed")
+	}
+
+	channelHeader := &common.ChannelHeader{
+		Type:      int32(headerType),
+		Version:   msgVersion,
+		Timestamp: util.CreateUtcTimestamp(),
+		ChannelId: channelID,
+		Epoch:     epoch,
+		TxId:      hex.EncodeToString(util.ComputeSHA256(nonce)),
+	}
+	channelHeaderBytes, err := proto.Marshal(channelHeader)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of channel header failed")
+	}
+	signatureHeaderBytes, err := proto.Marshal(&common.SignatureHeader{Nonce: nonce})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of signature header failed")
+	}
+
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{
+			ChannelHeader:   channelHeaderBytes,
+			SignatureHeader: signatureHeaderBytes,
+		},
+		Data: data,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of payload failed")
+	}
+
+	return &common.Envelope{Payload: payload}, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtxgen
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/test/metadata"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+)
+
+const (
+	channelID       = "mychannel"
+	systemChannelID = "testchainid"
+	consortium      = "SampleConsortium"
+)
+
+var (
+	cryptoConfigPath  = filepath.Join("../../..", metadata.CryptoConfigPath)
+	channelConfigPath = filepath.Join("../../..", metadata.ChannelConfigPath)
+)
+
+func TestNewChannelCreateTx(t *testing.T) {
+	envelope, err := NewChannelCreateTx(channelID, &Profile{
+		Consortium:  consortium,
+		Application: &Application{Organizations: []*Organization{org1(), org2()}},
+	})
+	if err != nil {
+		t.Fatalf("NewChannelCreateTx failed: %s", err)
+	}
+
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("marshal of envelope failed: %s", err)
+	}
+	configUpdate := extractConfigUpdate(t, envelopeBytes)
+
+	expected, err := ioutil.ReadFile(filepath.Join(channelConfigPath, "mychannel.tx"))
+	if err != nil {
+		t.Fatalf("reading channel tx fixture failed: %s", err)
+	}
+	if !proto.Equal(extractConfigUpdate(t, expected), configUpdate) {
+		t.Fatalf("config update doesn't match the configtxgen output: %s", proto.MarshalTextString(configUpdate))
+	}
+
+	if _, err := NewChannelCreateTx(channelID, &Profile{Application: &Application{}}); err == nil {
+		t.Fatal("expected error for profile without consortium")
+	}
+	if _, err := NewChannelCreateTx("", &Profile{Consortium: consortium, Application: &Application{}}); err == nil {
+		t.Fatal("expected error for missing channel ID")
+	}
+}
+
+func TestNewGenesisBlock(t *testing.T) {
+	block, err := NewGenesisBlock(systemChannelID, &Profile{
+		Orderer: &Orderer{
+			OrdererType:  SoloOrdererType,
+			Addresses:    []string{"orderer.example.com:7050"},
+			BatchTimeout: 2 * time.Second,
+			BatchSize: BatchSize{
+				MaxMessageCount:   10,
+				AbsoluteMaxBytes:  98 * 1024 * 1024,
+				PreferredMaxBytes: 512 * 1024,
+			},
+			Organizations: []*Organization{{
+				Name:   "OrdererMSP",
+				ID:     "OrdererMSP",
+				MSPDir: filepath.Join(cryptoConfigPath, "ordererOrganizations/example.com/msp"),
+			}},
+		},
+		Consortiums: map[string]*Consortium{
+			consortium: {Organizations: []*Organization{org1(), org2()}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenesisBlock failed: %s", err)
+	}
+	if block.Header.Number != 0 || len(block.Data.Data) != 1 {
+		t.Fatalf("unexpected genesis block: %v", block.Header)
+	}
+
+	config := extractConfig(t, block)
+
+	expectedBytes, err := ioutil.ReadFile(filepath.Join(channelConfigPath, "twoorgs.genesis.block"))
+	if err != nil {
+		t.Fatalf("reading genesis block fixture failed: %s", err)
+	}
+	expectedBlock := &common.Block{}
+	if err := proto.Unmarshal(expectedBytes, expectedBlock); err != nil {
+		t.Fatalf("unmarshal of genesis block fixture failed: %s", err)
+	}
+	expected := extractConfig(t, expectedBlock)
+
+	if !proto.Equal(expected, config) {
+		t.Fatalf("config doesn't match the configtxgen output: %s", proto.MarshalTextString(config))
+	}
+
+	if _, err := NewGenesisBlock(systemChannelID, &Profile{}); err == nil {
+		t.Fatal("expected error for profile without orderer")
+	}
+}
+
+func TestOrdererDefaults(t *testing.T) {
+	block, err := NewGenesisBlock(systemChannelID, &Profile{
+		Orderer: &Orderer{
+			Addresses: []string{"orderer.example.com:7050"},
+			BatchSize: BatchSize{PreferredMaxBytes: 1024},
+			Organizations: []*Organization{{
+				Name:   "OrdererMSP",
+				ID:     "OrdererMSP",
+				MSPDir: filepath.Join(cryptoConfigPath, "ordererOrganizations/example.com/msp"),
+			}},
+		},
+		Consortiums: map[string]*Consortium{
+			consortium: {Organizations: []*Organization{org1()}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenesisBlock failed: %s", err)
+	}
+
+	values := extractConfig(t, block).ChannelGroup.Groups["Orderer"].Values
+
+	batchSize := &ab.BatchSize{}
+	if err := proto.Unmarshal(values[channelConfig.BatchSizeKey].Value, batchSize); err != nil {
+		t.Fatalf("unmarshal of batch size failed: %s", err)
+	}
+	expected := &ab.BatchSize{MaxMessageCount: 10, AbsoluteMaxBytes: 99 * 1024 * 1024, PreferredMaxBytes: 1024}
+	if !proto.Equal(expected, batchSize) {
+		t.Fatalf("expected batch size %v, got %v", expected, batchSize)
+	}
+
+	batchTimeout := &ab.BatchTimeout{}
+	if err := proto.Unmarshal(values[channelConfig.BatchTimeoutKey].Value, batchTimeout); err != nil {
+		t.Fatalf("unmarshal of batch timeout failed: %s", err)
+	}
+	if batchTimeout.Timeout != "2s" {
+		t.Fatalf("expected default batch timeout, got %s", batchTimeout.Timeout)
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	org := org1()
+	org.Policies = map[string]*Policy{
+		"Admins": {Type: SignaturePolicyType, Rule: "OR('Org1MSP.admin', 'Org2MSP.admin')"},
+	}
+	group, err := newOrgGroup(org, true)
+	if err != nil {
+		t.Fatalf("newOrgGroup failed: %s", err)
+	}
+	if len(group.Policies) != 1 || group.Values["AnchorPeers"] == nil {
+		t.Fatalf("unexpected organization group: %s", proto.MarshalTextString(group))
+	}
+
+	_, err = newPolicy(&Policy{Type: ImplicitMetaPolicyType, Rule: "SOME Admins"}, "Admins")
+	if err == nil {
+		t.Fatal("expected error for invalid implicit meta rule")
+	}
+	_, err = newPolicy(&Policy{Type: "Other", Rule: "ANY Admins"}, "Admins")
+	if err == nil {
+		t.Fatal("expected error for unknown policy type")
+	}
+}
+
+func TestLoadMSP(t *testing.T) {
+	msp, err := LoadMSP(filepath.Join(cryptoConfigPath, "peerOrganizations/org1.example.com/msp"))
+	if err != nil {
+		t.Fatalf("LoadMSP failed: %s", err)
+	}
+	if len(msp.RootCerts) != 1 || len(msp.Admins) != 1 || len(msp.TLSRootCerts) != 1 || len(msp.RevocationList) != 1 {
+		t.Fatalf("unexpected MSP: %v", msp)
+	}
+
+	if _, err := LoadMSP("invalid"); err == nil {
+		t.Fatal("expected error for missing MSP directory")
+	}
+}
+
+func org1() *Organization {
+	return &Organization{
+		Name:        "Org1MSP",
+		ID:          "Org1MSP",
+		MSPDir:      filepath.Join(cryptoConfigPath, "peerOrganizations/org1.example.com/msp"),
+		AnchorPeers: []*AnchorPeer{{Host: "peer0.org1.example.com", Port: 7051}},
+	}
+}
+
+func org2() *Organization {
+	return &Organization{
+		Name:        "Org2MSP",
+		ID:          "Org2MSP",
+		MSPDir:      filepath.Join(cryptoConfigPath, "peerOrganizations/org2.example.com/msp"),
+		AnchorPeers: []*AnchorPeer{{Host: "peer0.org2.example.com", Port: 8051}},
+	}
+}
+
+func extractConfigUpdate(t *testing.T, envelope []byte) *common.ConfigUpdate {
+	configUpdateBytes, err := resource.ExtractChannelConfig(envelope)
+	if err != nil {
+		t.Fatalf("extracting channel config failed: %s", err)
+	}
+	configUpdate := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(configUpdateBytes, configUpdate); err != nil {
+		t.Fatalf("unmarshal of config update failed: %s", err)
+	}
+	return configUpdate
+}
+
+func extractConfig(t *testing.T, block *common.Block) *common.Config {
+	configEnvelope, err := resource.CreateConfigEnvelope(block.Data.Data[0])
+	if err != nil {
+		t.Fatalf("extracting config envelope failed: %s", err)
+	}
+	return configEnvelope.Config
+}
@@ -0,0 +1,385 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtxgen
+
+import (
+	"math"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/sdkpatch/cryptosuitebridge"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	applicationGroupKey        = "Application"
+	consortiumsGroupKey        = "Consortiums"
+	channelCreationPolicyKey   = "ChannelCreationPolicy"
+	blockValidationPolicyKey   = "BlockValidation"
+	ordererAdminsPolicyName    = "/Channel/Orderer/Admins"
+	blockDataHashingStructure  = math.MaxUint32
+	defaultOrdererBatchTimeout = "2s"
+
+	defaultMaxMessageCount   = 10
+	defaultAbsoluteMaxBytes  = 99 * 1024 * 1024
+	defaultPreferredMaxBytes = 512 * 1024
+)
+
+// NewChannelGroup returns the channel config group defined by the profile, i.e. the
+// configuration of a channel as found in its genesis block.
+func NewChannelGroup(profile *Profile) (*common.ConfigGroup, error) {
+	if profile == nil {
+		return nil, errors.New("profile is required")
+	}
+
+	group := newConfigGroup()
+	if err := addPolicies(group, profile.Policies, defaultGroupPolicies()); err != nil {
+		return nil, errors.WithMessage(err, "adding channel policies failed")
+	}
+
+	if err := addValue(group, channelConfig.HashingAlgorithmKey, &common.HashingAlgorithm{Name: cryptosuitebridge.SHA256}, channelConfig.AdminsPolicyKey); err != nil {
+		return nil, err
+	}
+	if err := addValue(group, channelConfig.BlockDataHashingStructureKey, &common.BlockDataHashingStructure{Width: blockDataHashingStructure}, channelConfig.AdminsPolicyKey); err != nil {
+		return nil, err
+	}
+	if profile.Orderer != nil {
+		if err := addValue(group, channelConfig.OrdererAddressesKey, &common.OrdererAddresses{Addresses: profile.Orderer.Addresses}, ordererAdminsPolicyName); err != nil {
+			return nil, err
+		}
+	}
+	if profile.Consortium != "" {
+		if err := addValue(group, channelConfig.ConsortiumKey, &common.Consortium{Name: profile.Consortium}, channelConfig.AdminsPolicyKey); err != nil {
+			return nil, err
+		}
+	}
+	if err := addCapabilities(group, profile.Capabilities); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if profile.Orderer != nil {
+		if group.Groups[channelConfig.OrdererGroupKey], err = newOrdererGroup(profile.Orderer); err != nil {
+			return nil, errors.WithMessage(err, "creating orderer group failed")
+		}
+	}
+	if profile.Application != nil {
+		if group.Groups[applicationGroupKey], err = newApplicationGroup(profile.Application); err != nil {
+			return nil, errors.WithMessage(err, "creating application group failed")
+		}
+	}
+	if profile.Consortiums != nil {
+		if group.Groups[consortiumsGroupKey], err = newConsortiumsGroup(profile.Consortiums); err != nil {
+			return nil, errors.WithMessage(err, "creating consortiums group failed")
+		}
+	}
+
+	group.ModPolicy = channelConfig.AdminsPolicyKey
+	return group, nil
+}
+
+type configValue struct {
+	key string
+	msg proto.Message
+}
+
+func newOrdererGroup(conf *Orderer) (*common.ConfigGroup, error) {
+	group := newConfigGroup()
+	if err := addPolicies(group, conf.Policies, defaultGroupPolicies()); err != nil {
+		return nil, err
+	}
+	if _, ok := group.Policies[blockValidationPolicyKey]; !ok {
+		if err := addPolicy(group, blockValidationPolicyKey, &Policy{Type: ImplicitMetaPolicyType, Rule: "ANY " + channelConfig.WritersPolicyKey}); err != nil {
+			return nil, err
+		}
+	}
+
+	ordererType := conf.OrdererType
+	if ordererType == "" {
+		ordererType = SoloOrdererType
+	}
+	batchTimeout := defaultOrdererBatchTimeout
+	if conf.BatchTimeout > 0 {
+		batchTimeout = conf.BatchTimeout.String()
+	}
+
+	values := []configValue{
+		{channelConfig.ConsensusTypeKey, &ab.ConsensusType{Type: ordererType}},
+		{channelConfig.BatchSizeKey, newBatchSize(conf.BatchSize)},
+		{channelConfig.BatchTimeoutKey, &ab.BatchTimeout{Timeout: batchTimeout}},
+		{channelConfig.ChannelRestrictionsKey, &ab.ChannelRestrictions{MaxCount: conf.MaxChannels}},
+	}
+	if ordererType == KafkaOrdererType {
+		values = append(values, configValue{channelConfig.KafkaBrokersKey, &ab.KafkaBrokers{Brokers: conf.KafkaBrokers}})
+	}
+	for _, v := range values {
+		if err := addValue(group, v.key, v.msg, channelConfig.AdminsPolicyKey); err != nil {
+			return nil, err
+		}
+	}
+	if err := addCapabilities(group, conf.Capabilities); err != nil {
+		return nil, err
+	}
+
+	for _, org := range conf.Organizations {
+		orgGroup, err := newOrgGroup(org, false)
+		if err != nil {
+			return nil, err
+		}
+		group.Groups[org.Name] = orgGroup
+	}
+
+	group.ModPolicy = channelConfig.AdminsPolicyKey
+	return group, nil
+}
+
+// newBatchSize returns the batch size of the orderer, with the defaults of configtxgen for the limits that are not set
+func newBatchSize(conf BatchSize) *ab.BatchSize {
+	batchSize := &ab.BatchSize{
+		MaxMessageCount:   conf.MaxMessageCount,
+		AbsoluteMaxBytes:  conf.AbsoluteMaxBytes,
+		PreferredMaxBytes: conf.PreferredMaxBytes,
+	}
+	if batchSize.MaxMessageCount == 0 {
+		batchSize.MaxMessageCount = defaultMaxMessageCount
+	}
+	if batchSize.AbsoluteMaxBytes == 0 {
+		batchSize.AbsoluteMaxBytes = defaultAbsoluteMaxBytes
+	}
+	if batchSize.PreferredMaxBytes == 0 {
+		batchSize.PreferredMaxBytes = defaultPreferredMaxBytes
+	}
+	return batchSize
+}
+
+func newApplicationGroup(conf *Application) (*common.ConfigGroup, error) {
+	group, err := newApplicationTemplate(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, org := range conf.Organizations {
+		if group.Groups[org.Name], err = newOrgGroup(org, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return group, nil
+}
+
+// newApplicationTemplate returns the application group with its policies and values but
+// with empty organization groups, as used in channel creation transactions
+func newApplicationTemplate(conf *Application) (*common.ConfigGroup, error) {
+	group := newConfigGroup()
+	if err := addPolicies(group, conf.Policies, defaultGroupPolicies()); err != nil {
+		return nil, err
+	}
+	if err := addCapabilities(group, conf.Capabilities); err != nil {
+		return nil, err
+	}
+	for _, org := range conf.Organizations {
+		if org.Name == "" {
+			return nil, errors.New("organization name is required")
+		}
+		group.Groups[org.Name] = newConfigGroup()
+	}
+
+	group.ModPolicy = channelConfig.AdminsPolicyKey
+	return group, nil
+}
+
+func newConsortiumsGroup(conf map[string]*Consortium) (*common.ConfigGroup, error) {
+	group := newConfigGroup()
+
+	// The orderer admins are responsible for the consortiums
+	acceptAll, err := proto.Marshal(cauthdsl.Envelope(cauthdsl.NOutOf(0, []*common.SignaturePolicy{}), [][]byte{}))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of policy failed")
+	}
+	group.Policies[channelConfig.AdminsPolicyKey] = &common.ConfigPolicy{
+		Policy:    &common.Policy{Type: int32(common.Policy_SIGNATURE), Value: acceptAll},
+		ModPolicy: ordererAdminsPolicyName,
+	}
+
+	for name, consortium := range conf {
+		consortiumGroup := newConfigGroup()
+		for _, org := range consortium.Organizations {
+			orgGroup, err := newOrgGroup(org, false)
+			if err != nil {
+				return nil, err
+			}
+			consortiumGroup.Groups[org.Name] = orgGroup
+		}
+
+		creationPolicy, err := newPolicy(&Policy{Type: ImplicitMetaPolicyType, Rule: "ANY " + channelConfig.AdminsPolicyKey}, "")
+		if err != nil {
+			return nil, err
+		}
+		if err := addValue(consortiumGroup, channelCreationPolicyKey, creationPolicy, ordererAdminsPolicyName); err != nil {
+			return nil, err
+		}
+
+		consortiumGroup.ModPolicy = ordererAdminsPolicyName
+		group.Groups[name] = consortiumGroup
+	}
+
+	group.ModPolicy = ordererAdminsPolicyName
+	return group, nil
+}
+
+func newOrgGroup(org *Organization, withAnchorPeers bool) (*common.ConfigGroup, error) {
+	if org == nil || org.Name == "" || org.ID == "" {
+		return nil, errors.New("organization name and ID are required")
+	}
+
+	group := newConfigGroup()
+	if err := addPolicies(group, org.Policies, defaultOrgPolicies(org.ID)); err != nil {
+		return nil, errors.WithMessage(err, "adding policies failed for organization "+org.Name)
+	}
+
+	msp, err := mspConfig(org)
+	if err != nil {
+		return nil, err
+	}
+	if err := addValue(group, channelConfig.MSPKey, msp, channelConfig.AdminsPolicyKey); err != nil {
+		return nil, err
+	}
+
+	if withAnchorPeers && len(org.AnchorPeers) > 0 {
+		anchorPeers := &pb.AnchorPeers{}
+		for _, ap := range org.AnchorPeers {
+			anchorPeers.AnchorPeers = append(anchorPeers.AnchorPeers, &pb.AnchorPeer{Host: ap.Host, Port: int32(ap.Port)})
+		}
+		if err := addValue(group, channelConfig.AnchorPeersKey, anchorPeers, channelConfig.AdminsPolicyKey); err != nil {
+			return nil, err
+		}
+	}
+
+	group.ModPolicy = channelConfig.AdminsPolicyKey
+	return group, nil
+}
+
+// defaultGroupPolicies returns the policies of a group which aggregate the policies of its sub-groups
+func defaultGroupPolicies() map[string]*Policy {
+	return map[string]*Policy{
+		channelConfig.ReadersPolicyKey: {Type: ImplicitMetaPolicyType, Rule: "ANY " + channelConfig.ReadersPolicyKey},
+		channelConfig.WritersPolicyKey: {Type: ImplicitMetaPolicyType, Rule: "ANY " + channelConfig.WritersPolicyKey},
+		channelConfig.AdminsPolicyKey:  {Type: ImplicitMetaPolicyType, Rule: "MAJORITY " + channelConfig.AdminsPolicyKey},
+	}
+}
+
+// defaultOrgPolicies returns the policies of an organization which are satisfied by its members (or admins)
+func defaultOrgPolicies(mspID string) map[string]*Policy {
+	return map[string]*Policy{
+		channelConfig.ReadersPolicyKey: {Type: SignaturePolicyType, Rule: "OR('" + mspID + ".member')"},
+		channelConfig.WritersPolicyKey: {Type: SignaturePolicyType, Rule: "OR('" + mspID + ".member')"},
+		channelConfig.AdminsPolicyKey:  {Type: SignaturePolicyType, Rule: "OR('" + mspID + ".admin')"},
+	}
+}
+
+func addPolicies(group *common.ConfigGroup, policies, defaults map[string]*Policy) error {
+	if policies == nil {
+		policies = defaults
+	}
+	for name, policy := range policies {
+		if err := addPolicy(group, name, policy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addPolicy(group *common.ConfigGroup, name string, policy *Policy) error {
+	p, err := newPolicy(policy, name)
+	if err != nil {
+		return err
+	}
+	group.Policies[name] = &common.ConfigPolicy{Policy: p, ModPolicy: channelConfig.AdminsPolicyKey}
+	return nil
+}
+
+func newPolicy(policy *Policy, name string) (*common.Policy, error) {
+	if policy == nil {
+		return nil, errors.Errorf("policy %s is nil", name)
+	}
+
+	var policyType common.Policy_PolicyType
+	var msg proto.Message
+	switch policy.Type {
+	case ImplicitMetaPolicyType:
+		policyType = common.Policy_IMPLICIT_META
+		implicitMeta, err := parseImplicitMetaPolicy(policy.Rule)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid policy "+name)
+		}
+		msg = implicitMeta
+	case SignaturePolicyType:
+		policyType = common.Policy_SIGNATURE
+		envelope, err := cauthdsl.FromString(policy.Rule)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid policy "+name)
+		}
+		msg = envelope
+	default:
+		return nil, errors.Errorf("unknown type [%s] of policy %s", policy.Type, name)
+	}
+
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of policy failed")
+	}
+	return &common.Policy{Type: int32(policyType), Value: value}, nil
+}
+
+// parseImplicitMetaPolicy parses rules such as "ANY Readers" or "MAJORITY Admins"
+func parseImplicitMetaPolicy(rule string) (*common.ImplicitMetaPolicy, error) {
+	args := strings.Fields(rule)
+	if len(args) != 2 {
+		return nil, errors.Errorf("implicit meta policy rule [%s] must have the form '<ANY|ALL|MAJORITY> <SubPolicy>'", rule)
+	}
+
+	r, ok := common.ImplicitMetaPolicy_Rule_value[args[0]]
+	if !ok {
+		return nil, errors.Errorf("unknown implicit meta policy rule [%s]", args[0])
+	}
+
+	return &common.ImplicitMetaPolicy{SubPolicy: args[1], Rule: common.ImplicitMetaPolicy_Rule(r)}, nil
+}
+
+func addCapabilities(group *common.ConfigGroup, capabilities []string) error {
+	if len(capabilities) == 0 {
+		return nil
+	}
+
+	value := &common.Capabilities{Capabilities: make(map[string]*common.Capability)}
+	for _, c := range capabilities {
+		value.Capabilities[c] = &common.Capability{}
+	}
+	return addValue(group, channelConfig.CapabilitiesKey, value, channelConfig.AdminsPolicyKey)
+}
+
+func addValue(group *common.ConfigGroup, key string, msg proto.Message, modPolicy string) error {
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrapf(err, "marshal of %s value failed", key)
+	}
+	group.Values[key] = &common.ConfigValue{Value: value, ModPolicy: modPolicy}
+	return nil
+}
+
+func newConfigGroup() *common.ConfigGroup {
+	return &common.ConfigGroup{
+		Groups:   make(map[string]*common.ConfigGroup),
+		Values:   make(map[string]*common.ConfigValue),
+		Policies: make(map[string]*common.ConfigPolicy),
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtxgen
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	imsp "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/sdkpatch/cryptosuitebridge"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+const (
+	cacertsDir              = "cacerts"
+	intermediatecertsDir    = "intermediatecerts"
+	admincertsDir           = "admincerts"
+	crlsDir                 = "crls"
+	tlscacertsDir           = "tlscacerts"
+	tlsintermediatecertsDir = "tlsintermediatecerts"
+)
+
+// LoadMSP reads the certificates and CRLs of an MSP from the given directory, which has the
+// layout generated by cryptogen or the fabric-ca client (cacerts, admincerts, tlscacerts, etc.).
+// Only cacerts is required. OU configuration (config.yaml) is not read.
+func LoadMSP(dir string) (*MSP, error) {
+	rootCerts, err := readPemDir(filepath.Join(dir, cacertsDir))
+	if err != nil {
+		return nil, err
+	}
+	if len(rootCerts) == 0 {
+		return nil, errors.Errorf("no CA certificates found in MSP directory %s", dir)
+	}
+
+	msp := &MSP{RootCerts: rootCerts}
+
+	dirs := []struct {
+		name  string
+		certs *[][]byte
+	}{
+		{intermediatecertsDir, &msp.IntermediateCerts},
+		{admincertsDir, &msp.Admins},
+		{crlsDir, &msp.RevocationList},
+		{tlscacertsDir, &msp.TLSRootCerts},
+		{tlsintermediatecertsDir, &msp.TLSIntermediateCerts},
+	}
+	for _, d := range dirs {
+		if *d.certs, err = readPemDir(filepath.Join(dir, d.name)); err != nil {
+			return nil, err
+		}
+	}
+
+	return msp, nil
+}
+
+// readPemDir returns the contents of the PEM files in the given directory, sorted by file name.
+// A missing directory is treated as empty.
+func readPemDir(dir string) ([][]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "reading directory %s failed", dir)
+	}
+
+	var contents [][]byte
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, f.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading file %s failed", path)
+		}
+		if block, _ := pem.Decode(b); block == nil {
+			return nil, errors.Errorf("no PEM content in file %s", path)
+		}
+		contents = append(contents, b)
+	}
+
+	return contents, nil
+}
+
+// mspConfig returns the MSP configuration of the organization
+func mspConfig(org *Organization) (*mb.MSPConfig, error) {
+	msp := org.MSP
+	if msp == nil {
+		if org.MSPDir == "" {
+			return nil, errors.Errorf("MSP or MSP directory is required for organization %s", org.Name)
+		}
+		var err error
+		msp, err = LoadMSP(org.MSPDir)
+		if err != nil {
+			return nil, errors.WithMessage(err, "loading MSP failed for organization "+org.Name)
+		}
+	}
+
+	fabricConfig := &mb.FabricMSPConfig{
+		Name:              org.ID,
+		RootCerts:         msp.RootCerts,
+		IntermediateCerts: msp.IntermediateCerts,
+		Admins:            msp.Admins,
+		RevocationList:    msp.RevocationList,
+		CryptoConfig: &mb.FabricCryptoConfig{
+			SignatureHashFamily:            cryptosuitebridge.SHA2,
+			IdentityIdentifierHashFunction: cryptosuitebridge.SHA256,
+		},
+		TlsRootCerts:         msp.TLSRootCerts,
+		TlsIntermediateCerts: msp.TLSIntermediateCerts,
+	}
+	fabricConfigBytes, err := proto.Marshal(fabricConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of MSP config failed")
+	}
+
+	return &mb.MSPConfig{Type: int32(imsp.FABRIC), Config: fabricConfigBytes}, nil
+}
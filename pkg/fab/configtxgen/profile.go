@@ -0,0 +1,106 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package configtxgen generates channel creation transactions and orderer genesis blocks from a
+// channel profile, in the same way as the configtxgen tool but without requiring external binaries.
+package configtxgen
+
+import (
+	"time"
+)
+
+const (
+	// SoloOrdererType is the orderer type of a single node orderer
+	SoloOrdererType = "solo"
+	// KafkaOrdererType is the orderer type of a Kafka based orderer
+	KafkaOrdererType = "kafka"
+
+	// ImplicitMetaPolicyType is the type of policies which aggregate the policies of sub-groups,
+	// e.g. "ANY Readers" or "MAJORITY Admins"
+	ImplicitMetaPolicyType = "ImplicitMeta"
+	// SignaturePolicyType is the type of policies which require signatures from principals,
+	// e.g. "OR('Org1MSP.member', 'Org2MSP.member')"
+	SignaturePolicyType = "Signature"
+)
+
+// Profile describes a channel in the same way as a configtxgen profile. A profile used for
+// a channel creation transaction requires Consortium and Application while a profile used
+// for an orderer genesis block requires Orderer and, for the orderer system channel,
+// Consortiums.
+type Profile struct {
+	Consortium   string
+	Application  *Application
+	Orderer      *Orderer
+	Consortiums  map[string]*Consortium
+	Capabilities []string           // Channel capabilities, e.g. "V1_1"
+	Policies     map[string]*Policy // Channel policies; defaults are used if not provided
+}
+
+// Application contains the application configuration of a channel
+type Application struct {
+	Organizations []*Organization
+	Capabilities  []string
+	Policies      map[string]*Policy
+}
+
+// Orderer contains the orderer configuration of a channel. BatchTimeout defaults to 2 seconds.
+type Orderer struct {
+	OrdererType   string
+	Addresses     []string
+	BatchTimeout  time.Duration
+	BatchSize     BatchSize
+	KafkaBrokers  []string
+	MaxChannels   uint64
+	Organizations []*Organization
+	Capabilities  []string
+	Policies      map[string]*Policy
+}
+
+// BatchSize contains the limits on the number of messages and bytes in a block.
+// Limits that are not set default to 10 messages, 99 MB and 512 KB respectively.
+type BatchSize struct {
+	MaxMessageCount   uint32
+	AbsoluteMaxBytes  uint32
+	PreferredMaxBytes uint32
+}
+
+// Consortium contains the organizations which may create application channels
+type Consortium struct {
+	Organizations []*Organization
+}
+
+// Organization describes a member organization of a channel. The MSP of the organization
+// is read from MSPDir unless MSP is provided.
+type Organization struct {
+	Name        string // Name of the organization's config group
+	ID          string // MSP ID
+	MSPDir      string
+	MSP         *MSP
+	AnchorPeers []*AnchorPeer
+	Policies    map[string]*Policy
+}
+
+// MSP contains the PEM encoded certificates and CRLs of an organization's MSP
+type MSP struct {
+	RootCerts            [][]byte
+	IntermediateCerts    [][]byte
+	Admins               [][]byte
+	RevocationList       [][]byte
+	TLSRootCerts         [][]byte
+	TLSIntermediateCerts [][]byte
+}
+
+// AnchorPeer is a peer used for cross-organization gossip communication
+type AnchorPeer struct {
+	Host string
+	Port int
+}
+
+// Policy is a policy definition of the given type (ImplicitMetaPolicyType or SignaturePolicyType)
+type Policy struct {
+	Type string
+	Rule string
+}
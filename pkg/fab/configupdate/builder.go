@@ -0,0 +1,320 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package configupdate builds channel configuration updates. The current channel configuration is
+// modified using a Builder and the resulting ConfigUpdate, containing the read and write sets which
+// transform the current configuration into the modified configuration, is computed automatically.
+package configupdate
+
+import (
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+const (
+	// ApplicationGroupKey is the name of the application group
+	ApplicationGroupKey = "Application"
+)
+
+// Builder modifies a copy of a channel configuration and computes the
+// ConfigUpdate required to apply the modifications
+type Builder struct {
+	channelID string
+	original  *common.Config
+	updated   *common.Config
+}
+
+// New returns a new Builder for the given channel configuration. The given
+// configuration is not modified.
+func New(channelID string, config *common.Config) (*Builder, error) {
+	if channelID == "" {
+		return nil, errors.New("channel ID is required")
+	}
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("config with a channel group is required")
+	}
+
+	return &Builder{
+		channelID: channelID,
+		original:  config,
+		updated:   proto.Clone(config).(*common.Config),
+	}, nil
+}
+
+// NewFromBlock returns a new Builder for the configuration contained in the given config block,
+// such as the block returned by resource.LastConfigFromOrderer.
+func NewFromBlock(block *common.Block) (*Builder, error) {
+	if block == nil || block.Data == nil || len(block.Data.Data) != 1 {
+		return nil, errors.New("config block must contain one transaction")
+	}
+
+	configEnvelope, err := resource.CreateConfigEnvelope(block.Data.Data[0])
+	if err != nil {
+		return nil, errors.WithMessage(err, "extracting config envelope from block failed")
+	}
+
+	channelID, err := channelIDFromEnvelope(block.Data.Data[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return New(channelID, configEnvelope.Config)
+}
+
+// ChannelID returns the ID of the channel
+func (b *Builder) ChannelID() string {
+	return b.channelID
+}
+
+// Config returns the modified configuration. The returned configuration may be modified directly
+// in order to make changes that are not supported by the other functions of the Builder.
+func (b *Builder) Config() *common.Config {
+	return b.updated
+}
+
+// Group returns the group at the given path, starting from the channel group. For
+// example, Group("Application", "Org1MSP") returns the group of application org Org1MSP.
+func (b *Builder) Group(path ...string) (*common.ConfigGroup, error) {
+	group := b.updated.ChannelGroup
+	for i, name := range path {
+		child, ok := group.Groups[name]
+		if !ok {
+			return nil, errors.Errorf("group not found: %v", path[:i+1])
+		}
+		group = child
+	}
+	return group, nil
+}
+
+// Value unmarshals the config value with the given key, in the group at the given path, into value
+func (b *Builder) Value(path []string, key string, value proto.Message) error {
+	group, err := b.Group(path...)
+	if err != nil {
+		return err
+	}
+
+	configValue, ok := group.Values[key]
+	if !ok {
+		return errors.Errorf("value [%s] not found in group %v", key, path)
+	}
+
+	if err := proto.Unmarshal(configValue.Value, value); err != nil {
+		return errors.Wrapf(err, "unmarshal of value [%s] failed", key)
+	}
+	return nil
+}
+
+// SetValue sets the config value with the given key in the group at the given path. The mod policy of an
+// existing value is retained. A new value is given the Admins mod policy.
+func (b *Builder) SetValue(path []string, key string, value proto.Message) error {
+	group, err := b.Group(path...)
+	if err != nil {
+		return err
+	}
+
+	valueBytes, err := proto.Marshal(value)
+	if err != nil {
+		return errors.Wrapf(err, "marshal of value [%s] failed", key)
+	}
+
+	if group.Values == nil {
+		group.Values = make(map[string]*common.ConfigValue)
+	}
+
+	configValue, ok := group.Values[key]
+	if !ok {
+		group.Values[key] = &common.ConfigValue{
+			ModPolicy: channelConfig.AdminsPolicyKey,
+			Value:     valueBytes,
+		}
+		return nil
+	}
+
+	configValue.Value = valueBytes
+	return nil
+}
+
+// SetPolicy sets the policy with the given name in the group at the given path. The mod policy of an
+// existing policy is retained. A new policy is given the Admins mod policy.
+func (b *Builder) SetPolicy(path []string, name string, policy *common.Policy) error {
+	group, err := b.Group(path...)
+	if err != nil {
+		return err
+	}
+
+	if group.Policies == nil {
+		group.Policies = make(map[string]*common.ConfigPolicy)
+	}
+
+	configPolicy, ok := group.Policies[name]
+	if !ok {
+		group.Policies[name] = &common.ConfigPolicy{
+			ModPolicy: channelConfig.AdminsPolicyKey,
+			Policy:    policy,
+		}
+		return nil
+	}
+
+	configPolicy.Policy = policy
+	return nil
+}
+
+// SetGroup adds (or replaces) the child group with the given name in the group at the given path
+func (b *Builder) SetGroup(path []string, name string, child *common.ConfigGroup) error {
+	group, err := b.Group(path...)
+	if err != nil {
+		return err
+	}
+
+	if group.Groups == nil {
+		group.Groups = make(map[string]*common.ConfigGroup)
+	}
+	group.Groups[name] = child
+	return nil
+}
+
+// RemoveGroup removes the child group with the given name from the group at the given path
+func (b *Builder) RemoveGroup(path []string, name string) error {
+	group, err := b.Group(path...)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := group.Groups[name]; !ok {
+		return errors.Errorf("group [%s] not found in group %v", name, path)
+	}
+	delete(group.Groups, name)
+	return nil
+}
+
+// AddApplicationOrg adds the given organization group to the application group. The organization group
+// contains the MSP config value and the policies of the organization.
+func (b *Builder) AddApplicationOrg(mspID string, org *common.ConfigGroup) error {
+	if _, err := b.Group(ApplicationGroupKey, mspID); err == nil {
+		return errors.Errorf("application org [%s] already exists", mspID)
+	}
+	return b.SetGroup([]string{ApplicationGroupKey}, mspID, org)
+}
+
+// RemoveApplicationOrg removes the given organization from the application group
+func (b *Builder) RemoveApplicationOrg(mspID string) error {
+	return b.RemoveGroup([]string{ApplicationGroupKey}, mspID)
+}
+
+// SetAnchorPeers sets the anchor peers of the given application organization
+func (b *Builder) SetAnchorPeers(mspID string, anchorPeers ...*pb.AnchorPeer) error {
+	return b.SetValue([]string{ApplicationGroupKey, mspID}, channelConfig.AnchorPeersKey, &pb.AnchorPeers{AnchorPeers: anchorPeers})
+}
+
+// SetBatchSize sets the batch size of the orderer
+func (b *Builder) SetBatchSize(batchSize *ab.BatchSize) error {
+	return b.SetValue([]string{channelConfig.OrdererGroupKey}, channelConfig.BatchSizeKey, batchSize)
+}
+
+// ConfigUpdate computes the ConfigUpdate which applies the modifications made to the configuration
+func (b *Builder) ConfigUpdate() (*common.ConfigUpdate, error) {
+	configUpdate, err := update.Compute(b.original, b.updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "computing config update failed")
+	}
+	configUpdate.ChannelId = b.channelID
+	return configUpdate, nil
+}
+
+// ConfigUpdateBytes returns the marshalled ConfigUpdate. These are the bytes which are signed
+// by the organizations that need to approve the update (see resource.CreateConfigSignature).
+func (b *Builder) ConfigUpdateBytes() ([]byte, error) {
+	configUpdate, err := b.ConfigUpdate()
+	if err != nil {
+		return nil, err
+	}
+
+	configUpdateBytes, err := proto.Marshal(configUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of config update failed")
+	}
+	return configUpdateBytes, nil
+}
+
+// Envelope returns an unsigned envelope containing the ConfigUpdate. The envelope has the same
+// format as the channel transaction files generated by configtxgen and may therefore be passed as
+// the channel config of a resmgmt.SaveChannelRequest.
+func (b *Builder) Envelope() ([]byte, error) {
+	configUpdateBytes, err := b.ConfigUpdateBytes()
+	if err != nil {
+		return nil, err
+	}
+	return newEnvelope(b.channelID, configUpdateBytes, nil)
+}
+
+// Pending returns a pending config update, to be signed by the organizations that need to approve the update
+func (b *Builder) Pending() (*Pending, error) {
+	configUpdateBytes, err := b.ConfigUpdateBytes()
+	if err != nil {
+		return nil, err
+	}
+	return NewPending(b.channelID, configUpdateBytes), nil
+}
+
+func newEnvelope(channelID string, configUpdate []byte, signatures []*common.ConfigSignature) ([]byte, error) {
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_CONFIG_UPDATE),
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of channel header failed")
+	}
+
+	data, err := proto.Marshal(&common.ConfigUpdateEnvelope{
+		ConfigUpdate: configUpdate,
+		Signatures:   signatures,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of config update envelope failed")
+	}
+
+	payload, err := proto.Marshal(&common.Payload{
+		Header: &common.Header{ChannelHeader: channelHeader},
+		Data:   data,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of payload failed")
+	}
+
+	envelope, err := proto.Marshal(&common.Envelope{Payload: payload})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of envelope failed")
+	}
+	return envelope, nil
+}
+
+func channelIDFromEnvelope(envelopeBytes []byte) (string, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return "", errors.Wrap(err, "unmarshal envelope failed")
+	}
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return "", errors.Wrap(err, "unmarshal payload failed")
+	}
+	if payload.Header == nil {
+		return "", errors.New("payload header is missing")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return "", errors.Wrap(err, "unmarshal channel header failed")
+	}
+	return channelHeader.ChannelId, nil
+}
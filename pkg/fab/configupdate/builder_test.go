@@ -0,0 +1,162 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configupdate
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	channelID = "mychannel"
+	version   = uint64(3)
+)
+
+func TestNoChanges(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	_, err := builder.ConfigUpdate()
+	assert.Error(t, err, "expecting error since nothing was modified")
+
+	_, err = New("", builder.Config())
+	assert.Error(t, err, "expecting error for missing channel ID")
+	_, err = New(channelID, &common.Config{})
+	assert.Error(t, err, "expecting error for missing channel group")
+}
+
+func TestSetBatchSize(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	batchSize := &ab.BatchSize{}
+	require.NoError(t, builder.Value([]string{channelConfig.OrdererGroupKey}, channelConfig.BatchSizeKey, batchSize))
+	batchSize.MaxMessageCount = batchSize.MaxMessageCount + 10
+	require.NoError(t, builder.SetBatchSize(batchSize))
+
+	configUpdate, err := builder.ConfigUpdate()
+	require.NoError(t, err)
+	assert.Equal(t, channelID, configUpdate.ChannelId)
+
+	// Only the batch size is modified so group versions remain the same
+	assert.Equal(t, version, configUpdate.ReadSet.Version)
+	assert.Equal(t, version, configUpdate.WriteSet.Version)
+	assert.Empty(t, configUpdate.ReadSet.Groups[channelConfig.OrdererGroupKey].Values)
+
+	writeOrderer := configUpdate.WriteSet.Groups[channelConfig.OrdererGroupKey]
+	require.NotNil(t, writeOrderer)
+	assert.Equal(t, version, writeOrderer.Version)
+	require.Len(t, writeOrderer.Values, 1)
+	value := writeOrderer.Values[channelConfig.BatchSizeKey]
+	require.NotNil(t, value)
+	assert.Equal(t, version+1, value.Version)
+
+	updatedBatchSize := &ab.BatchSize{}
+	require.NoError(t, proto.Unmarshal(value.Value, updatedBatchSize))
+	assert.Equal(t, batchSize.MaxMessageCount, updatedBatchSize.MaxMessageCount)
+
+	// The original config must not be modified
+	original := &ab.BatchSize{}
+	require.NoError(t, proto.Unmarshal(builder.original.ChannelGroup.Groups[channelConfig.OrdererGroupKey].Values[channelConfig.BatchSizeKey].Value, original))
+	assert.Equal(t, batchSize.MaxMessageCount-10, original.MaxMessageCount)
+}
+
+func TestSetAnchorPeers(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	require.NoError(t, builder.SetAnchorPeers("Org1MSP", &pb.AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}))
+	assert.Error(t, builder.SetAnchorPeers("Org3MSP"), "expecting error for unknown org")
+
+	configUpdate, err := builder.ConfigUpdate()
+	require.NoError(t, err)
+
+	// AnchorPeers is a new value so the membership of the org group changes and its version is incremented
+	readOrg := configUpdate.ReadSet.Groups[ApplicationGroupKey].Groups["Org1MSP"]
+	writeOrg := configUpdate.WriteSet.Groups[ApplicationGroupKey].Groups["Org1MSP"]
+	require.NotNil(t, readOrg)
+	require.NotNil(t, writeOrg)
+	assert.Equal(t, version, readOrg.Version)
+	assert.Equal(t, version+1, writeOrg.Version)
+	assert.Equal(t, uint64(0), writeOrg.Values[channelConfig.AnchorPeersKey].Version)
+	assert.Equal(t, channelConfig.AdminsPolicyKey, writeOrg.Values[channelConfig.AnchorPeersKey].ModPolicy)
+	assert.Equal(t, version, writeOrg.Values[channelConfig.MSPKey].Version, "expecting existing values at their current version")
+
+	_, ok := configUpdate.WriteSet.Groups[ApplicationGroupKey].Groups["Org2MSP"]
+	assert.False(t, ok, "expecting unmodified org to be excluded from the write set")
+}
+
+func TestAddRemoveApplicationOrg(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	org3, err := builder.Group(ApplicationGroupKey, "Org2MSP")
+	require.NoError(t, err)
+
+	assert.Error(t, builder.AddApplicationOrg("Org1MSP", org3), "expecting error for existing org")
+	require.NoError(t, builder.AddApplicationOrg("Org3MSP", proto.Clone(org3).(*common.ConfigGroup)))
+	require.NoError(t, builder.RemoveApplicationOrg("Org2MSP"))
+	assert.Error(t, builder.RemoveApplicationOrg("Org4MSP"), "expecting error for unknown org")
+
+	configUpdate, err := builder.ConfigUpdate()
+	require.NoError(t, err)
+
+	writeApp := configUpdate.WriteSet.Groups[ApplicationGroupKey]
+	require.NotNil(t, writeApp)
+	assert.Equal(t, version+1, writeApp.Version)
+	assert.Len(t, writeApp.Groups, 2)
+	assert.Equal(t, version, writeApp.Groups["Org1MSP"].Version)
+	assert.Equal(t, uint64(0), writeApp.Groups["Org3MSP"].Version)
+	assert.NotEmpty(t, writeApp.Groups["Org3MSP"].Values[channelConfig.MSPKey].Value)
+	assert.Equal(t, uint64(0), writeApp.Groups["Org3MSP"].Values[channelConfig.MSPKey].Version)
+}
+
+func TestEnvelope(t *testing.T) {
+	builder := newTestBuilder(t)
+	require.NoError(t, builder.SetPolicy([]string{ApplicationGroupKey}, "Endorsement", &common.Policy{Type: int32(common.Policy_SIGNATURE)}))
+
+	envelope, err := builder.Envelope()
+	require.NoError(t, err)
+
+	configUpdateBytes, err := resource.ExtractChannelConfig(envelope)
+	require.NoError(t, err)
+
+	expected, err := builder.ConfigUpdateBytes()
+	require.NoError(t, err)
+
+	configUpdate := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateBytes, configUpdate))
+	expectedConfigUpdate := &common.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(expected, expectedConfigUpdate))
+	assert.True(t, proto.Equal(expectedConfigUpdate, configUpdate))
+
+	id, err := channelIDFromEnvelope(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, id)
+}
+
+func newTestBuilder(t *testing.T) *Builder {
+	builder := &mocks.MockConfigBlockBuilder{
+		MockConfigGroupBuilder: mocks.MockConfigGroupBuilder{
+			Version:   version,
+			ModPolicy: channelConfig.AdminsPolicyKey,
+			MSPNames:  []string{"Org1MSP", "Org2MSP"},
+		},
+	}
+
+	configEnvelope, err := resource.CreateConfigEnvelope(builder.Build().Data.Data[0])
+	require.NoError(t, err)
+
+	b, err := New(channelID, configEnvelope.Config)
+	require.NoError(t, err)
+	return b
+}
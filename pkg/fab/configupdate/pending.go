@@ -0,0 +1,149 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configupdate
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/resource"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// Pending is a channel configuration update which is awaiting the signatures required by the modification
+// policies of the channel. It is serialized as a config update envelope (the format produced by configtxgen
+// and 'peer channel signconfigtx') so that it may be passed to the administrators of each organization, signed
+// independently and then merged before being submitted.
+type Pending struct {
+	channelID    string
+	configUpdate []byte
+	signatures   []*common.ConfigSignature
+}
+
+// NewPending returns a pending config update for the given marshalled ConfigUpdate
+func NewPending(channelID string, configUpdate []byte) *Pending {
+	return &Pending{
+		channelID:    channelID,
+		configUpdate: configUpdate,
+	}
+}
+
+// ParsePending parses a pending config update which was serialized using Bytes. A channel
+// transaction file generated by configtxgen may also be parsed.
+func ParsePending(envelopeBytes []byte) (*Pending, error) {
+	envelope := &common.Envelope{}
+	if err := proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return nil, errors.Wrap(err, "unmarshal envelope failed")
+	}
+	payload := &common.Payload{}
+	if err := proto.Unmarshal(envelope.Payload, payload); err != nil {
+		return nil, errors.Wrap(err, "unmarshal payload failed")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("payload header is missing")
+	}
+	channelHeader := &common.ChannelHeader{}
+	if err := proto.Unmarshal(payload.Header.ChannelHeader, channelHeader); err != nil {
+		return nil, errors.Wrap(err, "unmarshal channel header failed")
+	}
+	if common.HeaderType(channelHeader.Type) != common.HeaderType_CONFIG_UPDATE {
+		return nil, errors.Errorf("expecting envelope of type CONFIG_UPDATE but got %s", common.HeaderType(channelHeader.Type))
+	}
+	configUpdateEnvelope := &common.ConfigUpdateEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configUpdateEnvelope); err != nil {
+		return nil, errors.Wrap(err, "unmarshal config update envelope failed")
+	}
+
+	p := NewPending(channelHeader.ChannelId, configUpdateEnvelope.ConfigUpdate)
+	if err := p.AddSignatures(configUpdateEnvelope.Signatures...); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ChannelID returns the ID of the channel
+func (p *Pending) ChannelID() string {
+	return p.channelID
+}
+
+// ConfigUpdate returns the marshalled ConfigUpdate
+func (p *Pending) ConfigUpdate() []byte {
+	return p.configUpdate
+}
+
+// Signatures returns the signatures collected so far
+func (p *Pending) Signatures() []*common.ConfigSignature {
+	return p.signatures
+}
+
+// Sign signs the config update with the given signing identity, or with the identity
+// of the given context if signer is nil, and adds the signature.
+func (p *Pending) Sign(ctx context.Client, signer msp.SigningIdentity) error {
+	signature, err := resource.SignChannelConfig(ctx, p.configUpdate, signer)
+	if err != nil {
+		return errors.WithMessage(err, "signing of config update failed")
+	}
+	return p.AddSignatures(signature)
+}
+
+// AddSignatures adds the given signatures. A signature from an identity which has already signed is ignored.
+func (p *Pending) AddSignatures(signatures ...*common.ConfigSignature) error {
+	for _, signature := range signatures {
+		creator, err := signatureCreator(signature)
+		if err != nil {
+			return err
+		}
+		if p.signedBy(creator) {
+			logger.Debugf("Ignoring duplicate signature")
+			continue
+		}
+		p.signatures = append(p.signatures, signature)
+	}
+	return nil
+}
+
+// Merge adds the signatures of the given pending config updates, which must be for the same config update
+func (p *Pending) Merge(others ...*Pending) error {
+	for _, other := range others {
+		if other.channelID != p.channelID {
+			return errors.Errorf("cannot merge config update for channel [%s] into config update for channel [%s]", other.channelID, p.channelID)
+		}
+		if !bytes.Equal(other.configUpdate, p.configUpdate) {
+			return errors.New("cannot merge signatures of a different config update")
+		}
+		if err := p.AddSignatures(other.signatures...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Bytes serializes the pending config update, including its signatures
+func (p *Pending) Bytes() ([]byte, error) {
+	return newEnvelope(p.channelID, p.configUpdate, p.signatures)
+}
+
+func (p *Pending) signedBy(creator []byte) bool {
+	for _, signature := range p.signatures {
+		c, err := signatureCreator(signature)
+		if err == nil && bytes.Equal(c, creator) {
+			return true
+		}
+	}
+	return false
+}
+
+func signatureCreator(signature *common.ConfigSignature) ([]byte, error) {
+	header := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signature.SignatureHeader, header); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of signature header failed")
+	}
+	return header.Creator, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configupdate
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	"github.com/hyperledger/fabric-sdk-go/pkg/util/testutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingSignAndMerge(t *testing.T) {
+	pending := NewPending(channelID, []byte("config update"))
+
+	ctx := mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP"))
+
+	require.NoError(t, pending.Sign(ctx, nil))
+	require.NoError(t, pending.Sign(ctx, nil))
+	assert.Len(t, pending.Signatures(), 1, "expecting duplicate signature to be ignored")
+
+	// Serialize and sign elsewhere
+	pendingBytes, err := pending.Bytes()
+	require.NoError(t, err)
+	other, err := ParsePending(pendingBytes)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, other.ChannelID())
+	assert.Equal(t, []byte("config update"), other.ConfigUpdate())
+	assert.Len(t, other.Signatures(), 1)
+	require.NoError(t, other.AddSignatures(testSignature(t, "Org2MSP", []byte("org2 cert"))))
+	assert.Len(t, other.Signatures(), 2)
+
+	require.NoError(t, pending.Merge(other))
+	assert.Len(t, pending.Signatures(), 2)
+
+	assert.Error(t, pending.Merge(NewPending(channelID, []byte("other update"))), "expecting error merging a different update")
+	assert.Error(t, pending.Merge(NewPending("otherchannel", []byte("config update"))), "expecting error merging a different channel")
+
+	_, err = ParsePending([]byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestPendingValidate(t *testing.T) {
+	cs := mocks.NewMockProviderContext().CryptoSuite()
+
+	orgs := []string{"Org1MSP", "Org2MSP", "Org3MSP"}
+	admins := make(map[string]*common.ConfigSignature)
+	members := make(map[string]*common.ConfigSignature)
+	config := &common.Config{ChannelGroup: newTestChannelGroup()}
+	app := config.ChannelGroup.Groups[ApplicationGroupKey]
+	for _, org := range orgs {
+		caCert, caKey := testutil.GenerateCA(t, org)
+		adminCert := testutil.GenerateCert(t, "admin."+org, caCert, caKey)
+		app.Groups[org] = newTestOrgGroup(org, caCert, adminCert)
+		admins[org] = testSignature(t, org, adminCert)
+		members[org] = testSignature(t, org, testutil.GenerateCert(t, "user."+org, caCert, caKey))
+	}
+
+	// Org1 anchor peers may be set by Org1 admins
+	builder, err := New(channelID, config)
+	require.NoError(t, err)
+	require.NoError(t, builder.SetAnchorPeers("Org1MSP", &pb.AnchorPeer{Host: "peer0.org1.example.com", Port: 7051}))
+	pending, err := builder.Pending()
+	require.NoError(t, err)
+
+	assert.Error(t, pending.Validate(cs, config), "expecting error with no signatures")
+	require.NoError(t, pending.AddSignatures(admins["Org2MSP"], members["Org1MSP"]))
+	assert.Error(t, pending.Validate(cs, config), "expecting error without Org1 admin signature")
+	require.NoError(t, pending.AddSignatures(admins["Org1MSP"]))
+	assert.NoError(t, pending.Validate(cs, config))
+
+	// Removing an org requires a majority of the application org admins
+	builder, err = New(channelID, config)
+	require.NoError(t, err)
+	require.NoError(t, builder.RemoveApplicationOrg("Org3MSP"))
+	pending, err = builder.Pending()
+	require.NoError(t, err)
+
+	require.NoError(t, pending.AddSignatures(admins["Org1MSP"], members["Org2MSP"]))
+	assert.Error(t, pending.Validate(cs, config), "expecting error with only one admin signature")
+	require.NoError(t, pending.AddSignatures(admins["Org3MSP"]))
+	assert.NoError(t, pending.Validate(cs, config))
+
+	// The update must be based on the current config
+	stale := proto.Clone(config).(*common.Config)
+	stale.ChannelGroup.Groups[ApplicationGroupKey].Version++
+	assert.Error(t, pending.Validate(cs, stale), "expecting error for stale read set")
+
+	// The update must be for the same channel
+	other := NewPending("otherchannel", pending.ConfigUpdate())
+	assert.Error(t, other.Validate(cs, config), "expecting error for channel mismatch")
+}
+
+func newTestChannelGroup() *common.ConfigGroup {
+	return &common.ConfigGroup{
+		ModPolicy: channelConfig.AdminsPolicyKey,
+		Groups: map[string]*common.ConfigGroup{
+			ApplicationGroupKey: {
+				ModPolicy: channelConfig.AdminsPolicyKey,
+				Groups:    make(map[string]*common.ConfigGroup),
+				Policies: map[string]*common.ConfigPolicy{
+					channelConfig.AdminsPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, channelConfig.AdminsPolicyKey),
+				},
+			},
+		},
+		Policies: map[string]*common.ConfigPolicy{
+			channelConfig.AdminsPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, channelConfig.AdminsPolicyKey),
+		},
+	}
+}
+
+func newTestOrgGroup(mspID string, caCert, adminCert []byte) *common.ConfigGroup {
+	mspConfig := &mb.MSPConfig{
+		Config: marshalOrPanic(&mb.FabricMSPConfig{
+			Name:      mspID,
+			RootCerts: [][]byte{caCert},
+			Admins:    [][]byte{adminCert},
+		}),
+	}
+
+	return &common.ConfigGroup{
+		ModPolicy: channelConfig.AdminsPolicyKey,
+		Values: map[string]*common.ConfigValue{
+			channelConfig.MSPKey: {
+				ModPolicy: channelConfig.AdminsPolicyKey,
+				Value:     marshalOrPanic(mspConfig),
+			},
+		},
+		Policies: map[string]*common.ConfigPolicy{
+			channelConfig.AdminsPolicyKey: {
+				ModPolicy: channelConfig.AdminsPolicyKey,
+				Policy: &common.Policy{
+					Type:  int32(common.Policy_SIGNATURE),
+					Value: marshalOrPanic(cauthdsl.SignedByMspAdmin(mspID)),
+				},
+			},
+		},
+	}
+}
+
+func implicitMetaPolicy(rule common.ImplicitMetaPolicy_Rule, subPolicy string) *common.ConfigPolicy {
+	return &common.ConfigPolicy{
+		ModPolicy: channelConfig.AdminsPolicyKey,
+		Policy: &common.Policy{
+			Type:  int32(common.Policy_IMPLICIT_META),
+			Value: marshalOrPanic(&common.ImplicitMetaPolicy{Rule: rule, SubPolicy: subPolicy}),
+		},
+	}
+}
+
+func testSignature(t *testing.T, mspID string, cert []byte) *common.ConfigSignature {
+	creator, err := proto.Marshal(&mb.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	require.NoError(t, err)
+	header, err := proto.Marshal(&common.SignatureHeader{Creator: creator, Nonce: []byte("nonce")})
+	require.NoError(t, err)
+	return &common.ConfigSignature{SignatureHeader: header, Signature: []byte("signature")}
+}
+
+func marshalOrPanic(msg proto.Message) []byte {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,344 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configupdate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	channelConfig "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/channel/membership"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+const (
+	groupType  = "Group"
+	valueType  = "Value"
+	policyType = "Policy"
+)
+
+// Validate checks that the config update may be applied to the given (current) channel configuration and that
+// the collected signatures satisfy the modification policy of every element that the update modifies. The
+// checks are the same as those performed by the orderer, so an update which passes validation is not rejected
+// for lack of signatures.
+func (p *Pending) Validate(cs core.CryptoSuite, config *common.Config) error {
+	if config == nil || config.ChannelGroup == nil {
+		return errors.New("config with a channel group is required")
+	}
+
+	configUpdate := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(p.configUpdate, configUpdate); err != nil {
+		return errors.Wrap(err, "unmarshal of config update failed")
+	}
+	if configUpdate.ChannelId != p.channelID {
+		return errors.Errorf("config update is for channel [%s] but expecting channel [%s]", configUpdate.ChannelId, p.channelID)
+	}
+	if configUpdate.WriteSet == nil {
+		return errors.New("config update has no write set")
+	}
+
+	current := flatten(config.ChannelGroup)
+
+	var readSet map[string]*configItem
+	if configUpdate.ReadSet != nil {
+		readSet = flatten(configUpdate.ReadSet)
+	}
+	if err := verifyReadSet(readSet, current); err != nil {
+		return err
+	}
+
+	evaluator, err := newPolicyEvaluator(cs, config.ChannelGroup, p.signedData())
+	if err != nil {
+		return err
+	}
+
+	deltaSet := computeDeltaSet(readSet, flatten(configUpdate.WriteSet))
+	if len(deltaSet) == 0 {
+		return errors.New("config update contains no modifications")
+	}
+
+	for _, key := range sortedKeys(deltaSet) {
+		item := deltaSet[key]
+		existing, ok := current[key]
+		if !ok {
+			// New elements are authorized by the modification policy of the parent group, whose membership changes
+			if item.version != 0 {
+				return errors.Errorf("attempted to set key %s to version %d, but key does not exist", key, item.version)
+			}
+			continue
+		}
+		if item.version != existing.version+1 {
+			return errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, item.version, existing.version)
+		}
+
+		if err := evaluator.evaluate(existing.modPolicyPath(), existing.modPolicy); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("modification of %s is not authorized by mod policy [%s]", key, existing.modPolicy))
+		}
+	}
+
+	return nil
+}
+
+// signedData returns the data that was signed by each of the signatures
+func (p *Pending) signedData() []*membership.SignedData {
+	var signedData []*membership.SignedData
+	for _, signature := range p.signatures {
+		creator, err := signatureCreator(signature)
+		if err != nil {
+			logger.Warnf("Ignoring signature: %s", err)
+			continue
+		}
+		signedData = append(signedData, &membership.SignedData{
+			Data:      util.ConcatenateBytes(signature.SignatureHeader, p.configUpdate),
+			Identity:  creator,
+			Signature: signature.Signature,
+		})
+	}
+	return signedData
+}
+
+// configItem is an element (group, value or policy) of the configuration tree
+type configItem struct {
+	itemType  string
+	path      []string // the path of the group containing the element
+	key       string
+	version   uint64
+	modPolicy string
+}
+
+// modPolicyPath returns the path of the group relative to which the mod policy is resolved. The mod policy of
+// a group is relative to the group itself whereas the mod policy of a value or policy is relative to the group
+// which contains it.
+func (i *configItem) modPolicyPath() []string {
+	if i.itemType == groupType {
+		return append(append([]string{}, i.path...), i.key)
+	}
+	return i.path
+}
+
+func itemKey(itemType string, path []string, key string) string {
+	return fmt.Sprintf("[%s] /%s", itemType, strings.Join(append(append([]string{}, path...), key), "/"))
+}
+
+// flatten returns all of the elements of the configuration tree rooted at the given channel group, keyed by path
+func flatten(channelGroup *common.ConfigGroup) map[string]*configItem {
+	items := make(map[string]*configItem)
+	flattenGroup(channelGroup, nil, channelConfig.ChannelGroupKey, items)
+	return items
+}
+
+func flattenGroup(group *common.ConfigGroup, path []string, key string, items map[string]*configItem) {
+	items[itemKey(groupType, path, key)] = &configItem{itemType: groupType, path: path, key: key, version: group.Version, modPolicy: group.ModPolicy}
+
+	groupPath := append(append([]string{}, path...), key)
+	for name, value := range group.Values {
+		items[itemKey(valueType, groupPath, name)] = &configItem{itemType: valueType, path: groupPath, key: name, version: value.Version, modPolicy: value.ModPolicy}
+	}
+	for name, policy := range group.Policies {
+		items[itemKey(policyType, groupPath, name)] = &configItem{itemType: policyType, path: groupPath, key: name, version: policy.Version, modPolicy: policy.ModPolicy}
+	}
+	for name, child := range group.Groups {
+		flattenGroup(child, groupPath, name, items)
+	}
+}
+
+// verifyReadSet checks that every element in the read set is at its current version
+func verifyReadSet(readSet, current map[string]*configItem) error {
+	for key, item := range readSet {
+		existing, ok := current[key]
+		if !ok {
+			return errors.Errorf("existing config does not contain element for %s but was in the read set", key)
+		}
+		if existing.version != item.version {
+			return errors.Errorf("proposed update requires that key %s be at version %d, but it is currently at version %d", key, item.version, existing.version)
+		}
+	}
+	return nil
+}
+
+// computeDeltaSet returns the elements of the write set which are not in the read set at the same version
+func computeDeltaSet(readSet, writeSet map[string]*configItem) map[string]*configItem {
+	deltaSet := make(map[string]*configItem)
+	for key, item := range writeSet {
+		if readItem, ok := readSet[key]; ok && readItem.version == item.version {
+			continue
+		}
+		deltaSet[key] = item
+	}
+	return deltaSet
+}
+
+// policyEvaluator evaluates the policies of the current configuration against a set of signatures
+type policyEvaluator struct {
+	channelGroup *common.ConfigGroup
+	evaluator    *membership.PolicyEvaluator
+	signedData   []*membership.SignedData
+}
+
+func newPolicyEvaluator(cs core.CryptoSuite, channelGroup *common.ConfigGroup, signedData []*membership.SignedData) (*policyEvaluator, error) {
+	mspConfigs, err := collectMSPs(channelGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator, err := membership.NewPolicyEvaluatorForMSPs(cs, mspConfigs)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to create policy evaluator for the MSPs of the channel")
+	}
+
+	return &policyEvaluator{
+		channelGroup: channelGroup,
+		evaluator:    evaluator,
+		signedData:   signedData,
+	}, nil
+}
+
+// evaluate evaluates the named policy. The name is either absolute (e.g. /Channel/Application/Admins)
+// or relative to the group at the given path.
+func (e *policyEvaluator) evaluate(path []string, name string) error {
+	if name == "" {
+		return errors.New("mod policy is empty")
+	}
+
+	if strings.HasPrefix(name, "/") {
+		path = nil
+		name = strings.TrimPrefix(name, "/")
+	}
+	elements := strings.Split(name, "/")
+	path = append(append([]string{}, path...), elements[:len(elements)-1]...)
+
+	group, err := e.group(path)
+	if err != nil {
+		return err
+	}
+	return e.evaluatePolicy(group, path, elements[len(elements)-1])
+}
+
+func (e *policyEvaluator) evaluatePolicy(group *common.ConfigGroup, path []string, name string) error {
+	configPolicy, ok := group.Policies[name]
+	if !ok || configPolicy.Policy == nil {
+		return errors.Errorf("policy /%s/%s not found", strings.Join(path, "/"), name)
+	}
+
+	switch common.Policy_PolicyType(configPolicy.Policy.Type) {
+	case common.Policy_SIGNATURE:
+		policy := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(configPolicy.Policy.Value, policy); err != nil {
+			return errors.Wrap(err, "unmarshal of signature policy failed")
+		}
+		return e.evaluator.EvaluateSignedData(policy, e.signedData)
+
+	case common.Policy_IMPLICIT_META:
+		policy := &common.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(configPolicy.Policy.Value, policy); err != nil {
+			return errors.Wrap(err, "unmarshal of implicit meta policy failed")
+		}
+		return e.evaluateImplicitMeta(group, path, policy)
+
+	default:
+		return errors.Errorf("unsupported policy type: %d", configPolicy.Policy.Type)
+	}
+}
+
+// evaluateImplicitMeta evaluates the sub-policy in each of the child groups and checks that the required
+// number (any, all or a majority) of the sub-policies are satisfied
+func (e *policyEvaluator) evaluateImplicitMeta(group *common.ConfigGroup, path []string, policy *common.ImplicitMetaPolicy) error {
+	var threshold int
+	switch policy.Rule {
+	case common.ImplicitMetaPolicy_ANY:
+		threshold = 1
+	case common.ImplicitMetaPolicy_ALL:
+		threshold = len(group.Groups)
+	case common.ImplicitMetaPolicy_MAJORITY:
+		threshold = len(group.Groups)/2 + 1
+	default:
+		return errors.Errorf("unsupported implicit meta policy rule: %s", policy.Rule)
+	}
+	// In the special case that there are no sub-policies, consider 0 to be a majority or any
+	if len(group.Groups) == 0 {
+		threshold = 0
+	}
+
+	satisfied := 0
+	var unsatisfied []string
+	for _, name := range sortedGroupNames(group.Groups) {
+		childPath := append(append([]string{}, path...), name)
+		if err := e.evaluatePolicy(group.Groups[name], childPath, policy.SubPolicy); err != nil {
+			logger.Debugf("Sub-policy %s of /%s not satisfied: %s", policy.SubPolicy, strings.Join(childPath, "/"), err)
+			unsatisfied = append(unsatisfied, name)
+			continue
+		}
+		satisfied++
+	}
+
+	if satisfied < threshold {
+		return errors.Errorf("implicit policy %s %s of /%s not satisfied: %d of %d sub-policies satisfied, unsatisfied groups [%s]",
+			policy.Rule, policy.SubPolicy, strings.Join(path, "/"), satisfied, threshold, strings.Join(unsatisfied, ", "))
+	}
+	return nil
+}
+
+func (e *policyEvaluator) group(path []string) (*common.ConfigGroup, error) {
+	if len(path) == 0 || path[0] != channelConfig.ChannelGroupKey {
+		return nil, errors.Errorf("invalid policy path: /%s", strings.Join(path, "/"))
+	}
+
+	group := e.channelGroup
+	for i, name := range path[1:] {
+		child, ok := group.Groups[name]
+		if !ok {
+			return nil, errors.Errorf("group /%s not found", strings.Join(path[:i+2], "/"))
+		}
+		group = child
+	}
+	return group, nil
+}
+
+// collectMSPs returns the MSP configs of all of the organizations in the configuration
+func collectMSPs(group *common.ConfigGroup) ([]*mb.MSPConfig, error) {
+	var mspConfigs []*mb.MSPConfig
+	if value, ok := group.Values[channelConfig.MSPKey]; ok {
+		mspConfig := &mb.MSPConfig{}
+		if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+			return nil, errors.Wrap(err, "unmarshal of MSP config failed")
+		}
+		mspConfigs = append(mspConfigs, mspConfig)
+	}
+
+	for _, name := range sortedGroupNames(group.Groups) {
+		childConfigs, err := collectMSPs(group.Groups[name])
+		if err != nil {
+			return nil, err
+		}
+		mspConfigs = append(mspConfigs, childConfigs...)
+	}
+	return mspConfigs, nil
+}
+
+func sortedGroupNames(groups map[string]*common.ConfigGroup) []string {
+	var names []string
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(items map[string]*configItem) []string {
+	var keys []string
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,173 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package discovery is a client of the peer discovery service, which returns the
+// membership, configuration and endorsement layouts of a channel.
+package discovery
+
+import (
+	reqContext "context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/comm"
+	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+)
+
+var logger = logging.NewLogger("fabsdk/fab")
+
+// Client sends signed requests to the discovery service of a peer
+type Client struct {
+	ctx context.Client
+}
+
+// New returns a discovery client which signs requests with the identity of the given context
+func New(ctx context.Client) (*Client, error) {
+	if ctx == nil {
+		return nil, errors.New("client context is required")
+	}
+	return &Client{ctx: ctx}, nil
+}
+
+// Send signs the given request and sends it to each of the targets in turn until one of them
+// responds. An error is returned if none of the targets can be reached or if the response doesn't
+// contain a result for each query.
+func (c *Client) Send(reqCtx reqContext.Context, req *Request, targets ...core.NetworkPeer) (*Response, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("at least one target is required")
+	}
+
+	signedRequest, err := c.sign(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range targets {
+		var response *Response
+		response, err = c.send(reqCtx, signedRequest, len(req.queries), &target)
+		if err == nil {
+			return response, nil
+		}
+		logger.Debugf("Discovery request to [%s] failed: %s", target.URL, err)
+	}
+	return nil, err
+}
+
+// Targets returns the peers configured for the given channel, which are the targets of discovery
+// requests for the channel. If channelID is empty then all of the peers in the network
+// configuration are returned.
+func Targets(config core.Config, channelID string) ([]core.NetworkPeer, error) {
+	if channelID == "" {
+		return config.NetworkPeers()
+	}
+
+	channelPeers, err := config.ChannelPeers(channelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to read configuration for channel peers")
+	}
+	targets := make([]core.NetworkPeer, len(channelPeers))
+	for i, channelPeer := range channelPeers {
+		targets[i] = channelPeer.NetworkPeer
+	}
+	return targets, nil
+}
+
+func (c *Client) send(reqCtx reqContext.Context, signedRequest *discpb.SignedRequest, numQueries int, target *core.NetworkPeer) (*Response, error) {
+	conn, err := c.dial(reqCtx, target)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("connection to discovery service at [%s] failed", target.URL))
+	}
+	defer c.release(conn)
+
+	response, err := discpb.NewDiscoveryClient(conn).Discover(reqCtx, signedRequest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "discovery request to [%s] failed", target.URL)
+	}
+	if len(response.Results) != numQueries {
+		return nil, errors.Errorf("expecting %d results from discovery service at [%s] but got %d", numQueries, target.URL, len(response.Results))
+	}
+
+	logger.Debugf("Received %d discovery results from [%s]", len(response.Results), target.URL)
+	return &Response{results: response.Results}, nil
+}
+
+func (c *Client) sign(req *Request) (*discpb.SignedRequest, error) {
+	identity, err := c.ctx.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	payload, err := proto.Marshal(&discpb.Request{
+		Authentication: &discpb.AuthInfo{
+			ClientIdentity:    identity,
+			ClientTlsCertHash: comm.TLSCertHash(c.ctx.Config()),
+		},
+		Queries: req.queries,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal of discovery request failed")
+	}
+
+	signature, err := c.ctx.SigningManager().Sign(payload, c.ctx.PrivateKey())
+	if err != nil {
+		return nil, errors.WithMessage(err, "signing of discovery request failed")
+	}
+
+	return &discpb.SignedRequest{Payload: payload, Signature: signature}, nil
+}
+
+func (c *Client) dial(reqCtx reqContext.Context, target *core.NetworkPeer) (*grpc.ClientConn, error) {
+	opts, err := c.dialOptions(target)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := reqContext.WithTimeout(reqCtx, c.ctx.Config().TimeoutOrDefault(core.EndorserConnection))
+	defer cancel()
+
+	address := endpoint.ToAddress(target.URL)
+	if commManager := c.ctx.InfraProvider().CommManager(); commManager != nil {
+		return commManager.DialContext(ctx, address, opts...)
+	}
+	return grpc.DialContext(ctx, address, opts...)
+}
+
+func (c *Client) release(conn *grpc.ClientConn) {
+	if commManager := c.ctx.InfraProvider().CommManager(); commManager != nil {
+		commManager.ReleaseConn(conn)
+		return
+	}
+	if err := conn.Close(); err != nil {
+		logger.Debugf("Error closing connection: %s", err)
+	}
+}
+
+func (c *Client) dialOptions(target *core.NetworkPeer) ([]grpc.DialOption, error) {
+	allowInsecure, _ := target.GRPCOptions["allow-insecure"].(bool)
+	if !endpoint.AttemptSecured(target.URL, allowInsecure) {
+		return []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}, nil
+	}
+
+	certificate, err := target.TLSCACerts.TLSCert()
+	if err != nil {
+		logger.Debugf("No TLS certificate configured for [%s]: %s", target.URL, err)
+	}
+	serverName, _ := target.GRPCOptions["ssl-target-name-override"].(string)
+
+	tlsConfig, err := comm.TLSConfig(certificate, serverName, c.ctx.Config())
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), grpc.WithBlock()}, nil
+}
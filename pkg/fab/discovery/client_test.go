@@ -0,0 +1,112 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	reqContext "context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+)
+
+func TestSend(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+
+	server.SetPeers(
+		mocks.NewDiscoveryPeer("Org1MSP", "peer0.org1.example.com:7051", 10, "cc1", "cc2"),
+		mocks.NewDiscoveryPeer("Org2MSP", "peer0.org2.example.com:7051", 11),
+	)
+	server.SetConfig(&discpb.ConfigResult{Orderers: map[string]*discpb.Endpoints{
+		"OrdererMSP": {Endpoint: []*discpb.Endpoint{{Host: "orderer.example.com", Port: 7050}}},
+	}})
+	server.SetEndorsers("cc1", &discpb.EndorsementDescriptor{Chaincode: "cc1"})
+
+	client, err := New(mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP")))
+	require.NoError(t, err)
+
+	req := NewRequest().
+		AddConfigQuery("mychannel").
+		AddPeersQuery("mychannel").
+		AddLocalPeersQuery().
+		AddEndorsersQuery("mychannel", ChaincodeCall{ID: "cc1", Collections: []string{"coll1"}}, ChaincodeCall{ID: "cc2"}).
+		AddEndorsersQuery("mychannel", ChaincodeCall{ID: "unknown"})
+	response, err := client.Send(reqContext.Background(), req, target(address))
+	require.NoError(t, err)
+
+	config, err := response.ConfigAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, "orderer.example.com", config.Orderers["OrdererMSP"].Endpoint[0].Host)
+
+	peers, err := response.PeersAt(1)
+	require.NoError(t, err)
+	require.Len(t, peers, 2)
+	for _, p := range peers {
+		info, err := ParsePeer(p)
+		require.NoError(t, err)
+		if info.MSPID == "Org1MSP" {
+			assert.Equal(t, "peer0.org1.example.com:7051", info.Endpoint)
+			assert.Equal(t, uint64(10), info.LedgerHeight)
+			assert.Equal(t, []string{"cc1", "cc2"}, info.Chaincodes)
+		}
+	}
+
+	localPeers, err := response.PeersAt(2)
+	require.NoError(t, err)
+	require.Len(t, localPeers, 2)
+	info, err := ParsePeer(localPeers[0])
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), info.LedgerHeight, "expecting no state info for local peers")
+
+	descriptor, err := response.EndorsersAt(3)
+	require.NoError(t, err)
+	assert.Equal(t, "cc1", descriptor.Chaincode)
+
+	_, err = response.EndorsersAt(4)
+	assert.Error(t, err, "expecting error result for unknown chaincode")
+	_, err = response.PeersAt(0)
+	assert.Error(t, err, "expecting error for result of wrong type")
+	_, err = response.ConfigAt(5)
+	assert.Error(t, err, "expecting error for invalid index")
+}
+
+func TestSendError(t *testing.T) {
+	server, address := mocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetError(errors.New("access denied"))
+
+	client, err := New(mocks.NewMockContext(mspmocks.NewMockSigningIdentity("user1", "Org1MSP")))
+	require.NoError(t, err)
+
+	_, err = client.Send(reqContext.Background(), NewRequest().AddPeersQuery("mychannel"), target(address))
+	assert.Error(t, err)
+	assert.Equal(t, 1, server.RequestCount())
+
+	_, err = client.Send(reqContext.Background(), NewRequest().AddPeersQuery("mychannel"))
+	assert.Error(t, err, "expecting error for missing targets")
+}
+
+func TestParsePeerInvalid(t *testing.T) {
+	_, err := ParsePeer(&discpb.Peer{Identity: []byte("invalid")})
+	assert.Error(t, err)
+
+	peer := mocks.NewDiscoveryPeer("Org1MSP", "peer0.org1.example.com:7051", 10)
+	peer.MembershipInfo = peer.StateInfo
+	_, err = ParsePeer(peer)
+	assert.Error(t, err, "expecting error for membership info which isn't an alive message")
+}
+
+func target(address string) core.NetworkPeer {
+	return core.NetworkPeer{PeerConfig: core.PeerConfig{URL: "grpc://" + address}}
+}
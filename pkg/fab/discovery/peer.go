@@ -0,0 +1,123 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/gossip"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+)
+
+// PeerInfo holds the membership and state of a peer, as returned by the discovery service
+type PeerInfo struct {
+	MSPID    string
+	Endpoint string
+	// LedgerHeight is the height of the peer's ledger on the channel (zero for a local peers query)
+	LedgerHeight uint64
+	// Chaincodes are the names of the chaincodes installed on the peer and instantiated on the channel
+	Chaincodes []string
+}
+
+// ParsePeer extracts the MSP ID, endpoint, ledger height and chaincodes of the given peer
+func ParsePeer(peer *discpb.Peer) (*PeerInfo, error) {
+	identity := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(peer.Identity, identity); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of peer identity failed")
+	}
+
+	if peer.MembershipInfo == nil {
+		return nil, errors.Errorf("membership info of peer from [%s] is missing", identity.Mspid)
+	}
+	aliveMsg, err := gossipMessage(peer.MembershipInfo)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid membership info")
+	}
+	alive := aliveMsg.GetAliveMsg()
+	if alive == nil || alive.Membership == nil {
+		return nil, errors.Errorf("membership info of peer from [%s] is not an alive message", identity.Mspid)
+	}
+
+	info := &PeerInfo{MSPID: identity.Mspid, Endpoint: alive.Membership.Endpoint}
+	if peer.StateInfo == nil {
+		return info, nil
+	}
+
+	stateMsg, err := gossipMessage(peer.StateInfo)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid state info")
+	}
+	if properties := stateMsg.GetStateInfo().GetProperties(); properties != nil {
+		info.LedgerHeight = properties.LedgerHeight
+		for _, cc := range properties.Chaincodes {
+			info.Chaincodes = append(info.Chaincodes, cc.Name)
+		}
+	}
+	return info, nil
+}
+
+func gossipMessage(envelope *gossip.Envelope) (*gossip.GossipMessage, error) {
+	msg := &gossip.GossipMessage{}
+	if err := proto.Unmarshal(envelope.Payload, msg); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of gossip message failed")
+	}
+	return msg, nil
+}
+
+// Peer is a peer found by the discovery service. In addition to the fab.Peer
+// functions, it provides the state of the peer at the time it was discovered.
+type Peer struct {
+	fab.Peer
+	info *PeerInfo
+}
+
+// NewPeer creates a peer from the given discovered peer info. The connection settings of the
+// peer (TLS certificate and gRPC options) are taken from the peer in the configuration which
+// matches the peer's endpoint. If there's no match then the endpoint is used as is.
+func NewPeer(ctx context.Client, info *PeerInfo) (*Peer, error) {
+	peerConfig, err := ctx.Config().PeerConfigByURL(info.Endpoint)
+	if err != nil || peerConfig == nil {
+		logger.Debugf("No configuration found for discovered peer [%s]: %v", info.Endpoint, err)
+		peerConfig = &core.PeerConfig{URL: info.Endpoint}
+	}
+
+	peer, err := ctx.InfraProvider().CreatePeerFromConfig(&core.NetworkPeer{PeerConfig: *peerConfig, MSPID: info.MSPID})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create discovered peer")
+	}
+	return &Peer{Peer: peer, info: info}, nil
+}
+
+// Endpoint returns the endpoint of the peer as advertised to the discovery service
+func (p *Peer) Endpoint() string {
+	return p.info.Endpoint
+}
+
+// BlockHeight returns the height of the peer's ledger on the channel
+func (p *Peer) BlockHeight() uint64 {
+	return p.info.LedgerHeight
+}
+
+// Chaincodes returns the names of the chaincodes installed on the peer and instantiated on the channel
+func (p *Peer) Chaincodes() []string {
+	return p.info.Chaincodes
+}
+
+// HasChaincode returns true if the given chaincode is installed on the peer and instantiated on the channel
+func (p *Peer) HasChaincode(ccID string) bool {
+	for _, cc := range p.info.Chaincodes {
+		if cc == ccID {
+			return true
+		}
+	}
+	return false
+}
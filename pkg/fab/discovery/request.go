@@ -0,0 +1,137 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"github.com/pkg/errors"
+
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+)
+
+// ChaincodeCall identifies a chaincode invoked by a transaction, along with the
+// private data collections which the chaincode accesses
+type ChaincodeCall struct {
+	ID          string
+	Collections []string
+}
+
+// Request is a discovery request made up of one or more queries. The results
+// of the queries are returned in the order in which the queries were added.
+type Request struct {
+	queries []*discpb.Query
+}
+
+// NewRequest returns a new, empty discovery request
+func NewRequest() *Request {
+	return &Request{}
+}
+
+// AddConfigQuery adds a query for the MSP and orderer configuration of the channel
+func (r *Request) AddConfigQuery(channelID string) *Request {
+	return r.add(&discpb.Query{
+		Channel: channelID,
+		Query:   &discpb.Query_ConfigQuery{ConfigQuery: &discpb.ConfigQuery{}},
+	})
+}
+
+// AddPeersQuery adds a query for the peers which have joined the channel
+func (r *Request) AddPeersQuery(channelID string) *Request {
+	return r.add(&discpb.Query{
+		Channel: channelID,
+		Query:   &discpb.Query_PeerQuery{PeerQuery: &discpb.PeerMembershipQuery{}},
+	})
+}
+
+// AddLocalPeersQuery adds a query for all of the peers known to the target peer,
+// regardless of channel. (The peer only answers this query for administrators.)
+func (r *Request) AddLocalPeersQuery() *Request {
+	return r.add(&discpb.Query{
+		Query: &discpb.Query_LocalPeers{LocalPeers: &discpb.LocalPeerQuery{}},
+	})
+}
+
+// AddEndorsersQuery adds a query for the endorsement layouts of a transaction which invokes the
+// given chaincodes. The first chaincode is the one invoked by the client, the others being
+// chaincodes which it calls.
+func (r *Request) AddEndorsersQuery(channelID string, calls ...ChaincodeCall) *Request {
+	interest := &discpb.ChaincodeInterest{}
+	for _, call := range calls {
+		interest.Chaincodes = append(interest.Chaincodes, &discpb.ChaincodeCall{
+			Name:            call.ID,
+			CollectionNames: call.Collections,
+		})
+	}
+	return r.add(&discpb.Query{
+		Channel: channelID,
+		Query:   &discpb.Query_CcQuery{CcQuery: &discpb.ChaincodeQuery{Interests: []*discpb.ChaincodeInterest{interest}}},
+	})
+}
+
+func (r *Request) add(query *discpb.Query) *Request {
+	r.queries = append(r.queries, query)
+	return r
+}
+
+// Response holds the results of a discovery request
+type Response struct {
+	results []*discpb.QueryResult
+}
+
+// ConfigAt returns the channel configuration returned for the query at the given index
+func (r *Response) ConfigAt(i int) (*discpb.ConfigResult, error) {
+	result, err := r.resultAt(i)
+	if err != nil {
+		return nil, err
+	}
+	config := result.GetConfigResult()
+	if config == nil {
+		return nil, errors.Errorf("result at index %d is not a config result", i)
+	}
+	return config, nil
+}
+
+// PeersAt returns the peers returned for the (channel or local) peers query at the given index
+func (r *Response) PeersAt(i int) ([]*discpb.Peer, error) {
+	result, err := r.resultAt(i)
+	if err != nil {
+		return nil, err
+	}
+	members := result.GetMembers()
+	if members == nil {
+		return nil, errors.Errorf("result at index %d is not a peer membership result", i)
+	}
+
+	var peers []*discpb.Peer
+	for _, orgPeers := range members.PeersByOrg {
+		peers = append(peers, orgPeers.Peers...)
+	}
+	return peers, nil
+}
+
+// EndorsersAt returns the endorsement descriptor returned for the endorsers query at the given index
+func (r *Response) EndorsersAt(i int) (*discpb.EndorsementDescriptor, error) {
+	result, err := r.resultAt(i)
+	if err != nil {
+		return nil, err
+	}
+	ccResult := result.GetCcQueryRes()
+	if ccResult == nil || len(ccResult.Content) == 0 {
+		return nil, errors.Errorf("result at index %d is not a chaincode query result", i)
+	}
+	return ccResult.Content[0], nil
+}
+
+func (r *Response) resultAt(i int) (*discpb.QueryResult, error) {
+	if i < 0 || i >= len(r.results) {
+		return nil, errors.Errorf("no result at index %d", i)
+	}
+	result := r.results[i]
+	if discErr := result.GetError(); discErr != nil {
+		return nil, errors.Errorf("discovery service returned error: %s", discErr.Content)
+	}
+	return result, nil
+}
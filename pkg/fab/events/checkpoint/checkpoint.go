@@ -0,0 +1,132 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package checkpoint records how far events have been delivered to the consumers of a channel
+// so that an event client may resume from that point after a restart.
+package checkpoint
+
+import (
+	"encoding/json"
+	"path"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/keyvaluestore"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint is the position of the last event delivered to consumers
+type Checkpoint struct {
+	// BlockNum is the number of the last block for which events were delivered
+	BlockNum uint64 `json:"block_num"`
+	// TxIndex is the index, within the block, of the last transaction for which events were delivered.
+	// A value of -1 indicates that only the block events were delivered.
+	TxIndex int `json:"tx_index"`
+	// Complete indicates that the events of all of the transactions in the block were delivered
+	Complete bool `json:"complete"`
+}
+
+// Delivered returns true if the events of the given transaction were delivered.
+// A transaction index of -1 refers to the block events.
+func (cp *Checkpoint) Delivered(blockNum uint64, txIndex int) bool {
+	if cp == nil {
+		return false
+	}
+	if blockNum != cp.BlockNum {
+		return blockNum < cp.BlockNum
+	}
+	return cp.Complete || txIndex <= cp.TxIndex
+}
+
+// NextBlockNum returns the number of the block from which delivery should resume
+func (cp *Checkpoint) NextBlockNum() uint64 {
+	if cp.Complete {
+		return cp.BlockNum + 1
+	}
+	return cp.BlockNum
+}
+
+// Store loads and saves the checkpoints of channels
+type Store interface {
+	// Load returns the checkpoint of the given channel or nil if no checkpoint was saved
+	Load(channelID string) (*Checkpoint, error)
+
+	// Save saves the checkpoint of the given channel
+	Save(channelID string, checkpoint *Checkpoint) error
+}
+
+// KVStore is a checkpoint store backed by a key-value store
+type KVStore struct {
+	store core.KVStore
+}
+
+// New returns a checkpoint store which saves checkpoints to the given key-value store.
+// Checkpoints are stored as JSON encoded byte arrays, keyed by channel ID.
+func New(store core.KVStore) *KVStore {
+	return &KVStore{store: store}
+}
+
+// NewFileStore returns a checkpoint store which saves the checkpoint of each channel to a file in the given directory
+func NewFileStore(dir string) (*KVStore, error) {
+	store, err := keyvaluestore.New(
+		&keyvaluestore.FileKeyValueStoreOptions{
+			Path: dir,
+			KeySerializer: func(key interface{}) (string, error) {
+				channelID, ok := key.(string)
+				if !ok {
+					return "", errors.New("converting key to string failed")
+				}
+				return path.Join(dir, channelID+".json"), nil
+			},
+		})
+	if err != nil {
+		return nil, errors.WithMessage(err, "creating file key-value store failed")
+	}
+	return New(store), nil
+}
+
+// NewMemoryStore returns a checkpoint store which keeps checkpoints in memory
+func NewMemoryStore() *KVStore {
+	return New(keyvaluestore.NewMemoryKeyValueStore())
+}
+
+// Load returns the checkpoint of the given channel or nil if no checkpoint was saved
+func (s *KVStore) Load(channelID string) (*Checkpoint, error) {
+	value, err := s.store.Load(channelID)
+	if err != nil {
+		if err == core.ErrKeyValueNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "loading checkpoint for channel [%s] failed", channelID)
+	}
+
+	valueBytes, ok := value.([]byte)
+	if !ok {
+		return nil, errors.Errorf("invalid checkpoint for channel [%s]", channelID)
+	}
+
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(valueBytes, cp); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal of checkpoint for channel [%s] failed", channelID)
+	}
+	return cp, nil
+}
+
+// Save saves the checkpoint of the given channel
+func (s *KVStore) Save(channelID string, checkpoint *Checkpoint) error {
+	if checkpoint == nil {
+		return errors.New("checkpoint is nil")
+	}
+
+	valueBytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "marshal of checkpoint failed")
+	}
+
+	if err := s.store.Store(channelID, valueBytes); err != nil {
+		return errors.Wrapf(err, "saving checkpoint for channel [%s] failed", channelID)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checkpoint
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	if err != nil {
+		t.Fatalf("creating temp dir failed: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %s", err)
+	}
+	testStore(t, store)
+
+	_, err = os.Stat(path.Join(dir, "mychannel.json"))
+	assert.Nil(t, err, "expected checkpoint file for channel")
+
+	// The checkpoint survives a restart
+	store, err = NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %s", err)
+	}
+	cp, err := store.Load("mychannel")
+	assert.Nil(t, err)
+	assert.Equal(t, &Checkpoint{BlockNum: 10, TxIndex: 4, Complete: true}, cp)
+}
+
+func testStore(t *testing.T, store Store) {
+	cp, err := store.Load("mychannel")
+	assert.Nil(t, err)
+	assert.Nil(t, cp, "expected no checkpoint")
+
+	assert.Nil(t, store.Save("mychannel", &Checkpoint{BlockNum: 10, TxIndex: 4, Complete: true}))
+	assert.Nil(t, store.Save("otherchannel", &Checkpoint{BlockNum: 2, TxIndex: -1}))
+	assert.NotNil(t, store.Save("mychannel", nil), "expected error for nil checkpoint")
+
+	cp, err = store.Load("mychannel")
+	assert.Nil(t, err)
+	assert.Equal(t, &Checkpoint{BlockNum: 10, TxIndex: 4, Complete: true}, cp)
+
+	cp, err = store.Load("otherchannel")
+	assert.Nil(t, err)
+	assert.Equal(t, &Checkpoint{BlockNum: 2, TxIndex: -1}, cp)
+}
+
+func TestDelivered(t *testing.T) {
+	var cp *Checkpoint
+	assert.False(t, cp.Delivered(0, -1), "nothing is delivered without a checkpoint")
+
+	cp = &Checkpoint{BlockNum: 5, TxIndex: 1}
+	assert.True(t, cp.Delivered(4, 10))
+	assert.True(t, cp.Delivered(5, -1))
+	assert.True(t, cp.Delivered(5, 1))
+	assert.False(t, cp.Delivered(5, 2))
+	assert.False(t, cp.Delivered(6, -1))
+	assert.Equal(t, uint64(5), cp.NextBlockNum())
+
+	cp.Complete = true
+	assert.True(t, cp.Delivered(5, 2))
+	assert.Equal(t, uint64(6), cp.NextBlockNum())
+}
@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/client/common/discovery/dynamicdiscovery"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/lbp"
 
@@ -107,6 +109,78 @@ func TestConnect(t *testing.T) {
 	}
 }
 
+func TestConnectDynamicDiscovery(t *testing.T) {
+	channelID := "testchannel"
+
+	server, address := fabmocks.StartDiscoveryServer("127.0.0.1:0")
+	defer server.Stop()
+	server.SetPeers(fabmocks.NewDiscoveryPeer("org1", "peer1.example.com:7051", 10))
+
+	discoveryProvider, err := dynamicdiscovery.New("org1", "user1")
+	if err != nil {
+		t.Fatalf("Error creating discovery provider: %s", err)
+	}
+	defer discoveryProvider.Close()
+
+	ctx := fabmocks.NewMockContextWithCustomDiscovery(mspmocks.NewMockSigningIdentity("user1", "org1"), discoveryProvider)
+	ctx.SetConfig(&discoveryConfig{Config: fabmocks.NewMockConfig(), address: address})
+	if err := discoveryProvider.Initialize(ctx); err != nil {
+		t.Fatalf("Error initializing discovery provider: %s", err)
+	}
+
+	dispatcher := New(
+		ctx,
+		fabmocks.NewMockChannelCfg(channelID),
+		clientmocks.NewProviderFactory().Provider(
+			clientmocks.NewMockConnection(
+				clientmocks.WithLedger(
+					servicemocks.NewMockLedger(servicemocks.FilteredBlockEventFactory, sourceURL),
+				),
+			),
+		),
+		WithLoadBalancePolicy(lbp.NewRandom()),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	// Connect to the discovered peer
+	errch := make(chan error)
+	dispatcherEventch <- NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	if dispatcher.Connection() == nil {
+		t.Fatalf("Got nil connection")
+	}
+
+	// Stop the dispatcher
+	stopResp := make(chan error)
+	dispatcherEventch <- esdispatcher.NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+}
+
+// discoveryConfig returns the mock discovery server as the only peer of the channel
+type discoveryConfig struct {
+	core.Config
+	address string
+}
+
+func (c *discoveryConfig) ChannelPeers(name string) ([]core.ChannelPeer, error) {
+	return []core.ChannelPeer{{NetworkPeer: core.NetworkPeer{PeerConfig: core.PeerConfig{URL: "grpc://" + c.address}, MSPID: "org1"}}}, nil
+}
+
+func (c *discoveryConfig) PeerConfigByURL(url string) (*core.PeerConfig, error) {
+	return nil, errors.Errorf("no peer config for [%s]", url)
+}
+
 func TestConnectNoPeers(t *testing.T) {
 	channelID := "testchannel"
 
@@ -0,0 +1,36 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lbp
+
+import (
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockheight"
+)
+
+// BlockHeight implements a load-balance policy which excludes peers that are lagging behind
+// the tallest peer and then chooses one of the remaining peers using another load-balance policy
+type BlockHeight struct {
+	tracker *blockheight.Tracker
+	lbp     LoadBalancePolicy
+}
+
+// NewBlockHeight returns a new BlockHeight load-balance policy. The given policy chooses among
+// the peers that are not lagging behind. If nil then the peer is chosen randomly.
+func NewBlockHeight(tracker *blockheight.Tracker, lbp LoadBalancePolicy) *BlockHeight {
+	if lbp == nil {
+		lbp = NewRandom()
+	}
+	return &BlockHeight{
+		tracker: tracker,
+		lbp:     lbp,
+	}
+}
+
+// Choose chooses a peer from the list of peers that are not lagging behind
+func (lbp *BlockHeight) Choose(peers []fab.Peer) (fab.Peer, error) {
+	return lbp.lbp.Choose(lbp.tracker.Filter(peers))
+}
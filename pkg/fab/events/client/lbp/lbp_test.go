@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/blockheight"
 	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 )
 
@@ -113,3 +114,27 @@ func newMockPeers(numPeers int) []fab.Peer {
 	}
 	return peers
 }
+
+func TestBlockHeight(t *testing.T) {
+	peer1 := fabmocks.NewMockPeer("peer1", "peer1.example.com:7051")
+	peer2 := fabmocks.NewMockPeer("peer2", "peer2.example.com:7051")
+	peer3 := fabmocks.NewMockPeer("peer3", "peer3.example.com:7051")
+
+	tracker := blockheight.NewTracker(blockheight.WithMaxBlocksBehind(1))
+	tracker.Update(peer1.URL(), 10)
+	tracker.Update(peer2.URL(), 5)
+	tracker.Update(peer3.URL(), 9)
+
+	lbp := NewBlockHeight(tracker, NewRoundRobin())
+
+	peers := []fab.Peer{peer1, peer2, peer3}
+	for i := 0; i < 10; i++ {
+		peer, err := lbp.Choose(peers)
+		if err != nil {
+			t.Fatalf("error choosing peer with block height load-balance policy: %s", err)
+		}
+		if peer == peer2 {
+			t.Fatalf("lagging peer should not have been chosen")
+		}
+	}
+}
@@ -59,6 +59,10 @@ func New(context fabcontext.Client, chConfig fab.ChannelCfg, opts ...options.Opt
 	// produces event endpoints containing additional GRPC options.
 	deliverCtx := newDeliverContext(context)
 
+	if err := params.seekFromCheckpoint(chConfig.ID()); err != nil {
+		return nil, err
+	}
+
 	client := &Client{
 		Client: *client.New(
 			dispatcher.New(deliverCtx, chConfig, params.connProvider, opts...),
@@ -106,14 +110,18 @@ func (c *Client) setSeekFromLastBlockReceived() error {
 	c.Lock()
 	defer c.Unlock()
 
-	// Make sure that, when we reconnect, we receive all of the events that we've missed
+	// Make sure that, when we reconnect, we receive all of the events that we've missed.
+	// The peer that we reconnect to delivers the blocks committed while we were disconnected
+	// before any new blocks so the stream of events remains gap-free.
 	lastBlockNum := c.Dispatcher().LastBlockNum()
 	if lastBlockNum < math.MaxUint64 {
+		logger.Debugf("Resuming from block #%d", lastBlockNum+1)
 		c.seekType = seek.FromBlock
-		c.fromBlock = c.Dispatcher().LastBlockNum() + 1
+		c.fromBlock = lastBlockNum + 1
 	} else {
-		// We haven't received any blocks yet. Just ask for the newest
-		c.seekType = seek.Newest
+		// We haven't received any blocks yet. Seek from the position that was
+		// originally requested (or from the checkpoint) so that nothing is missed.
+		logger.Debugf("No blocks received yet. Seeking from [%s]", c.seekType)
 	}
 	return nil
 }
@@ -134,6 +142,29 @@ func (c *Client) seekInfo() (*ab.SeekInfo, error) {
 	}
 }
 
+// seekFromCheckpoint sets the seek position to the block following the last one whose events were
+// all delivered, according to the checkpoint of the channel. The seek position is left unchanged if
+// there is no checkpoint.
+func (p *params) seekFromCheckpoint(channelID string) error {
+	if p.checkpoints == nil {
+		return nil
+	}
+
+	cp, err := p.checkpoints.Load(channelID)
+	if err != nil {
+		return errors.WithMessage(err, "unable to load checkpoint")
+	}
+	if cp == nil {
+		logger.Debugf("No checkpoint for channel [%s]", channelID)
+		return nil
+	}
+
+	logger.Debugf("Resuming from checkpoint for channel [%s]: %#v", channelID, cp)
+	p.seekType = seek.FromBlock
+	p.fromBlock = cp.NextBlockNum()
+	return nil
+}
+
 // deliverContext overrides the DiscoveryProvider
 type deliverContext struct {
 	fabcontext.Client
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/checkpoint"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client"
 	clientdisp "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	clientmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/mocks"
@@ -463,3 +464,42 @@ func newMockContext() *fabmocks.MockContext {
 	ctx.SetConfig(newMockConfig())
 	return ctx
 }
+
+func TestSeekFromCheckpoint(t *testing.T) {
+	channelID := "mychannel"
+
+	store := checkpoint.NewMemoryStore()
+	if err := store.Save(channelID, &checkpoint.Checkpoint{BlockNum: 5, TxIndex: 2}); err != nil {
+		t.Fatalf("error saving checkpoint: %s", err)
+	}
+
+	eventClient, err := New(
+		newMockContext(),
+		fabmocks.NewMockChannelCfg(channelID),
+		WithSeekType(seek.Newest),
+		WithCheckpointStore(store),
+	)
+	if err != nil {
+		t.Fatalf("error creating deliver client: %s", err)
+	}
+	defer eventClient.Close()
+
+	// Block #5 was only partially delivered so it must be delivered again
+	assertSeekFrom(t, eventClient, 5)
+
+	// No blocks were received so a reconnect also resumes from the checkpoint
+	if err := eventClient.setSeekFromLastBlockReceived(); err != nil {
+		t.Fatalf("error setting seek position: %s", err)
+	}
+	assertSeekFrom(t, eventClient, 5)
+}
+
+func assertSeekFrom(t *testing.T, eventClient *Client, blockNum uint64) {
+	seekInfo, err := eventClient.seekInfo()
+	if err != nil {
+		t.Fatalf("error getting seek info: %s", err)
+	}
+	if seekInfo.Start.GetSpecified() == nil || seekInfo.Start.GetSpecified().Number != blockNum {
+		t.Fatalf("expecting seek from block #%d but got %#v", blockNum, seekInfo.Start)
+	}
+}
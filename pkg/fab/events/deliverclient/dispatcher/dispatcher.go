@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package dispatcher
 
 import (
+	"math"
+
 	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
@@ -78,9 +80,13 @@ func (ed *Dispatcher) handleEvent(e esdispatcher.Event) {
 	case *pb.DeliverResponse_Status:
 		ed.handleDeliverResponseStatus(response)
 	case *pb.DeliverResponse_Block:
-		ed.HandleBlock(response.Block, delevent.SourceURL)
+		if ed.checkGap(response.Block.Header.Number) {
+			ed.HandleBlock(response.Block, delevent.SourceURL)
+		}
 	case *pb.DeliverResponse_FilteredBlock:
-		ed.HandleFilteredBlock(response.FilteredBlock, delevent.SourceURL)
+		if ed.checkGap(response.FilteredBlock.Number) {
+			ed.HandleFilteredBlock(response.FilteredBlock, delevent.SourceURL)
+		}
 	default:
 		logger.Errorf("handler not found for deliver response type %T", response)
 	}
@@ -94,7 +100,31 @@ func (ed *Dispatcher) handleDeliverResponseStatus(evt *pb.DeliverResponse_Status
 	}
 
 	logger.Warnf("Got deliver response status event: %#v. Disconnecting...", evt)
+	ed.disconnect(errors.Errorf("got error status from deliver server: %s", evt.Status))
+}
+
+// checkGap returns true if the given block immediately follows the last block received. If any blocks
+// are missing then false is returned and the connection is closed. When the client reconnects it seeks
+// from the block following the last block received so that the missing blocks are delivered before
+// any new blocks.
+func (ed *Dispatcher) checkGap(blockNum uint64) bool {
+	lastBlockNum := ed.LastBlockNum()
+	if lastBlockNum == math.MaxUint64 || blockNum <= lastBlockNum+1 {
+		return true
+	}
+
+	if ed.Connection() == nil {
+		// We've already disconnected in order to catch up. Ignore any blocks that were still in flight.
+		logger.Debugf("Ignoring block #%d received after disconnecting", blockNum)
+		return false
+	}
+
+	logger.Warnf("Received block #%d but expecting block #%d. Disconnecting in order to catch up on the missing blocks...", blockNum, lastBlockNum+1)
+	ed.disconnect(errors.Errorf("missing blocks #%d to #%d", lastBlockNum+1, blockNum-1))
+	return false
+}
 
+func (ed *Dispatcher) disconnect(cause error) {
 	errch := make(chan error, 1)
 	ed.Dispatcher.HandleDisconnectEvent(&clientdisp.DisconnectEvent{
 		Errch: errch,
@@ -105,7 +135,7 @@ func (ed *Dispatcher) handleDeliverResponseStatus(evt *pb.DeliverResponse_Status
 	}
 
 	ed.Dispatcher.HandleDisconnectedEvent(&clientdisp.DisconnectedEvent{
-		Err: errors.Errorf("got error status from deliver server: %s", evt.Status),
+		Err: cause,
 	})
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	clientdisp "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/dispatcher"
 	clientmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/client/mocks"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/connection"
 	delivermocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/mocks"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter"
@@ -20,6 +21,7 @@ import (
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
 	fabmocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
 	mspmocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmsp"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
 
@@ -295,3 +297,96 @@ func TestFilteredBlockEvents(t *testing.T) {
 		t.Fatalf("Error stopping dispatcher: %s", err)
 	}
 }
+
+func TestBlockGap(t *testing.T) {
+	channelID := "testchannel"
+
+	dispatcher := New(
+		fabmocks.NewMockContextWithCustomDiscovery(
+			mspmocks.NewMockSigningIdentity("user1", "Org1MSP"),
+			clientmocks.NewDiscoveryProvider(peer1, peer2),
+		),
+		fabmocks.NewMockChannelCfg(channelID),
+		clientmocks.NewProviderFactory().Provider(
+			delivermocks.NewConnection(
+				clientmocks.WithLedger(servicemocks.NewMockLedger(delivermocks.BlockEventFactory, sourceURL)),
+			),
+		),
+	)
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	errch := make(chan error)
+	regch := make(chan fab.Registration)
+	conneventch := make(chan *clientdisp.ConnectionEvent, 5)
+	dispatcherEventch <- clientdisp.NewRegisterConnectionEvent(conneventch, regch, errch)
+	select {
+	case err := <-errch:
+		t.Fatalf("Error registering for connection events: %s", err)
+	case <-regch:
+	}
+
+	dispatcherEventch <- clientdisp.NewConnectEvent(errch)
+	if err := <-errch; err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+
+	eventch := make(chan *fab.BlockEvent, 10)
+	dispatcherEventch <- esdispatcher.NewRegisterBlockEvent(blockfilter.AcceptAny, eventch, regch, errch)
+	select {
+	case <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for block events: %s", err)
+	}
+
+	dispatcherEventch <- newBlockEvent(channelID, 5)
+	// Blocks #6 and #7 are missing
+	dispatcherEventch <- newBlockEvent(channelID, 8)
+	dispatcherEventch <- newBlockEvent(channelID, 9)
+
+	select {
+	case event := <-eventch:
+		if event.Block.Header.Number != 5 {
+			t.Fatalf("expecting block #5 but got block #%d", event.Block.Header.Number)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for block event")
+	}
+
+	for {
+		select {
+		case event := <-conneventch:
+			if event.Connected {
+				continue
+			}
+			if event.Err == nil || event.Err.Error() != "missing blocks #6 to #7" {
+				t.Fatalf("unexpected disconnected event error: %v", event.Err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out waiting for disconnected event")
+		}
+		break
+	}
+
+	select {
+	case event := <-eventch:
+		t.Fatalf("not expecting block #%d to be published before the missing blocks", event.Block.Header.Number)
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	if dispatcher.LastBlockNum() != 5 {
+		t.Fatalf("expecting last block number 5 but got %d", dispatcher.LastBlockNum())
+	}
+}
+
+func newBlockEvent(channelID string, blockNum uint64) *connection.Event {
+	block := servicemocks.NewBlock(channelID)
+	block.Header.Number = blockNum
+	return connection.NewEvent(&pb.DeliverResponse{Type: &pb.DeliverResponse_Block{Block: block}}, sourceURL)
+}
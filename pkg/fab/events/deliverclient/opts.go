@@ -11,6 +11,7 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/api"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/checkpoint"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/deliverclient/seek"
 )
 
@@ -19,6 +20,7 @@ type params struct {
 	seekType     seek.Type
 	fromBlock    uint64
 	respTimeout  time.Duration
+	checkpoints  checkpoint.Store
 }
 
 func defaultParams() *params {
@@ -48,6 +50,18 @@ func WithBlockNum(value uint64) options.Opt {
 	}
 }
 
+// WithCheckpointStore specifies the store in which the position of the last event delivered
+// to consumers is recorded. If a checkpoint exists for the channel then the client resumes
+// from that point, overriding the seek type, and events that were already delivered are not
+// delivered again.
+func WithCheckpointStore(value checkpoint.Store) options.Opt {
+	return func(p options.Params) {
+		if setter, ok := p.(checkpointStoreSetter); ok {
+			setter.SetCheckpointStore(value)
+		}
+	}
+}
+
 // withConnectionProvider is used only for testing
 func withConnectionProvider(connProvider api.ConnectionProvider) options.Opt {
 	return func(p options.Params) {
@@ -70,6 +84,10 @@ type fromBlockSetter interface {
 	SetFromBlock(value uint64)
 }
 
+type checkpointStoreSetter interface {
+	SetCheckpointStore(value checkpoint.Store)
+}
+
 func (p *params) PermitBlockEvents() {
 	logger.Debugf("PermitBlockEvents")
 	p.connProvider = deliverProvider
@@ -95,3 +113,8 @@ func (p *params) SetResponseTimeout(value time.Duration) {
 	logger.Debugf("ResponseTimeout: %s", value)
 	p.respTimeout = value
 }
+
+func (p *params) SetCheckpointStore(value checkpoint.Store) {
+	logger.Debugf("CheckpointStore: %T", value)
+	p.checkpoints = value
+}
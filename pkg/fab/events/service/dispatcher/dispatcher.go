@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/checkpoint"
 	ledgerutil "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/util"
 	cb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
@@ -52,6 +53,8 @@ type Dispatcher struct {
 	ccRegistrations            map[string]*ChaincodeReg
 	state                      int32
 	lastBlockNum               uint64
+	checkpoint                 *checkpoint.Checkpoint
+	checkpointLoaded           bool
 }
 
 // New creates a new Dispatcher.
@@ -146,7 +149,7 @@ func (ed *Dispatcher) updateLastBlockNum(blockNum uint64) error {
 		atomic.StoreUint64(&ed.lastBlockNum, blockNum)
 		return nil
 	}
-	return errors.Errorf("Expecting a block number greater than %d but received block number %d", lastBlockNum, lastBlockNum)
+	return errors.Errorf("Expecting a block number greater than %d but received block number %d", lastBlockNum, blockNum)
 }
 
 // clearBlockRegistrations removes all block registrations and closes the corresponding event channels.
@@ -306,8 +309,20 @@ func (ed *Dispatcher) HandleBlock(block *cb.Block, sourceURL string) {
 		return
 	}
 
-	ed.publishBlockEvents(block, sourceURL)
-	ed.publishFilteredBlockEvents(toFilteredBlock(block), sourceURL)
+	fblock := toFilteredBlock(block)
+	delivered := false
+	if ed.delivered(fblock.ChannelId, fblock.Number, -1) {
+		logger.Debugf("Not publishing block events for block #%d since they were already delivered", fblock.Number)
+	} else {
+		delivered = ed.publishBlockEvents(block, sourceURL)
+		if ed.publishFilteredBlockEvents(fblock, sourceURL) {
+			delivered = true
+		}
+	}
+
+	if ed.publishTxEvents(fblock, sourceURL) || delivered {
+		ed.saveBlockCheckpoint(fblock)
+	}
 }
 
 // HandleFilteredBlock handles a filtered block event
@@ -319,8 +334,59 @@ func (ed *Dispatcher) HandleFilteredBlock(fblock *pb.FilteredBlock, sourceURL st
 		return
 	}
 
-	logger.Debugf("Publishing filtered block event...")
-	ed.publishFilteredBlockEvents(fblock, sourceURL)
+	delivered := false
+	if ed.delivered(fblock.ChannelId, fblock.Number, -1) {
+		logger.Debugf("Not publishing filtered block event for block #%d since it was already delivered", fblock.Number)
+	} else {
+		logger.Debugf("Publishing filtered block event...")
+		delivered = ed.publishFilteredBlockEvents(fblock, sourceURL)
+	}
+
+	if ed.publishTxEvents(fblock, sourceURL) || delivered {
+		ed.saveBlockCheckpoint(fblock)
+	}
+}
+
+// delivered returns true if the events of the given transaction (or the block events if txIndex is -1)
+// were delivered, according to the checkpoint of the channel
+func (ed *Dispatcher) delivered(channelID string, blockNum uint64, txIndex int) bool {
+	if ed.checkpointStore == nil {
+		return false
+	}
+
+	if !ed.checkpointLoaded {
+		cp, err := ed.checkpointStore.Load(channelID)
+		if err != nil {
+			logger.Warnf("Unable to load checkpoint for channel [%s]: %s", channelID, err)
+		}
+		ed.checkpoint = cp
+		ed.checkpointLoaded = true
+	}
+
+	return ed.checkpoint.Delivered(blockNum, txIndex)
+}
+
+// saveBlockCheckpoint records that the events of the given block were delivered. It is only called
+// once all of the events of the block were published and at least one of them reached a consumer,
+// so that the checkpoint is written once per block and doesn't move past blocks nobody received.
+func (ed *Dispatcher) saveBlockCheckpoint(fblock *pb.FilteredBlock) {
+	if fblock == nil {
+		return
+	}
+	ed.saveCheckpoint(fblock.ChannelId, &checkpoint.Checkpoint{BlockNum: fblock.Number, TxIndex: len(fblock.FilteredTransactions) - 1, Complete: true})
+}
+
+// saveCheckpoint records the position of the last event delivered
+func (ed *Dispatcher) saveCheckpoint(channelID string, cp *checkpoint.Checkpoint) {
+	if ed.checkpointStore == nil {
+		return
+	}
+
+	ed.checkpoint = cp
+	ed.checkpointLoaded = true
+	if err := ed.checkpointStore.Save(channelID, cp); err != nil {
+		logger.Warnf("Unable to save checkpoint for channel [%s]: %s", channelID, err)
+	}
 }
 
 func (ed *Dispatcher) unregisterBlockEvents(registration *BlockReg) error {
@@ -374,7 +440,8 @@ func (ed *Dispatcher) unregisterTXEvents(registration *TxStatusReg) error {
 	return nil
 }
 
-func (ed *Dispatcher) publishBlockEvents(block *cb.Block, sourceURL string) {
+func (ed *Dispatcher) publishBlockEvents(block *cb.Block, sourceURL string) bool {
+	delivered := false
 	for _, reg := range ed.blockRegistrations {
 		if !reg.Filter(block) {
 			logger.Debugf("Not sending block event for block #%d since it was filtered out.", block.Header.Number)
@@ -396,13 +463,15 @@ func (ed *Dispatcher) publishBlockEvents(block *cb.Block, sourceURL string) {
 				logger.Warnf("Timed out sending block event.")
 			}
 		}
+		delivered = true
 	}
+	return delivered
 }
 
-func (ed *Dispatcher) publishFilteredBlockEvents(fblock *pb.FilteredBlock, sourceURL string) {
+func (ed *Dispatcher) publishFilteredBlockEvents(fblock *pb.FilteredBlock, sourceURL string) bool {
 	if fblock == nil {
 		logger.Warnf("Filtered block is nil. Event will not be published")
-		return
+		return false
 	}
 
 	logger.Debugf("Publishing filtered block event: %#v", fblock)
@@ -424,28 +493,47 @@ func (ed *Dispatcher) publishFilteredBlockEvents(fblock *pb.FilteredBlock, sourc
 			}
 		}
 	}
+	return len(ed.filteredBlockRegistrations) > 0
+}
 
-	for _, tx := range fblock.FilteredTransactions {
-		ed.publishTxStatusEvents(tx, fblock.Number, sourceURL)
+// publishTxEvents publishes the transaction status and chaincode events of the transactions in the
+// filtered block, skipping the transactions whose events were already delivered. It returns true if
+// any event was delivered to a consumer.
+func (ed *Dispatcher) publishTxEvents(fblock *pb.FilteredBlock, sourceURL string) bool {
+	if fblock == nil {
+		return false
+	}
+
+	delivered := false
+	for i, tx := range fblock.FilteredTransactions {
+		if ed.delivered(fblock.ChannelId, fblock.Number, i) {
+			logger.Debugf("Not publishing events for TxID [%s] since they were already delivered", tx.Txid)
+			continue
+		}
+
+		if ed.publishTxStatusEvents(tx, fblock.Number, sourceURL) {
+			delivered = true
+		}
 
 		// Only send a chaincode event if the transaction has committed
 		if tx.TxValidationCode == pb.TxValidationCode_VALID {
 			txActions := tx.GetTransactionActions()
-			if txActions == nil {
-				continue
-			}
-			for _, action := range txActions.ChaincodeActions {
-				if action.ChaincodeEvent != nil {
-					ed.publishCCEvents(action.ChaincodeEvent, fblock.Number, sourceURL)
+			if txActions != nil {
+				for _, action := range txActions.ChaincodeActions {
+					if action.ChaincodeEvent != nil && ed.publishCCEvents(action.ChaincodeEvent, fblock.Number, sourceURL) {
+						delivered = true
+					}
 				}
 			}
 		}
 	}
+	return delivered
 }
 
-func (ed *Dispatcher) publishTxStatusEvents(tx *pb.FilteredTransaction, blockNum uint64, sourceURL string) {
+func (ed *Dispatcher) publishTxStatusEvents(tx *pb.FilteredTransaction, blockNum uint64, sourceURL string) bool {
 	logger.Debugf("Publishing Tx Status event for TxID [%s]...", tx.Txid)
-	if reg, ok := ed.txRegistrations[tx.Txid]; ok {
+	reg, ok := ed.txRegistrations[tx.Txid]
+	if ok {
 		logger.Debugf("Sending Tx Status event for TxID [%s] to registrant...", tx.Txid)
 
 		if ed.eventConsumerTimeout < 0 {
@@ -464,9 +552,11 @@ func (ed *Dispatcher) publishTxStatusEvents(tx *pb.FilteredTransaction, blockNum
 			}
 		}
 	}
+	return ok
 }
 
-func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent, blockNum uint64, sourceURL string) {
+func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent, blockNum uint64, sourceURL string) bool {
+	delivered := false
 	for _, reg := range ed.ccRegistrations {
 		logger.Debugf("Matching CCEvent[%s,%s] against Reg[%s,%s] ...", ccEvent.ChaincodeId, ccEvent.EventName, reg.ChaincodeID, reg.EventFilter)
 		if reg.ChaincodeID == ccEvent.ChaincodeId && reg.EventRegExp.MatchString(ccEvent.EventName) {
@@ -487,8 +577,10 @@ func (ed *Dispatcher) publishCCEvents(ccEvent *pb.ChaincodeEvent, blockNum uint6
 					logger.Warnf("Timed out sending CC event.")
 				}
 			}
+			delivered = true
 		}
 	}
+	return delivered
 }
 
 // RegisterHandler registers an event handler
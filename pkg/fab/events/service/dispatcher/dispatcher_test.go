@@ -8,10 +8,13 @@ package dispatcher
 
 import (
 	"bytes"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/checkpoint"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/blockfilter/headertypefilter"
 	servicemocks "github.com/hyperledger/fabric-sdk-go/pkg/fab/events/service/mocks"
@@ -823,3 +826,186 @@ func checkCCEvent(t *testing.T, event *fab.CCEvent, expectedCCID string, expecte
 		t.Fatalf("expecting one of [%v] but received [%s]", expectedEventNames, event.EventName)
 	}
 }
+
+func TestCheckpoint(t *testing.T) {
+	channelID := "testchannel"
+	txID1 := "1234"
+	txID2 := "5678"
+	txID3 := "9012"
+
+	store := checkpoint.NewMemoryStore()
+	// The events of the first transaction in block #1 were delivered before a restart
+	if err := store.Save(channelID, &checkpoint.Checkpoint{BlockNum: 1, TxIndex: 0}); err != nil {
+		t.Fatalf("Error saving checkpoint: %s", err)
+	}
+
+	dispatcher := New(withCheckpointStore(store))
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+
+	fbeventch := make(chan *fab.FilteredBlockEvent, 10)
+	dispatcherEventch <- NewRegisterFilteredBlockEvent(fbeventch, regch, errch)
+	select {
+	case <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for filtered block events: %s", err)
+	}
+
+	txeventchs := make(map[string]chan *fab.TxStatusEvent)
+	for _, txID := range []string{txID1, txID2, txID3} {
+		txeventchs[txID] = make(chan *fab.TxStatusEvent, 10)
+		dispatcherEventch <- NewRegisterTxStatusEvent(txID, txeventchs[txID], regch, errch)
+		select {
+		case <-regch:
+		case err := <-errch:
+			t.Fatalf("Error registering for TxStatus events: %s", err)
+		}
+	}
+
+	fblock1 := servicemocks.NewFilteredBlock(channelID,
+		servicemocks.NewFilteredTx(txID1, pb.TxValidationCode_VALID),
+		servicemocks.NewFilteredTx(txID2, pb.TxValidationCode_VALID),
+	)
+	fblock1.Number = 1
+	fblock2 := servicemocks.NewFilteredBlock(channelID, servicemocks.NewFilteredTx(txID3, pb.TxValidationCode_VALID))
+	fblock2.Number = 2
+
+	dispatcherEventch <- NewFilteredBlockEvent(fblock1, sourceURL)
+	dispatcherEventch <- NewFilteredBlockEvent(fblock2, sourceURL)
+
+	// The filtered block event of block #1 and the event for the first transaction were already delivered
+	select {
+	case event := <-fbeventch:
+		if event.FilteredBlock.Number != 2 {
+			t.Fatalf("expecting filtered block event for block #2 but got block #%d", event.FilteredBlock.Number)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for filtered block event")
+	}
+
+	for _, txID := range []string{txID2, txID3} {
+		select {
+		case <-txeventchs[txID]:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for TxStatus event for TxID [%s]", txID)
+		}
+	}
+	select {
+	case <-txeventchs[txID1]:
+		t.Fatalf("not expecting TxStatus event for TxID [%s] since it was already delivered", txID1)
+	default:
+	}
+
+	stopResp := make(chan error)
+	dispatcherEventch <- NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+
+	cp, err := store.Load(channelID)
+	if err != nil {
+		t.Fatalf("Error loading checkpoint: %s", err)
+	}
+	if cp == nil || cp.BlockNum != 2 || cp.TxIndex != 0 || !cp.Complete {
+		t.Fatalf("unexpected checkpoint: %#v", cp)
+	}
+}
+
+func TestCheckpointSavedOncePerDeliveredBlock(t *testing.T) {
+	channelID := "testchannel"
+	txID1 := "1234"
+	txID2 := "5678"
+
+	store := &countingCheckpointStore{Store: checkpoint.NewMemoryStore()}
+	dispatcher := New(withCheckpointStore(store))
+	if err := dispatcher.Start(); err != nil {
+		t.Fatalf("Error starting dispatcher: %s", err)
+	}
+
+	dispatcherEventch, err := dispatcher.EventCh()
+	if err != nil {
+		t.Fatalf("Error getting event channel from dispatcher: %s", err)
+	}
+
+	regch := make(chan fab.Registration)
+	errch := make(chan error)
+
+	txeventch := make(chan *fab.TxStatusEvent, 10)
+	dispatcherEventch <- NewRegisterTxStatusEvent(txID1, txeventch, regch, errch)
+	select {
+	case <-regch:
+	case err := <-errch:
+		t.Fatalf("Error registering for TxStatus events: %s", err)
+	}
+
+	// Block #1 has a consumer for one of its transactions
+	fblock1 := servicemocks.NewFilteredBlock(channelID,
+		servicemocks.NewFilteredTx(txID1, pb.TxValidationCode_VALID),
+		servicemocks.NewFilteredTx(txID2, pb.TxValidationCode_VALID),
+	)
+	fblock1.Number = 1
+	// No consumer receives any event of block #2
+	fblock2 := servicemocks.NewFilteredBlock(channelID, servicemocks.NewFilteredTx("9012", pb.TxValidationCode_VALID))
+	fblock2.Number = 2
+
+	dispatcherEventch <- NewFilteredBlockEvent(fblock1, sourceURL)
+	dispatcherEventch <- NewFilteredBlockEvent(fblock2, sourceURL)
+
+	select {
+	case <-txeventch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for TxStatus event for TxID [%s]", txID1)
+	}
+
+	stopResp := make(chan error)
+	dispatcherEventch <- NewStopEvent(stopResp)
+	if err := <-stopResp; err != nil {
+		t.Fatalf("Error stopping dispatcher: %s", err)
+	}
+
+	if saves := store.numSaves(); saves != 1 {
+		t.Fatalf("expecting one checkpoint save but got %d", saves)
+	}
+
+	cp, err := store.Load(channelID)
+	if err != nil {
+		t.Fatalf("Error loading checkpoint: %s", err)
+	}
+	if cp == nil || cp.BlockNum != 1 || cp.TxIndex != 1 || !cp.Complete {
+		t.Fatalf("unexpected checkpoint: %#v", cp)
+	}
+}
+
+type countingCheckpointStore struct {
+	checkpoint.Store
+	mutex sync.Mutex
+	saves int
+}
+
+func (s *countingCheckpointStore) Save(channelID string, cp *checkpoint.Checkpoint) error {
+	s.mutex.Lock()
+	s.saves++
+	s.mutex.Unlock()
+	return s.Store.Save(channelID, cp)
+}
+
+func (s *countingCheckpointStore) numSaves() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.saves
+}
+
+func withCheckpointStore(store checkpoint.Store) options.Opt {
+	return func(p options.Params) {
+		p.(*params).SetCheckpointStore(store)
+	}
+}
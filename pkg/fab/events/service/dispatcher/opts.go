@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/options"
+	"github.com/hyperledger/fabric-sdk-go/pkg/fab/events/checkpoint"
 )
 
 type params struct {
 	eventConsumerBufferSize uint
 	eventConsumerTimeout    time.Duration
+	checkpointStore         checkpoint.Store
 }
 
 func defaultParams() *params {
@@ -62,3 +64,8 @@ func (p *params) SetEventConsumerTimeout(value time.Duration) {
 	logger.Debugf("EventConsumerTimeout: %s", value)
 	p.eventConsumerTimeout = value
 }
+
+func (p *params) SetCheckpointStore(value checkpoint.Store) {
+	logger.Debugf("CheckpointStore: %T", value)
+	p.checkpointStore = value
+}
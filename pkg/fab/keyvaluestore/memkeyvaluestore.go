@@ -0,0 +1,69 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	"github.com/pkg/errors"
+)
+
+// MemoryKeyValueStore stores values in memory. The values do not survive a restart of the process.
+type MemoryKeyValueStore struct {
+	mutex  sync.RWMutex
+	values map[interface{}]interface{}
+}
+
+// NewMemoryKeyValueStore creates a new, empty, instance of MemoryKeyValueStore
+func NewMemoryKeyValueStore() *MemoryKeyValueStore {
+	return &MemoryKeyValueStore{
+		values: make(map[interface{}]interface{}),
+	}
+}
+
+// Load returns the value stored in the store for a key.
+// If a value for the key was not found, returns (nil, ErrNotFound)
+func (mkvs *MemoryKeyValueStore) Load(key interface{}) (interface{}, error) {
+	mkvs.mutex.RLock()
+	defer mkvs.mutex.RUnlock()
+
+	value, ok := mkvs.values[key]
+	if !ok {
+		return nil, core.ErrKeyValueNotFound
+	}
+	return value, nil
+}
+
+// Store sets the value for the key.
+func (mkvs *MemoryKeyValueStore) Store(key interface{}, value interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+	if value == nil {
+		return errors.New("value is nil")
+	}
+
+	mkvs.mutex.Lock()
+	defer mkvs.mutex.Unlock()
+
+	mkvs.values[key] = value
+	return nil
+}
+
+// Delete deletes the value for a key.
+func (mkvs *MemoryKeyValueStore) Delete(key interface{}) error {
+	if key == nil {
+		return errors.New("key is nil")
+	}
+
+	mkvs.mutex.Lock()
+	defer mkvs.mutex.Unlock()
+
+	delete(mkvs.values, key)
+	return nil
+}
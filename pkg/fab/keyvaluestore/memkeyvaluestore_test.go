@@ -0,0 +1,47 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package keyvaluestore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+)
+
+func TestMemoryKVS(t *testing.T) {
+	var store core.KVStore = NewMemoryKeyValueStore()
+
+	if err := store.Store(nil, []byte("1234")); err == nil {
+		t.Fatal("Store(nil, ...) should throw error")
+	}
+	if err := store.Store("key", nil); err == nil {
+		t.Fatal("Store(..., nil) should throw error")
+	}
+
+	if _, err := store.Load("key"); err != core.ErrKeyValueNotFound {
+		t.Fatalf("expected ErrKeyValueNotFound but got %v", err)
+	}
+
+	if err := store.Store("key", []byte("1234")); err != nil {
+		t.Fatalf("Store failed [%s]", err)
+	}
+	value, err := store.Load("key")
+	if err != nil {
+		t.Fatalf("Load failed [%s]", err)
+	}
+	if !bytes.Equal(value.([]byte), []byte("1234")) {
+		t.Fatalf("unexpected value [%s]", value)
+	}
+
+	if err := store.Delete("key"); err != nil {
+		t.Fatalf("Delete failed [%s]", err)
+	}
+	if _, err := store.Load("key"); err != core.ErrKeyValueNotFound {
+		t.Fatalf("expected ErrKeyValueNotFound after delete but got %v", err)
+	}
+}
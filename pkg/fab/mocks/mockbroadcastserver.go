@@ -42,26 +42,29 @@ type MockBroadcastServer struct {
 
 // Broadcast mock broadcast
 func (m *MockBroadcastServer) Broadcast(server po.AtomicBroadcast_BroadcastServer) error {
-	_, err := server.Recv()
-	if err == io.EOF {
-		return nil
-	}
-	if err != nil {
-		return err
+	for {
+		_, err := server.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if m.BroadcastError != nil {
+			return m.BroadcastError
+		}
+
+		response := broadcastResponseSuccess
+		if m.BroadcastInternalServerError {
+			response = broadcastResponseError
+		} else if m.BroadcastCustomResponse != nil {
+			response = m.BroadcastCustomResponse
+		}
+
+		if err := server.Send(response); err != nil {
+			return err
+		}
 	}
-	if m.BroadcastError != nil {
-		return m.BroadcastError
-	}
-
-	if m.BroadcastInternalServerError {
-		return server.Send(broadcastResponseError)
-	}
-
-	if m.BroadcastCustomResponse != nil {
-		return server.Send(m.BroadcastCustomResponse)
-	}
-
-	return server.Send(broadcastResponseSuccess)
 }
 
 // Deliver mock deliver
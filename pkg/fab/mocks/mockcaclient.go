@@ -27,11 +27,21 @@ func (mgr *MockCAClient) Enroll(enrollmentID string, enrollmentSecret string) er
 	return errors.New("not implemented")
 }
 
+// EnrollWithRequest enrolls a user with a Fabric network
+func (mgr *MockCAClient) EnrollWithRequest(request *api.EnrollmentRequest) error {
+	return errors.New("not implemented")
+}
+
 // Reenroll re-enrolls a user
 func (mgr *MockCAClient) Reenroll(enrollmentID string) error {
 	return errors.New("not implemented")
 }
 
+// ReenrollWithRequest re-enrolls a user
+func (mgr *MockCAClient) ReenrollWithRequest(request *api.ReenrollmentRequest) error {
+	return errors.New("not implemented")
+}
+
 // Register registers a user with a Fabric network
 func (mgr *MockCAClient) Register(request *api.RegistrationRequest) (string, error) {
 	return "", errors.New("not implemented")
@@ -41,3 +51,53 @@ func (mgr *MockCAClient) Register(request *api.RegistrationRequest) (string, err
 func (mgr *MockCAClient) Revoke(request *api.RevocationRequest) (*api.RevocationResponse, error) {
 	return nil, errors.New("not implemented")
 }
+
+// GetIdentity returns information about an identity
+func (mgr *MockCAClient) GetIdentity(id, caname string) (*api.IdentityResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetAllIdentities returns all identities
+func (mgr *MockCAClient) GetAllIdentities(caname string) ([]*api.IdentityResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// ModifyIdentity modifies an identity
+func (mgr *MockCAClient) ModifyIdentity(request *api.IdentityRequest) (*api.IdentityResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// RemoveIdentity removes an identity
+func (mgr *MockCAClient) RemoveIdentity(request *api.RemoveIdentityRequest) (*api.IdentityResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetAffiliation returns information about an affiliation
+func (mgr *MockCAClient) GetAffiliation(affiliation, caname string) (*api.AffiliationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetAllAffiliations returns all affiliations
+func (mgr *MockCAClient) GetAllAffiliations(caname string) (*api.AffiliationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// AddAffiliation adds an affiliation
+func (mgr *MockCAClient) AddAffiliation(request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// ModifyAffiliation renames an affiliation
+func (mgr *MockCAClient) ModifyAffiliation(request *api.ModifyAffiliationRequest) (*api.AffiliationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// RemoveAffiliation removes an affiliation
+func (mgr *MockCAClient) RemoveAffiliation(request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// GetCAInfo returns generic CA information
+func (mgr *MockCAClient) GetCAInfo(caname string) (*api.GetCAInfoResponse, error) {
+	return nil, errors.New("not implemented")
+}
@@ -75,6 +75,11 @@ func (c *MockConfig) CAConfig(org string) (*config.CAConfig, error) {
 	return &caConfig, nil
 }
 
+// CAConfigByID not implemented
+func (c *MockConfig) CAConfigByID(caID string) (*config.CAConfig, error) {
+	return nil, errors.New("not implemented")
+}
+
 //CAServerCertPems Read configuration option for the server certificate embedded pems
 func (c *MockConfig) CAServerCertPems(org string) ([]string, error) {
 	return nil, nil
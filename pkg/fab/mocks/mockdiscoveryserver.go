@@ -0,0 +1,202 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mocks
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	discpb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/gossip"
+	mb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/msp"
+)
+
+// MockDiscoveryServer is a mock discovery server which answers queries using the peers,
+// config and endorsement descriptors that were set on it
+type MockDiscoveryServer struct {
+	mutex        sync.RWMutex
+	grpcServer   *grpc.Server
+	peers        []*discpb.Peer
+	config       *discpb.ConfigResult
+	endorsers    map[string]*discpb.EndorsementDescriptor
+	err          error
+	requestCount int
+}
+
+// SetPeers sets the peers returned for channel and local peers queries
+func (m *MockDiscoveryServer) SetPeers(peers ...*discpb.Peer) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.peers = peers
+}
+
+// SetConfig sets the result of config queries
+func (m *MockDiscoveryServer) SetConfig(config *discpb.ConfigResult) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.config = config
+}
+
+// SetEndorsers sets the endorsement descriptor returned for queries of the given chaincode
+// (i.e. the first chaincode of the chaincode interest)
+func (m *MockDiscoveryServer) SetEndorsers(ccID string, descriptor *discpb.EndorsementDescriptor) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.endorsers == nil {
+		m.endorsers = make(map[string]*discpb.EndorsementDescriptor)
+	}
+	m.endorsers[ccID] = descriptor
+}
+
+// SetError sets the error returned for all requests
+func (m *MockDiscoveryServer) SetError(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.err = err
+}
+
+// RequestCount returns the number of requests received
+func (m *MockDiscoveryServer) RequestCount() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.requestCount
+}
+
+// Stop stops the server
+func (m *MockDiscoveryServer) Stop() {
+	m.grpcServer.Stop()
+}
+
+// Discover mock implementation which answers each of the queries of the request
+func (m *MockDiscoveryServer) Discover(ctx context.Context, signedRequest *discpb.SignedRequest) (*discpb.Response, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requestCount++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	request := &discpb.Request{}
+	if err := proto.Unmarshal(signedRequest.Payload, request); err != nil {
+		return nil, err
+	}
+
+	response := &discpb.Response{}
+	for _, query := range request.Queries {
+		response.Results = append(response.Results, m.answer(query))
+	}
+	return response, nil
+}
+
+func (m *MockDiscoveryServer) answer(query *discpb.Query) *discpb.QueryResult {
+	switch q := query.Query.(type) {
+	case *discpb.Query_ConfigQuery:
+		if m.config == nil {
+			return discoveryError("no config")
+		}
+		return &discpb.QueryResult{Result: &discpb.QueryResult_ConfigResult{ConfigResult: m.config}}
+	case *discpb.Query_PeerQuery:
+		return m.members(true)
+	case *discpb.Query_LocalPeers:
+		return m.members(false)
+	case *discpb.Query_CcQuery:
+		ccResult := &discpb.ChaincodeQueryResult{}
+		for _, interest := range q.CcQuery.Interests {
+			if len(interest.Chaincodes) == 0 {
+				return discoveryError("chaincode interest is empty")
+			}
+			descriptor, ok := m.endorsers[interest.Chaincodes[0].Name]
+			if !ok {
+				return discoveryError(fmt.Sprintf("no endorsement descriptor for chaincode %s", interest.Chaincodes[0].Name))
+			}
+			ccResult.Content = append(ccResult.Content, descriptor)
+		}
+		return &discpb.QueryResult{Result: &discpb.QueryResult_CcQueryRes{CcQueryRes: ccResult}}
+	default:
+		return discoveryError("unsupported query")
+	}
+}
+
+func (m *MockDiscoveryServer) members(withStateInfo bool) *discpb.QueryResult {
+	peersByOrg := make(map[string]*discpb.Peers)
+	for _, peer := range m.peers {
+		identity := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(peer.Identity, identity); err != nil {
+			return discoveryError(err.Error())
+		}
+		if !withStateInfo {
+			peer = &discpb.Peer{MembershipInfo: peer.MembershipInfo, Identity: peer.Identity}
+		}
+		orgPeers, ok := peersByOrg[identity.Mspid]
+		if !ok {
+			orgPeers = &discpb.Peers{}
+			peersByOrg[identity.Mspid] = orgPeers
+		}
+		orgPeers.Peers = append(orgPeers.Peers, peer)
+	}
+	return &discpb.QueryResult{Result: &discpb.QueryResult_Members{Members: &discpb.PeerMembershipResult{PeersByOrg: peersByOrg}}}
+}
+
+func discoveryError(msg string) *discpb.QueryResult {
+	return &discpb.QueryResult{Result: &discpb.QueryResult_Error{Error: &discpb.Error{Content: msg}}}
+}
+
+// NewDiscoveryPeer returns a peer, as returned by the discovery service, with the given
+// membership and state
+func NewDiscoveryPeer(mspID, endpoint string, ledgerHeight uint64, chaincodes ...string) *discpb.Peer {
+	aliveMsg := &gossip.GossipMessage{
+		Tag: gossip.GossipMessage_EMPTY,
+		Content: &gossip.GossipMessage_AliveMsg{
+			AliveMsg: &gossip.AliveMessage{
+				Membership: &gossip.Member{Endpoint: endpoint, PkiId: []byte(endpoint)},
+				Timestamp:  &gossip.PeerTime{},
+			},
+		},
+	}
+
+	properties := &gossip.Properties{LedgerHeight: ledgerHeight}
+	for _, cc := range chaincodes {
+		properties.Chaincodes = append(properties.Chaincodes, &gossip.Chaincode{Name: cc, Version: "v1"})
+	}
+	stateInfoMsg := &gossip.GossipMessage{
+		Tag: gossip.GossipMessage_CHAN_OR_ORG,
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{
+				Timestamp:  &gossip.PeerTime{},
+				PkiId:      []byte(endpoint),
+				Properties: properties,
+			},
+		},
+	}
+
+	return &discpb.Peer{
+		MembershipInfo: &gossip.Envelope{Payload: marshalOrPanic(aliveMsg)},
+		StateInfo:      &gossip.Envelope{Payload: marshalOrPanic(stateInfoMsg)},
+		Identity:       marshalOrPanic(&mb.SerializedIdentity{Mspid: mspID, IdBytes: []byte(endpoint)}),
+	}
+}
+
+// StartDiscoveryServer starts a mock discovery server for unit testing purpose and returns
+// the server along with the address that it listens on
+func StartDiscoveryServer(discoveryTestURL string) (*MockDiscoveryServer, string) {
+	grpcServer := grpc.NewServer()
+	lis, err := net.Listen("tcp", discoveryTestURL)
+	if err != nil {
+		panic(fmt.Sprintf("Error starting discovery server: %s", err))
+	}
+	discoveryServer := &MockDiscoveryServer{grpcServer: grpcServer}
+	discpb.RegisterDiscoveryServer(grpcServer, discoveryServer)
+	fmt.Printf("Test discovery server started\n")
+	go grpcServer.Serve(lis)
+	return discoveryServer, lis.Addr().String()
+}
@@ -52,3 +52,11 @@ func (t *MockTransactor) SendTransaction(tx *fab.Transaction) (*fab.TransactionR
 	}
 	return response, nil
 }
+
+// SendSignedEnvelope sends a transaction envelope that was signed outside of the SDK to the chain’s orderer service.
+func (t *MockTransactor) SendSignedEnvelope(envelope *fab.SignedEnvelope) (*fab.TransactionResponse, error) {
+	response := &fab.TransactionResponse{
+		Orderer: "example.com",
+	}
+	return response, nil
+}
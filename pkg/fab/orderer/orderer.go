@@ -19,6 +19,7 @@ import (
 	grpcstatus "google.golang.org/grpc/status"
 
 	ab "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/status"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
@@ -305,6 +306,81 @@ func broadcastStream(broadcastClient ab.AtomicBroadcast_BroadcastClient, respons
 	responses <- broadcastResponse.Status
 }
 
+// SendBroadcastBatch sends several envelopes to the orderer over a single broadcast stream.
+// The status, or the error, of each envelope is returned in the order the envelopes were given.
+func (o *Orderer) SendBroadcastBatch(ctx reqContext.Context, envelopes []*fab.SignedEnvelope) ([]*common.Status, []error) {
+	statuses := make([]*common.Status, len(envelopes))
+	errs := make([]error, len(envelopes))
+
+	conn, err := o.conn(ctx)
+	if err != nil {
+		rpcStatus, ok := grpcstatus.FromError(err)
+		if ok {
+			err = errors.WithMessage(status.NewFromGRPCStatus(rpcStatus), "connection failed")
+		} else {
+			err = status.New(status.OrdererClientStatus, status.ConnectionFailed.ToInt32(), err.Error(), nil)
+		}
+		return statuses, multi.Fill(errs, 0, err)
+	}
+	defer o.releaseConn(ctx, conn)
+
+	broadcastClient, err := ab.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		rpcStatus, ok := grpcstatus.FromError(err)
+		if ok {
+			err = status.NewFromGRPCStatus(rpcStatus)
+		}
+		return statuses, multi.Fill(errs, 0, errors.Wrap(err, "NewAtomicBroadcastClient failed"))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		broadcastBatchStream(broadcastClient, statuses, errs)
+		close(done)
+	}()
+
+	for _, envelope := range envelopes {
+		err = broadcastClient.Send(&common.Envelope{
+			Payload:   envelope.Payload,
+			Signature: envelope.Signature,
+		})
+		if err != nil {
+			// The orderer still responds to the envelopes that were sent; the others are reported by the stream as not acknowledged
+			logger.Debugf("failed to send envelope to orderer [%s]", err)
+			break
+		}
+	}
+	if err = broadcastClient.CloseSend(); err != nil {
+		logger.Debugf("unable to close broadcast client [%s]", err)
+	}
+
+	<-done
+	return statuses, errs
+}
+
+// broadcastBatchStream receives a broadcast response for each of the envelopes sent on the stream
+func broadcastBatchStream(broadcastClient ab.AtomicBroadcast_BroadcastClient, statuses []*common.Status, errs []error) {
+	for i := range statuses {
+		broadcastResponse, err := broadcastClient.Recv()
+		if err != nil {
+			rpcStatus, ok := grpcstatus.FromError(err)
+			if ok {
+				err = status.NewFromGRPCStatus(rpcStatus)
+			}
+			multi.Fill(errs, i, errors.Wrap(err, "broadcast recv failed"))
+			return
+		}
+
+		if broadcastResponse.Status != common.Status_SUCCESS {
+			errs[i] = status.New(status.OrdererServerStatus, int32(broadcastResponse.Status), broadcastResponse.Info, nil)
+			continue
+		}
+
+		broadcastStatus := broadcastResponse.Status
+		statuses[i] = &broadcastStatus
+	}
+}
+
 // SendDeliver sends a deliver request to the ordering service and returns the
 // blocks requested
 // envelope: contains the seek request for blocks
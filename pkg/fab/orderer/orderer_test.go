@@ -342,6 +342,61 @@ func TestSendBroadcastError(t *testing.T) {
 	assert.Equal(t, status.GRPCTransportStatus, statusError.Group)
 }
 
+func TestSendBroadcastBatchHappy(t *testing.T) {
+
+	ordererConfig := getGRPCOpts(ordererAddr, true, false, true)
+	orderer, _ := New(mocks.NewMockConfig(), FromOrdererConfig(ordererConfig))
+
+	envelopes := []*fab.SignedEnvelope{{Payload: []byte("1")}, {Payload: []byte("2")}, {Payload: []byte("3")}}
+	statuses, errs := orderer.SendBroadcastBatch(reqContext.Background(), envelopes)
+	assert.Len(t, statuses, len(envelopes))
+	for i := range envelopes {
+		assert.Nil(t, errs[i])
+		assert.Equal(t, common.Status_SUCCESS, *statuses[i])
+	}
+}
+
+func TestSendBroadcastBatchServerBadResponse(t *testing.T) {
+
+	broadcastServer := mocks.MockBroadcastServer{
+		BroadcastInternalServerError: true,
+	}
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	addr := startCustomizedMockServer(t, testOrdererURL, grpcServer, &broadcastServer)
+	orderer, _ := New(mocks.NewMockConfig(), WithURL("grpc://"+addr), WithInsecure())
+
+	statuses, errs := orderer.SendBroadcastBatch(reqContext.Background(), []*fab.SignedEnvelope{{}, {}})
+	for i := range errs {
+		assert.Nil(t, statuses[i])
+		statusError, ok := status.FromError(errs[i])
+		assert.True(t, ok, "Expected status error")
+		assert.EqualValues(t, common.Status_INTERNAL_SERVER_ERROR, status.ToOrdererStatusCode(statusError.Code))
+		assert.Equal(t, status.OrdererServerStatus, statusError.Group)
+	}
+}
+
+func TestSendBroadcastBatchError(t *testing.T) {
+
+	broadcastServer := mocks.MockBroadcastServer{
+		BroadcastError: errors.New("just to test error scenario"),
+	}
+
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+	addr := startCustomizedMockServer(t, testOrdererURL, grpcServer, &broadcastServer)
+	orderer, _ := New(mocks.NewMockConfig(), WithURL("grpc://"+addr), WithInsecure())
+
+	statuses, errs := orderer.SendBroadcastBatch(reqContext.Background(), []*fab.SignedEnvelope{{}, {}})
+	for i := range errs {
+		assert.Nil(t, statuses[i])
+		statusError, ok := status.FromError(errs[i])
+		assert.True(t, ok, "Expected status error")
+		assert.Equal(t, status.GRPCTransportStatus, statusError.Group)
+	}
+}
+
 func TestBroadcastBadDial(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
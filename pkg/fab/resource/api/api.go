@@ -40,8 +40,10 @@ type InstallChaincodeRequest struct {
 	Path string
 	// chaincodeVersion: required - version of the chaincode
 	Version string
-	// required - package (chaincode package type and bytes)
+	// required - package (chaincode package type and bytes), unless a signed package is provided
 	Package *CCPackage
+	// optional - signed chaincode deployment package (CHAINCODE_PACKAGE envelope), installed instead of Package
+	SignedPackage *common.Envelope
 }
 
 // JoinChannelRequest allows a set of peers to transact on a channel on the network
@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
 	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -29,6 +30,8 @@ type ChaincodeInstallRequest struct {
 	Path    string
 	Version string
 	Package *ChaincodePackage
+	// SignedPackage is a signed chaincode deployment package which is installed instead of Package
+	SignedPackage *common.Envelope
 }
 
 // ChaincodePackage contains package type and bytes required to create CDS
@@ -50,10 +53,39 @@ func CreateChaincodeInstallProposal(txh fab.TransactionHeader, request Chaincode
 func createInstallInvokeRequest(request ChaincodeInstallRequest) (fab.ChaincodeInvokeRequest, error) {
 	// Generate arguments for install
 	args := [][]byte{}
+
+	var ccBytes []byte
+	var err error
+	if request.SignedPackage != nil {
+		ccBytes, err = protos_utils.Marshal(request.SignedPackage)
+		if err != nil {
+			return fab.ChaincodeInvokeRequest{}, errors.WithMessage(err, "marshal of signed chaincode package failed")
+		}
+	} else {
+		ccBytes, err = createInstallCDS(request)
+		if err != nil {
+			return fab.ChaincodeInvokeRequest{}, err
+		}
+	}
+	args = append(args, ccBytes)
+
+	cir := fab.ChaincodeInvokeRequest{
+		ChaincodeID: lscc,
+		Fcn:         lsccInstall,
+		Args:        args,
+	}
+	return cir, nil
+}
+
+func createInstallCDS(request ChaincodeInstallRequest) ([]byte, error) {
+	if request.Package == nil {
+		return nil, errors.New("chaincode package is required")
+	}
+
 	timestamp := time.Now()
 	ts, err := ptypes.TimestampProto(timestamp)
 	if err != nil {
-		return fab.ChaincodeInvokeRequest{}, errors.Wrap(err, "failed to create timestamp in install proposal")
+		return nil, errors.Wrap(err, "failed to create timestamp in install proposal")
 	}
 
 	ccds := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{
@@ -62,16 +94,9 @@ func createInstallInvokeRequest(request ChaincodeInstallRequest) (fab.ChaincodeI
 
 	ccdsBytes, err := protos_utils.Marshal(ccds)
 	if err != nil {
-		return fab.ChaincodeInvokeRequest{}, errors.WithMessage(err, "marshal of chaincode deployment spec failed")
-	}
-	args = append(args, ccdsBytes)
-
-	cir := fab.ChaincodeInvokeRequest{
-		ChaincodeID: lscc,
-		Fcn:         lsccInstall,
-		Args:        args,
+		return nil, errors.WithMessage(err, "marshal of chaincode deployment spec failed")
 	}
-	return cir, nil
+	return ccdsBytes, nil
 }
 
 func createInstalledChaincodesInvokeRequest() fab.ChaincodeInvokeRequest {
@@ -9,6 +9,7 @@ package resource
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	contextImpl "github.com/hyperledger/fabric-sdk-go/pkg/context"
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/txn"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/fab/mocks"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,3 +44,25 @@ func TestCreateChaincodeInstallProposal(t *testing.T) {
 	_, err = txn.SendProposal(reqCtx, prop, []fab.ProposalProcessor{&peer})
 	assert.Nil(t, err, "sending mock proposal failed")
 }
+
+func TestCreateSignedChaincodeInstallProposal(t *testing.T) {
+	signedPackage := &common.Envelope{Payload: []byte("signed package")}
+	request := ChaincodeInstallRequest{
+		Name:          "examplecc",
+		Path:          "github.com/examplecc",
+		Version:       "1",
+		SignedPackage: signedPackage,
+	}
+
+	cir, err := createInstallInvokeRequest(request)
+	assert.Nil(t, err, "createInstallInvokeRequest failed")
+	assert.Len(t, cir.Args, 1)
+
+	envelope := &common.Envelope{}
+	assert.Nil(t, proto.Unmarshal(cir.Args[0], envelope), "install argument should be the signed package")
+	assert.Equal(t, signedPackage.Payload, envelope.Payload)
+
+	request.SignedPackage = nil
+	_, err = createInstallInvokeRequest(request)
+	assert.NotNil(t, err, "expecting error for missing chaincode package")
+}
@@ -271,18 +271,21 @@ func InstallChaincode(reqCtx reqContext.Context, req api.InstallChaincodeRequest
 	if req.Version == "" {
 		return nil, fab.EmptyTransactionID, errors.New("chaincode version required")
 	}
-	if req.Package == nil {
+	if req.Package == nil && req.SignedPackage == nil {
 		return nil, fab.EmptyTransactionID, errors.New("chaincode package is required")
 	}
 
 	propReq := ChaincodeInstallRequest{
-		Name:    req.Name,
-		Path:    req.Path,
-		Version: req.Version,
-		Package: &ChaincodePackage{
+		Name:          req.Name,
+		Path:          req.Path,
+		Version:       req.Version,
+		SignedPackage: req.SignedPackage,
+	}
+	if req.Package != nil {
+		propReq.Package = &ChaincodePackage{
 			Type: req.Package.Type,
 			Code: req.Package.Code,
-		},
+		}
 	}
 
 	ctx, ok := contextImpl.RequestClientContext(reqCtx)
@@ -0,0 +1,205 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwset
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// DifferenceType is the kind of read-write set entry that differs
+type DifferenceType string
+
+const (
+	// NamespaceDifference is reported when a namespace was accessed by only one of the endorsers
+	NamespaceDifference DifferenceType = "namespace"
+	// ReadDifference is reported when a key was read at different versions, or read by only one of the endorsers
+	ReadDifference DifferenceType = "read"
+	// WriteDifference is reported when different values were written to a key, or a key was written by only one of the endorsers
+	WriteDifference DifferenceType = "write"
+	// RangeQueryDifference is reported when a range query returned different results, or was performed by only one of the endorsers
+	RangeQueryDifference DifferenceType = "range_query"
+	// CollectionDifference is reported when the private read-write set hashes of a collection do not match
+	CollectionDifference DifferenceType = "collection"
+	// HashedReadDifference is reported when a private key was read at different versions, or read by only one of the endorsers
+	HashedReadDifference DifferenceType = "hashed_read"
+	// HashedWriteDifference is reported when different values were written to a private key, or a private key was written by only one of the endorsers
+	HashedWriteDifference DifferenceType = "hashed_write"
+)
+
+// Difference describes an entry of the read-write sets simulated by two endorsers that does not match.
+// Left and Right hold the entry of the respective read-write set and either is nil if the entry is missing.
+// Key is the key of the entry: the key for reads and writes, the hex encoded key hash for hashed reads and
+// writes, and the range for range queries.
+type Difference struct {
+	Type       DifferenceType
+	Namespace  string
+	Collection string
+	Key        string
+	Left       interface{}
+	Right      interface{}
+}
+
+// String returns a description of the difference
+func (d *Difference) String() string {
+	location := d.Namespace
+	if d.Collection != "" {
+		location += "/" + d.Collection
+	}
+	if d.Key != "" {
+		location += " [" + d.Key + "]"
+	}
+
+	switch {
+	case isNil(d.Left):
+		return fmt.Sprintf("%s %s: only in right", d.Type, location)
+	case isNil(d.Right):
+		return fmt.Sprintf("%s %s: only in left", d.Type, location)
+	default:
+		return fmt.Sprintf("%s %s: mismatch", d.Type, location)
+	}
+}
+
+// Diff compares two read-write sets and returns their differences, ordered by namespace,
+// with the entries of the left read-write set first. Nil is returned if the read-write sets match.
+func Diff(left, right *TxRWSet) []*Difference {
+	if left == nil {
+		left = &TxRWSet{}
+	}
+	if right == nil {
+		right = &TxRWSet{}
+	}
+
+	var diffs []*Difference
+	for _, l := range left.NsRWSets {
+		r := right.Namespace(l.Namespace)
+		if r == nil {
+			diffs = append(diffs, &Difference{Type: NamespaceDifference, Namespace: l.Namespace, Left: l})
+			continue
+		}
+		diffs = append(diffs, diffNamespace(l, r)...)
+	}
+	for _, r := range right.NsRWSets {
+		if left.Namespace(r.Namespace) == nil {
+			diffs = append(diffs, &Difference{Type: NamespaceDifference, Namespace: r.Namespace, Right: r})
+		}
+	}
+	return diffs
+}
+
+func diffNamespace(left, right *NsRWSet) []*Difference {
+	var diffs []*Difference
+
+	diffs = append(diffs, diffEntries(ReadDifference, left.Namespace, "", readEntries(left.Reads), readEntries(right.Reads))...)
+	diffs = append(diffs, diffEntries(WriteDifference, left.Namespace, "", writeEntries(left.Writes), writeEntries(right.Writes))...)
+	diffs = append(diffs, diffEntries(RangeQueryDifference, left.Namespace, "", rangeQueryEntries(left.RangeQueries), rangeQueryEntries(right.RangeQueries))...)
+
+	for _, l := range left.CollHashedRWSets {
+		r := right.Collection(l.CollectionName)
+		if r == nil {
+			diffs = append(diffs, &Difference{Type: CollectionDifference, Namespace: left.Namespace, Collection: l.CollectionName, Left: l})
+			continue
+		}
+		diffs = append(diffs, diffCollection(left.Namespace, l, r)...)
+	}
+	for _, r := range right.CollHashedRWSets {
+		if left.Collection(r.CollectionName) == nil {
+			diffs = append(diffs, &Difference{Type: CollectionDifference, Namespace: left.Namespace, Collection: r.CollectionName, Right: r})
+		}
+	}
+
+	return diffs
+}
+
+func diffCollection(namespace string, left, right *CollHashedRWSet) []*Difference {
+	var diffs []*Difference
+	if !bytes.Equal(left.PvtRWSetHash, right.PvtRWSetHash) {
+		diffs = append(diffs, &Difference{Type: CollectionDifference, Namespace: namespace, Collection: left.CollectionName, Left: left, Right: right})
+	}
+	diffs = append(diffs, diffEntries(HashedReadDifference, namespace, left.CollectionName, hashedReadEntries(left.HashedReads), hashedReadEntries(right.HashedReads))...)
+	diffs = append(diffs, diffEntries(HashedWriteDifference, namespace, left.CollectionName, hashedWriteEntries(left.HashedWrites), hashedWriteEntries(right.HashedWrites))...)
+	return diffs
+}
+
+// entry is a keyed read-write set entry
+type entry struct {
+	key   string
+	value interface{}
+}
+
+func diffEntries(diffType DifferenceType, namespace, collection string, left, right []entry) []*Difference {
+	rightByKey := make(map[string]interface{})
+	for _, e := range right {
+		rightByKey[e.key] = e.value
+	}
+	leftByKey := make(map[string]interface{})
+
+	var diffs []*Difference
+	for _, l := range left {
+		leftByKey[l.key] = l.value
+		r, ok := rightByKey[l.key]
+		if !ok || !reflect.DeepEqual(l.value, r) {
+			diffs = append(diffs, &Difference{Type: diffType, Namespace: namespace, Collection: collection, Key: l.key, Left: l.value, Right: r})
+		}
+	}
+	for _, r := range right {
+		if _, ok := leftByKey[r.key]; !ok {
+			diffs = append(diffs, &Difference{Type: diffType, Namespace: namespace, Collection: collection, Key: r.key, Right: r.value})
+		}
+	}
+	return diffs
+}
+
+func readEntries(reads []*KVRead) []entry {
+	var entries []entry
+	for _, r := range reads {
+		entries = append(entries, entry{key: r.Key, value: r})
+	}
+	return entries
+}
+
+func writeEntries(writes []*KVWrite) []entry {
+	var entries []entry
+	for _, w := range writes {
+		entries = append(entries, entry{key: w.Key, value: w})
+	}
+	return entries
+}
+
+func rangeQueryEntries(queries []*RangeQuery) []entry {
+	var entries []entry
+	for _, q := range queries {
+		entries = append(entries, entry{key: fmt.Sprintf("%s..%s", q.StartKey, q.EndKey), value: q})
+	}
+	return entries
+}
+
+func hashedReadEntries(reads []*KVReadHash) []entry {
+	var entries []entry
+	for _, r := range reads {
+		entries = append(entries, entry{key: hex.EncodeToString(r.KeyHash), value: r})
+	}
+	return entries
+}
+
+func hashedWriteEntries(writes []*KVWriteHash) []entry {
+	var entries []entry
+	for _, w := range writes {
+		entries = append(entries, entry{key: hex.EncodeToString(w.KeyHash), value: w})
+	}
+	return entries
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
@@ -0,0 +1,236 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rwset decodes the read-write sets produced by chaincode simulation into typed structures
+// and compares the read-write sets of different endorsers.
+package rwset
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+	protos_utils "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/utils"
+)
+
+// TxRWSet contains the read-write set of a transaction, per namespace (chaincode)
+type TxRWSet struct {
+	NsRWSets []*NsRWSet `json:"ns_rwsets"`
+}
+
+// NsRWSet contains the reads, writes and range queries of a namespace along with
+// the hashes of the private data read and written in each collection
+type NsRWSet struct {
+	Namespace        string             `json:"namespace"`
+	Reads            []*KVRead          `json:"reads,omitempty"`
+	Writes           []*KVWrite         `json:"writes,omitempty"`
+	RangeQueries     []*RangeQuery      `json:"range_queries,omitempty"`
+	CollHashedRWSets []*CollHashedRWSet `json:"collection_hashed_rwsets,omitempty"`
+}
+
+// Version is the version of a key, i.e. the block and transaction that last wrote it
+type Version struct {
+	BlockNum uint64 `json:"block_num"`
+	TxNum    uint64 `json:"tx_num"`
+}
+
+// KVRead is a key read during simulation along with the version that was read.
+// Version is nil if the key did not exist.
+type KVRead struct {
+	Key     string   `json:"key"`
+	Version *Version `json:"version,omitempty"`
+}
+
+// KVWrite is a key written, or deleted, during simulation
+type KVWrite struct {
+	Key      string `json:"key"`
+	IsDelete bool   `json:"is_delete,omitempty"`
+	Value    []byte `json:"value,omitempty"`
+}
+
+// RangeQuery is a range query performed during simulation. The keys read are either available
+// as raw reads or summarized as merkle hashes, depending on the number of keys read.
+type RangeQuery struct {
+	StartKey          string         `json:"start_key"`
+	EndKey            string         `json:"end_key"`
+	ItrExhausted      bool           `json:"itr_exhausted"`
+	Reads             []*KVRead      `json:"reads,omitempty"`
+	ReadsMerkleHashes *MerkleSummary `json:"reads_merkle_hashes,omitempty"`
+}
+
+// MerkleSummary summarizes the reads of a range query
+type MerkleSummary struct {
+	MaxDegree      uint32   `json:"max_degree"`
+	MaxLevel       uint32   `json:"max_level"`
+	MaxLevelHashes [][]byte `json:"max_level_hashes"`
+}
+
+// CollHashedRWSet contains the hashes of the private data read and written in a collection
+type CollHashedRWSet struct {
+	CollectionName string         `json:"collection_name"`
+	PvtRWSetHash   []byte         `json:"pvt_rwset_hash,omitempty"`
+	HashedReads    []*KVReadHash  `json:"hashed_reads,omitempty"`
+	HashedWrites   []*KVWriteHash `json:"hashed_writes,omitempty"`
+}
+
+// KVReadHash is the hash of a private key read during simulation along with the version that was read
+type KVReadHash struct {
+	KeyHash []byte   `json:"key_hash"`
+	Version *Version `json:"version,omitempty"`
+}
+
+// KVWriteHash is the hash of a private key, and of its value, written during simulation
+type KVWriteHash struct {
+	KeyHash   []byte `json:"key_hash"`
+	IsDelete  bool   `json:"is_delete,omitempty"`
+	ValueHash []byte `json:"value_hash,omitempty"`
+}
+
+// EndorserRWSet is the read-write set simulated by an endorser
+type EndorserRWSet struct {
+	Endorser string
+	RWSet    *TxRWSet
+}
+
+// Unmarshal decodes the results of a chaincode action, i.e. a serialized TxReadWriteSet
+func Unmarshal(results []byte) (*TxRWSet, error) {
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(results); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of read-write set failed")
+	}
+
+	rwSet := &TxRWSet{}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		rwSet.NsRWSets = append(rwSet.NsRWSets, newNsRWSet(nsRWSet))
+	}
+	return rwSet, nil
+}
+
+// FromChaincodeAction decodes the read-write set of the given chaincode action
+func FromChaincodeAction(action *pb.ChaincodeAction) (*TxRWSet, error) {
+	if action == nil {
+		return nil, errors.New("chaincode action is required")
+	}
+	return Unmarshal(action.Results)
+}
+
+// FromProposalResponse decodes the read-write set simulated by the endorser of the given proposal response
+func FromProposalResponse(response *pb.ProposalResponse) (*TxRWSet, error) {
+	if response == nil {
+		return nil, errors.New("proposal response is required")
+	}
+
+	payload := &pb.ProposalResponsePayload{}
+	if err := proto.Unmarshal(response.Payload, payload); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of proposal response payload failed")
+	}
+
+	action, err := protos_utils.GetChaincodeAction(payload.Extension)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal of chaincode action failed")
+	}
+
+	return FromChaincodeAction(action)
+}
+
+// FromProposalResponses decodes the read-write set simulated by each endorser
+func FromProposalResponses(responses []*fab.TransactionProposalResponse) ([]*EndorserRWSet, error) {
+	var rwSets []*EndorserRWSet
+	for _, r := range responses {
+		rwSet, err := FromProposalResponse(r.ProposalResponse)
+		if err != nil {
+			return nil, errors.WithMessage(err, "decoding read-write set of "+r.Endorser+" failed")
+		}
+		rwSets = append(rwSets, &EndorserRWSet{Endorser: r.Endorser, RWSet: rwSet})
+	}
+	return rwSets, nil
+}
+
+// Namespace returns the read-write set of the given namespace or nil if the namespace was not accessed
+func (s *TxRWSet) Namespace(namespace string) *NsRWSet {
+	for _, nsRWSet := range s.NsRWSets {
+		if nsRWSet.Namespace == namespace {
+			return nsRWSet
+		}
+	}
+	return nil
+}
+
+// Collection returns the hashed read-write set of the given collection or nil if the collection was not accessed
+func (s *NsRWSet) Collection(collection string) *CollHashedRWSet {
+	for _, collRWSet := range s.CollHashedRWSets {
+		if collRWSet.CollectionName == collection {
+			return collRWSet
+		}
+	}
+	return nil
+}
+
+func newNsRWSet(nsRWSet *rwsetutil.NsRwSet) *NsRWSet {
+	s := &NsRWSet{Namespace: nsRWSet.NameSpace}
+
+	kvRWSet := nsRWSet.KvRwSet
+	if kvRWSet != nil {
+		s.Reads = newKVReads(kvRWSet.Reads)
+		for _, w := range kvRWSet.Writes {
+			s.Writes = append(s.Writes, &KVWrite{Key: w.Key, IsDelete: w.IsDelete, Value: w.Value})
+		}
+		for _, rq := range kvRWSet.RangeQueriesInfo {
+			s.RangeQueries = append(s.RangeQueries, newRangeQuery(rq))
+		}
+	}
+
+	for _, collRWSet := range nsRWSet.CollHashedRwSets {
+		s.CollHashedRWSets = append(s.CollHashedRWSets, newCollHashedRWSet(collRWSet))
+	}
+	return s
+}
+
+func newKVReads(reads []*kvrwset.KVRead) []*KVRead {
+	var kvReads []*KVRead
+	for _, r := range reads {
+		kvReads = append(kvReads, &KVRead{Key: r.Key, Version: newVersion(r.Version)})
+	}
+	return kvReads
+}
+
+func newRangeQuery(rq *kvrwset.RangeQueryInfo) *RangeQuery {
+	q := &RangeQuery{StartKey: rq.StartKey, EndKey: rq.EndKey, ItrExhausted: rq.ItrExhausted}
+	if rawReads := rq.GetRawReads(); rawReads != nil {
+		q.Reads = newKVReads(rawReads.KvReads)
+	}
+	if summary := rq.GetReadsMerkleHashes(); summary != nil {
+		q.ReadsMerkleHashes = &MerkleSummary{
+			MaxDegree:      summary.MaxDegree,
+			MaxLevel:       summary.MaxLevel,
+			MaxLevelHashes: summary.MaxLevelHashes,
+		}
+	}
+	return q
+}
+
+func newCollHashedRWSet(collRWSet *rwsetutil.CollHashedRwSet) *CollHashedRWSet {
+	s := &CollHashedRWSet{CollectionName: collRWSet.CollectionName, PvtRWSetHash: collRWSet.PvtRwSetHash}
+	if collRWSet.HashedRwSet != nil {
+		for _, r := range collRWSet.HashedRwSet.HashedReads {
+			s.HashedReads = append(s.HashedReads, &KVReadHash{KeyHash: r.KeyHash, Version: newVersion(r.Version)})
+		}
+		for _, w := range collRWSet.HashedRwSet.HashedWrites {
+			s.HashedWrites = append(s.HashedWrites, &KVWriteHash{KeyHash: w.KeyHash, IsDelete: w.IsDelete, ValueHash: w.ValueHash})
+		}
+	}
+	return s
+}
+
+func newVersion(v *kvrwset.Version) *Version {
+	if v == nil {
+		return nil
+	}
+	return &Version{BlockNum: v.BlockNum, TxNum: v.TxNum}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestFromProposalResponse(t *testing.T) {
+	response := newProposalResponse(t, newTestRWSet("value2"))
+
+	rwSet, err := FromProposalResponse(response)
+	assert.Nil(t, err, "FromProposalResponse failed")
+	assert.Equal(t, 1, len(rwSet.NsRWSets))
+
+	ns := rwSet.Namespace("ns1")
+	if !assert.NotNil(t, ns, "expected namespace ns1") {
+		return
+	}
+	assert.Nil(t, rwSet.Namespace("ns2"))
+
+	assert.Equal(t, []*KVRead{{Key: "key1", Version: &Version{BlockNum: 1, TxNum: 2}}, {Key: "key3"}}, ns.Reads)
+	assert.Equal(t, []*KVWrite{{Key: "key2", Value: []byte("value2")}, {Key: "key4", IsDelete: true}}, ns.Writes)
+
+	assert.Equal(t, 2, len(ns.RangeQueries))
+	assert.Equal(t, "a", ns.RangeQueries[0].StartKey)
+	assert.Equal(t, "c", ns.RangeQueries[0].EndKey)
+	assert.True(t, ns.RangeQueries[0].ItrExhausted)
+	assert.Equal(t, []*KVRead{{Key: "b", Version: &Version{BlockNum: 3}}}, ns.RangeQueries[0].Reads)
+	assert.Equal(t, &MerkleSummary{MaxDegree: 50, MaxLevel: 1, MaxLevelHashes: [][]byte{[]byte("hash")}}, ns.RangeQueries[1].ReadsMerkleHashes)
+
+	coll := ns.Collection("coll1")
+	if !assert.NotNil(t, coll, "expected collection coll1") {
+		return
+	}
+	assert.Nil(t, ns.Collection("coll2"))
+	assert.Equal(t, []byte("pvthash"), coll.PvtRWSetHash)
+	assert.Equal(t, []*KVReadHash{{KeyHash: []byte("k1"), Version: &Version{BlockNum: 4}}}, coll.HashedReads)
+	assert.Equal(t, []*KVWriteHash{{KeyHash: []byte("k2"), ValueHash: []byte("v2")}}, coll.HashedWrites)
+
+	_, err = FromProposalResponse(&pb.ProposalResponse{Payload: []byte("invalid")})
+	assert.NotNil(t, err, "expected error for invalid payload")
+
+	_, err = FromProposalResponse(nil)
+	assert.NotNil(t, err, "expected error for nil response")
+}
+
+func TestFromProposalResponses(t *testing.T) {
+	responses := []*fab.TransactionProposalResponse{
+		{Endorser: "peer1", ProposalResponse: newProposalResponse(t, newTestRWSet("value2"))},
+		{Endorser: "peer2", ProposalResponse: newProposalResponse(t, newTestRWSet("other"))},
+	}
+
+	rwSets, err := FromProposalResponses(responses)
+	assert.Nil(t, err, "FromProposalResponses failed")
+	assert.Equal(t, 2, len(rwSets))
+	assert.Equal(t, "peer1", rwSets[0].Endorser)
+	assert.Equal(t, "peer2", rwSets[1].Endorser)
+
+	responses = append(responses, &fab.TransactionProposalResponse{Endorser: "peer3", ProposalResponse: &pb.ProposalResponse{Payload: []byte("invalid")}})
+	_, err = FromProposalResponses(responses)
+	assert.NotNil(t, err, "expected error for invalid payload")
+	assert.True(t, strings.Contains(err.Error(), "peer3"), "expected endorser in error")
+}
+
+func TestDiff(t *testing.T) {
+	left := decodeTestRWSet(t, newTestRWSet("value2"))
+
+	assert.Nil(t, Diff(left, decodeTestRWSet(t, newTestRWSet("value2"))), "expected no differences")
+
+	right := decodeTestRWSet(t, newTestRWSet("other"))
+	right.NsRWSets[0].Reads[0].Version.TxNum = 3
+	right.NsRWSets[0].Reads = right.NsRWSets[0].Reads[:1]
+	right.NsRWSets[0].CollHashedRWSets[0].PvtRWSetHash = []byte("otherhash")
+	right.NsRWSets[0].CollHashedRWSets[0].HashedWrites[0].ValueHash = []byte("other")
+	right.NsRWSets = append(right.NsRWSets, &NsRWSet{Namespace: "ns2"})
+
+	diffs := Diff(left, right)
+	if !assert.Equal(t, 6, len(diffs), "unexpected differences %v", diffs) {
+		return
+	}
+
+	assertDifference(t, diffs[0], ReadDifference, "ns1", "", "key1")
+	assertDifference(t, diffs[1], ReadDifference, "ns1", "", "key3")
+	assert.Nil(t, diffs[1].Right, "key3 is only read by the left endorser")
+	assertDifference(t, diffs[2], WriteDifference, "ns1", "", "key2")
+	assert.Equal(t, []byte("value2"), diffs[2].Left.(*KVWrite).Value)
+	assert.Equal(t, []byte("other"), diffs[2].Right.(*KVWrite).Value)
+	assertDifference(t, diffs[3], CollectionDifference, "ns1", "coll1", "")
+	assertDifference(t, diffs[4], HashedWriteDifference, "ns1", "coll1", "6b32")
+	assertDifference(t, diffs[5], NamespaceDifference, "ns2", "", "")
+	assert.Nil(t, diffs[5].Left, "ns2 is only accessed by the right endorser")
+
+	assert.Equal(t, "read ns1 [key3]: only in left", diffs[1].String())
+	assert.Equal(t, "hashed_write ns1/coll1 [6b32]: mismatch", diffs[4].String())
+	assert.Equal(t, "namespace ns2: only in right", diffs[5].String())
+}
+
+func assertDifference(t *testing.T, d *Difference, diffType DifferenceType, namespace, collection, key string) {
+	assert.Equal(t, diffType, d.Type)
+	assert.Equal(t, namespace, d.Namespace)
+	assert.Equal(t, collection, d.Collection)
+	assert.Equal(t, key, d.Key)
+}
+
+func decodeTestRWSet(t *testing.T, txRWSet *rwsetutil.TxRwSet) *TxRWSet {
+	rwSet, err := FromProposalResponse(newProposalResponse(t, txRWSet))
+	assert.Nil(t, err, "FromProposalResponse failed")
+	return rwSet
+}
+
+func newTestRWSet(value string) *rwsetutil.TxRwSet {
+	return &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "ns1",
+				KvRwSet: &kvrwset.KVRWSet{
+					Reads: []*kvrwset.KVRead{
+						{Key: "key1", Version: &kvrwset.Version{BlockNum: 1, TxNum: 2}},
+						{Key: "key3"},
+					},
+					Writes: []*kvrwset.KVWrite{
+						{Key: "key2", Value: []byte(value)},
+						{Key: "key4", IsDelete: true},
+					},
+					RangeQueriesInfo: []*kvrwset.RangeQueryInfo{
+						{StartKey: "a", EndKey: "c", ItrExhausted: true,
+							ReadsInfo: &kvrwset.RangeQueryInfo_RawReads{RawReads: &kvrwset.QueryReads{
+								KvReads: []*kvrwset.KVRead{{Key: "b", Version: &kvrwset.Version{BlockNum: 3}}}}}},
+						{StartKey: "d", EndKey: "z",
+							ReadsInfo: &kvrwset.RangeQueryInfo_ReadsMerkleHashes{ReadsMerkleHashes: &kvrwset.QueryReadsMerkleSummary{
+								MaxDegree: 50, MaxLevel: 1, MaxLevelHashes: [][]byte{[]byte("hash")}}}},
+					},
+				},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{
+					{
+						CollectionName: "coll1",
+						PvtRwSetHash:   []byte("pvthash"),
+						HashedRwSet: &kvrwset.HashedRWSet{
+							HashedReads:  []*kvrwset.KVReadHash{{KeyHash: []byte("k1"), Version: &kvrwset.Version{BlockNum: 4}}},
+							HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("k2"), ValueHash: []byte("v2")}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newProposalResponse(t *testing.T, txRWSet *rwsetutil.TxRwSet) *pb.ProposalResponse {
+	results, err := txRWSet.ToProtoBytes()
+	assert.Nil(t, err)
+
+	ccActionBytes, err := proto.Marshal(&pb.ChaincodeAction{Results: results})
+	assert.Nil(t, err)
+
+	payload, err := proto.Marshal(&pb.ProposalResponsePayload{Extension: ccActionBytes})
+	assert.Nil(t, err)
+
+	return &pb.ProposalResponse{Payload: payload, Response: &pb.Response{Status: 200}}
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric/common/crypto"
 	contextApi "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/context"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/cryptosuite"
 	"github.com/hyperledger/fabric-sdk-go/third_party/github.com/hyperledger/fabric/protos/common"
@@ -54,19 +55,34 @@ func (th *TransactionHeader) ChannelID() string {
 // NewHeader computes a TransactionID from the current user context and holds
 // metadata to create transaction proposals.
 func NewHeader(ctx contextApi.Client, channelID string) (*TransactionHeader, error) {
-	// generate a random nonce
-	nonce, err := crypto.GetRandomNonce()
-	if err != nil {
-		return nil, errors.WithMessage(err, "nonce creation failed")
-	}
-
 	creator, err := ctx.Serialize()
 	if err != nil {
 		return nil, errors.WithMessage(err, "identity from context failed")
 	}
 
+	return NewHeaderFromCreator(ctx.CryptoSuite(), channelID, creator, nil)
+}
+
+// NewHeaderFromCreator computes a TransactionID from the given serialized creator identity and nonce
+// and holds metadata to create transaction proposals. It allows a header to be created, or rebuilt,
+// for an identity whose signing key is not available to the SDK. A random nonce is generated if
+// none is provided.
+func NewHeaderFromCreator(cs core.CryptoSuite, channelID string, creator, nonce []byte) (*TransactionHeader, error) {
+	if len(creator) == 0 {
+		return nil, errors.New("creator is required")
+	}
+
+	if len(nonce) == 0 {
+		// generate a random nonce
+		var err error
+		nonce, err = crypto.GetRandomNonce()
+		if err != nil {
+			return nil, errors.WithMessage(err, "nonce creation failed")
+		}
+	}
+
 	ho := cryptosuite.GetSHA256Opts() // TODO: make configurable
-	h, err := ctx.CryptoSuite().GetHash(ho)
+	h, err := cs.GetHash(ho)
 	if err != nil {
 		return nil, errors.WithMessage(err, "hash function creation failed")
 	}
@@ -77,6 +77,20 @@ func signProposal(ctx contextApi.Client, proposal *pb.Proposal) (*pb.SignedPropo
 	return &pb.SignedProposal{ProposalBytes: proposalBytes, Signature: signature}, nil
 }
 
+// ProposalBytes returns the marshaled proposal, i.e. the bytes that have to be signed by the proposal creator.
+// It is used, along with SendSignedProposal, when the creator's signing key is not available to the SDK.
+func ProposalBytes(proposal *fab.TransactionProposal) ([]byte, error) {
+	if proposal == nil || proposal.Proposal == nil {
+		return nil, errors.New("proposal is required")
+	}
+
+	proposalBytes, err := proto.Marshal(proposal.Proposal)
+	if err != nil {
+		return nil, errors.Wrap(err, "mashal proposal failed")
+	}
+	return proposalBytes, nil
+}
+
 // SendProposal sends a TransactionProposal to ProposalProcessor.
 func SendProposal(reqCtx reqContext.Context, proposal *fab.TransactionProposal, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, error) {
 
@@ -97,6 +111,29 @@ func SendProposal(reqCtx reqContext.Context, proposal *fab.TransactionProposal,
 		return nil, errors.WithMessage(err, "sign proposal failed")
 	}
 
+	return sendSignedProposal(reqCtx, signedProposal, targets)
+}
+
+// SendSignedProposal sends a proposal that was signed outside of the SDK to ProposalProcessor.
+// The proposal bytes must be the ones returned by ProposalBytes.
+func SendSignedProposal(reqCtx reqContext.Context, signedProposal *pb.SignedProposal, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, error) {
+
+	if signedProposal == nil || len(signedProposal.ProposalBytes) == 0 {
+		return nil, errors.New("signed proposal is required")
+	}
+
+	if len(signedProposal.Signature) == 0 {
+		return nil, errors.New("signature is required")
+	}
+
+	if len(targets) < 1 {
+		return nil, errors.New("targets is required")
+	}
+
+	return sendSignedProposal(reqCtx, signedProposal, targets)
+}
+
+func sendSignedProposal(reqCtx reqContext.Context, signedProposal *pb.SignedProposal, targets []fab.ProposalProcessor) ([]*fab.TransactionProposalResponse, error) {
 	request := fab.ProcessProposalRequest{SignedProposal: signedProposal}
 
 	var responseMtx sync.Mutex
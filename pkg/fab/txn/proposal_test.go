@@ -100,6 +100,49 @@ func TestSendTransactionProposal(t *testing.T) {
 	}
 }
 
+func TestSendSignedProposal(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "1234")
+	ctx := mocks.NewMockContext(user)
+
+	peer := mocks.MockPeer{MockName: "Peer1", MockURL: "http://peer1.com",
+		MockRoles: []string{}, MockCert: nil, Status: 200, Payload: []byte("A")}
+
+	creator, err := ctx.Serialize()
+	assert.Nil(t, err, "serialize failed")
+
+	nonce := []byte("nonce")
+	txh, err := NewHeaderFromCreator(ctx.CryptoSuite(), testChannel, creator, nonce)
+	assert.Nil(t, err, "create transaction header failed")
+	assert.Equal(t, nonce, txh.Nonce())
+
+	// the same creator and nonce always produce the same transaction ID
+	txh2, err := NewHeaderFromCreator(ctx.CryptoSuite(), testChannel, creator, nonce)
+	assert.Nil(t, err, "create transaction header failed")
+	assert.Equal(t, txh.TransactionID(), txh2.TransactionID())
+
+	_, err = NewHeaderFromCreator(ctx.CryptoSuite(), testChannel, nil, nonce)
+	assert.NotNil(t, err, "expected error for missing creator")
+
+	tp, err := CreateChaincodeInvokeProposal(txh, fab.ChaincodeInvokeRequest{ChaincodeID: "cc", Fcn: "Hello"})
+	assert.Nil(t, err, "new transaction proposal failed")
+
+	proposalBytes, err := ProposalBytes(tp)
+	assert.Nil(t, err, "proposal bytes failed")
+
+	signature, err := ctx.SigningManager().Sign(proposalBytes, ctx.PrivateKey())
+	assert.Nil(t, err, "sign failed")
+
+	reqCtx, cancel := context.NewRequest(ctx, context.WithTimeout(10*time.Second))
+	defer cancel()
+
+	_, err = SendSignedProposal(reqCtx, &pb.SignedProposal{ProposalBytes: proposalBytes}, []fab.ProposalProcessor{&peer})
+	assert.NotNil(t, err, "expected error for missing signature")
+
+	tpr, err := SendSignedProposal(reqCtx, &pb.SignedProposal{ProposalBytes: proposalBytes, Signature: signature}, []fab.ProposalProcessor{&peer})
+	assert.Nil(t, err, "send signed proposal failed")
+	assert.Equal(t, []byte("A"), tpr[0].ProposalResponse.GetResponse().Payload)
+}
+
 func TestNewTransactionProposalParams(t *testing.T) {
 	user := mspmocks.NewMockSigningIdentity("test", "1234")
 	ctx := mocks.NewMockContext(user)
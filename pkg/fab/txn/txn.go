@@ -11,8 +11,10 @@ import (
 	reqContext "context"
 	"math/rand"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/multi"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/logging"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/fab"
 	"github.com/hyperledger/fabric-sdk-go/pkg/context"
@@ -103,6 +105,101 @@ func Send(reqCtx reqContext.Context, tx *fab.Transaction, orderers []fab.Orderer
 	if orderers == nil || len(orderers) == 0 {
 		return nil, errors.New("orderers is nil")
 	}
+
+	payload, err := createTransactionPayload(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionResponse, err := BroadcastPayload(reqCtx, payload, orderers)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactionResponse, nil
+}
+
+// SendBatch sends several transactions to the chain’s orderer service. Orderers that support it receive the
+// transactions over a single broadcast stream. Transactions that an orderer fails to accept are retried on the
+// other orderers, picked in a random order, until all are exhausted. The response, or the error, of each
+// transaction is returned in the order the transactions were given.
+func SendBatch(reqCtx reqContext.Context, txs []*fab.Transaction, orderers []fab.Orderer) ([]*fab.TransactionResponse, []error) {
+	responses := make([]*fab.TransactionResponse, len(txs))
+	errs := make([]error, len(txs))
+
+	if len(orderers) == 0 {
+		return responses, multi.Fill(errs, 0, errors.New("orderers is nil"))
+	}
+
+	ctx, ok := context.RequestClientContext(reqCtx)
+	if !ok {
+		return responses, multi.Fill(errs, 0, errors.New("failed get client context from reqContext for SendBatch"))
+	}
+
+	envelopes := make([]*fab.SignedEnvelope, len(txs))
+	var pending []int
+	for i, tx := range txs {
+		payload, err := createTransactionPayload(tx)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		envelopes[i], err = signPayload(ctx, payload)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	// Iterate the orderers in a random order and broadcast the pending envelopes until all are accepted
+	for _, o := range rand.Perm(len(orderers)) {
+		if len(pending) == 0 {
+			break
+		}
+		pending = broadcastEnvelopeBatch(reqCtx, envelopes, pending, orderers[o], responses, errs)
+	}
+
+	return responses, errs
+}
+
+// broadcastEnvelopeBatch sends the pending envelopes to the given orderer, recording the response or error of
+// each one, and returns the envelopes that were not accepted
+func broadcastEnvelopeBatch(reqCtx reqContext.Context, envelopes []*fab.SignedEnvelope, pending []int, orderer fab.Orderer, responses []*fab.TransactionResponse, errs []error) []int {
+	var failed []int
+
+	batchBroadcaster, ok := orderer.(fab.BatchBroadcaster)
+	if !ok {
+		for _, i := range pending {
+			responses[i], errs[i] = sendBroadcast(reqCtx, envelopes[i], orderer)
+			if errs[i] != nil {
+				failed = append(failed, i)
+			}
+		}
+		return failed
+	}
+
+	batch := make([]*fab.SignedEnvelope, len(pending))
+	for n, i := range pending {
+		batch[n] = envelopes[i]
+	}
+
+	logger.Debugf("Broadcasting %d envelopes to orderer :%s\n", len(batch), orderer.URL())
+	_, batchErrs := batchBroadcaster.SendBroadcastBatch(reqCtx, batch)
+	for n, i := range pending {
+		if batchErrs[n] != nil {
+			logger.Debugf("Receive Error Response from orderer :%v\n", batchErrs[n])
+			errs[i] = errors.Wrapf(batchErrs[n], "calling orderer '%s' failed", orderer.URL())
+			failed = append(failed, i)
+			continue
+		}
+		responses[i], errs[i] = &fab.TransactionResponse{Orderer: orderer.URL()}, nil
+	}
+	return failed
+}
+
+// createTransactionPayload creates the payload to be signed and sent to the orderer for the given transaction
+func createTransactionPayload(tx *fab.Transaction) (*common.Payload, error) {
 	if tx == nil {
 		return nil, errors.New("transaction is nil")
 	}
@@ -122,14 +219,36 @@ func Send(reqCtx reqContext.Context, tx *fab.Transaction, orderers []fab.Orderer
 	}
 
 	// create the payload
-	payload := common.Payload{Header: hdr, Data: txBytes}
+	return &common.Payload{Header: hdr, Data: txBytes}, nil
+}
 
-	transactionResponse, err := BroadcastPayload(reqCtx, &payload, orderers)
+// TransactionPayloadBytes returns the marshaled payload of the envelope for the given transaction, i.e. the bytes
+// that have to be signed by the transaction creator. It is used, along with SendSignedEnvelope, when the
+// creator's signing key is not available to the SDK.
+func TransactionPayloadBytes(tx *fab.Transaction) ([]byte, error) {
+	payload, err := createTransactionPayload(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	return transactionResponse, nil
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "marshaling of payload failed")
+	}
+	return payloadBytes, nil
+}
+
+// SendSignedEnvelope sends a transaction envelope that was signed outside of the SDK to some orderer, picking
+// random endpoints until all are exhausted. The envelope payload must be the one returned by TransactionPayloadBytes.
+func SendSignedEnvelope(reqCtx reqContext.Context, envelope *fab.SignedEnvelope, orderers []fab.Orderer) (*fab.TransactionResponse, error) {
+	if envelope == nil || len(envelope.Payload) == 0 {
+		return nil, errors.New("envelope payload is required")
+	}
+	if len(envelope.Signature) == 0 {
+		return nil, errors.New("signature is required")
+	}
+
+	return broadcastEnvelope(reqCtx, envelope, orderers)
 }
 
 // BroadcastPayload will send the given payload to some orderer, picking random endpoints
@@ -291,6 +291,48 @@ func TestSendTransaction(t *testing.T) {
 	}
 }
 
+func TestSendBatch(t *testing.T) {
+	user := mspmocks.NewMockSigningIdentity("test", "1234")
+	ctx := mocks.NewMockContext(user)
+
+	reqCtx, cancel := context.NewRequest(ctx, context.WithTimeout(10*time.Second))
+	defer cancel()
+
+	validTx := &fab.Transaction{
+		Proposal: &fab.TransactionProposal{
+			Proposal: &pb.Proposal{Header: []byte(""), Payload: []byte(""), Extension: []byte("")},
+		},
+		Transaction: &pb.Transaction{},
+	}
+	txs := []*fab.Transaction{validTx, nil, validTx}
+
+	_, errs := SendBatch(reqCtx, txs, nil)
+	for _, err := range errs {
+		assert.EqualError(t, err, "orderers is nil")
+	}
+
+	orderer1 := mocks.NewMockOrderer("1", nil)
+	orderer2 := mocks.NewMockOrderer("2", nil)
+	orderers := []fab.Orderer{orderer1, orderer2}
+
+	// One orderer fails to accept a transaction; it should be sent to the other one
+	orderer1.EnqueueSendBroadcastError(errors.New("Service Unavailable"))
+
+	responses, errs := SendBatch(reqCtx, txs, orderers)
+	assert.Nil(t, errs[0])
+	assert.NotNil(t, responses[0])
+	assert.EqualError(t, errs[1], "transaction is nil")
+	assert.Nil(t, responses[1])
+	assert.Nil(t, errs[2])
+	assert.NotNil(t, responses[2])
+
+	// All orderers fail
+	orderer1.EnqueueSendBroadcastError(errors.New("Service Unavailable"))
+	orderer2.EnqueueSendBroadcastError(errors.New("Service Unavailable"))
+	_, errs = SendBatch(reqCtx, []*fab.Transaction{validTx}, orderers)
+	assert.Contains(t, errs[0].Error(), "Service Unavailable")
+}
+
 func TestBuildChannelHeader(t *testing.T) {
 	user := mspmocks.NewMockSigningIdentity("test", "1234")
 	ctx := mocks.NewMockContext(user)
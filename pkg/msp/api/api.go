@@ -18,9 +18,21 @@ var (
 // CAClient provides management of identities in a Fabric network
 type CAClient interface {
 	Enroll(enrollmentID string, enrollmentSecret string) error
+	EnrollWithRequest(request *EnrollmentRequest) error
 	Reenroll(enrollmentID string) error
+	ReenrollWithRequest(request *ReenrollmentRequest) error
 	Register(request *RegistrationRequest) (string, error)
 	Revoke(request *RevocationRequest) (*RevocationResponse, error)
+	GetIdentity(id, caname string) (*IdentityResponse, error)
+	GetAllIdentities(caname string) ([]*IdentityResponse, error)
+	ModifyIdentity(request *IdentityRequest) (*IdentityResponse, error)
+	RemoveIdentity(request *RemoveIdentityRequest) (*IdentityResponse, error)
+	GetAffiliation(affiliation, caname string) (*AffiliationResponse, error)
+	GetAllAffiliations(caname string) (*AffiliationResponse, error)
+	AddAffiliation(request *AffiliationRequest) (*AffiliationResponse, error)
+	ModifyAffiliation(request *ModifyAffiliationRequest) (*AffiliationResponse, error)
+	RemoveAffiliation(request *AffiliationRequest) (*AffiliationResponse, error)
+	GetCAInfo(caname string) (*GetCAInfoResponse, error)
 }
 
 // AttributeRequest is a request for an attribute.
@@ -29,6 +41,71 @@ type AttributeRequest struct {
 	Optional bool
 }
 
+// EnrollmentRequest is a request to enroll an identity
+type EnrollmentRequest struct {
+	// The identity name to enroll
+	Name string
+	// The secret returned via Register
+	Secret string
+	// CAName is the name of the CA to connect to
+	CAName string
+	// AttrReqs are requests for attributes to add to the certificate.
+	// Each attribute is added only if the requestor owns the attribute.
+	AttrReqs []*AttributeRequest
+	// Profile is the name of the signing profile to use in issuing the X509 certificate
+	Profile string
+	// Label is the label to use in HSM operations
+	Label string
+	// CSR is Certificate Signing Request info
+	CSR *CSRInfo
+}
+
+// ReenrollmentRequest is a request to reenroll an identity.
+// This is useful to renew a certificate before it has expired.
+type ReenrollmentRequest struct {
+	// The identity name to reenroll
+	Name string
+	// CAName is the name of the CA to connect to
+	CAName string
+	// AttrReqs are requests for attributes to add to the certificate.
+	// Each attribute is added only if the requestor owns the attribute.
+	AttrReqs []*AttributeRequest
+	// Profile is the name of the signing profile to use in issuing the X509 certificate
+	Profile string
+	// Label is the label to use in HSM operations
+	Label string
+	// CSR is Certificate Signing Request info
+	CSR *CSRInfo
+}
+
+// CSRInfo is Certificate Signing Request (CSR) Information.
+// The common name of the CSR is always the enrollment ID.
+type CSRInfo struct {
+	// Names are the subject names of the certificate
+	Names []CSRName
+	// Hosts are the host names of the certificate. If omitted, the local host name is used.
+	Hosts []string
+	// KeyRequest is the algorithm and size of the generated key. If omitted, an ECDSA P-256 key is generated.
+	KeyRequest *KeyRequest
+	// SerialNumber is the serial number of the certificate subject
+	SerialNumber string
+}
+
+// CSRName contains the subject name fields of a certificate
+type CSRName struct {
+	C  string // Country
+	ST string // State
+	L  string // Locality
+	O  string // OrganisationName
+	OU string // OrganisationalUnitName
+}
+
+// KeyRequest contains the algorithm and size of a key to generate
+type KeyRequest struct {
+	Algo string
+	Size int
+}
+
 // RegistrationRequest defines the attributes required to register a user with the CA
 type RegistrationRequest struct {
 	// Name is the unique name of the identity
@@ -55,6 +132,8 @@ type Attribute struct {
 	Name  string
 	Key   string
 	Value string
+	// ECert adds the attribute to the enrollment certificate by default
+	ECert bool
 }
 
 // RevocationRequest defines the attributes required to revoke credentials with the CA
@@ -89,3 +168,109 @@ type RevokedCert struct {
 	// AKI of the revoked certificate
 	AKI string
 }
+
+// IdentityRequest defines the attributes of an identity which are modified on the CA
+type IdentityRequest struct {
+	// ID is the unique name of the identity
+	ID string
+	// Type of identity (e.g. "peer, app, user").
+	// If omitted, the type is not modified.
+	Type string
+	// MaxEnrollments is the number of times the secret can be reused to enroll.
+	// If omitted, the maximum number of enrollments is not modified.
+	MaxEnrollments int
+	// The identity's affiliation e.g. org1.department1.
+	// If omitted, the affiliation is not modified.
+	Affiliation string
+	// Attributes associated with this identity.
+	// If omitted, the attributes are not modified.
+	Attributes []Attribute
+	// CAName is the name of the CA to connect to
+	CAName string
+	// Secret is the new enrollment secret of the identity.
+	// If omitted, the secret is not modified.
+	Secret string
+}
+
+// RemoveIdentityRequest defines the attributes required to remove an identity from the CA
+type RemoveIdentityRequest struct {
+	// ID is the unique name of the identity
+	ID string
+	// Force forces the removal of the caller's own identity
+	Force bool
+	// CAName is the name of the CA to connect to
+	CAName string
+}
+
+// IdentityResponse represents an identity returned by the CA
+type IdentityResponse struct {
+	// ID is the unique name of the identity
+	ID string
+	// Type of identity (e.g. "peer, app, user")
+	Type string
+	// MaxEnrollments is the number of times the secret can be reused to enroll
+	MaxEnrollments int
+	// The identity's affiliation e.g. org1.department1
+	Affiliation string
+	// Attributes associated with this identity
+	Attributes []Attribute
+	// CAName is the name of the CA which returned the identity
+	CAName string
+	// Secret is the enrollment secret of the identity, which is only returned if it was modified
+	Secret string
+}
+
+// AffiliationRequest defines the attributes required to add or remove an affiliation on the CA
+type AffiliationRequest struct {
+	// Name is the name of the affiliation e.g. org1.department1
+	Name string
+	// Force creates the parent affiliations if they don't exist when adding an affiliation.
+	// When removing an affiliation, Force also removes its sub-affiliations and identities.
+	Force bool
+	// CAName is the name of the CA to connect to
+	CAName string
+}
+
+// ModifyAffiliationRequest defines the attributes required to rename an affiliation on the CA
+type ModifyAffiliationRequest struct {
+	AffiliationRequest
+	// NewName is the new name of the affiliation. Force must be set if identities are affected by the change.
+	NewName string
+}
+
+// AffiliationResponse contains the response of an affiliation request
+type AffiliationResponse struct {
+	AffiliationInfo
+	// CAName is the name of the CA which returned the response
+	CAName string
+}
+
+// AffiliationInfo contains an affiliation along with its sub-affiliations and the identities that belong to it
+type AffiliationInfo struct {
+	Name         string
+	Affiliations []AffiliationInfo
+	Identities   []IdentityInfo
+}
+
+// IdentityInfo contains information about an identity
+type IdentityInfo struct {
+	ID             string
+	Type           string
+	Affiliation    string
+	Attributes     []Attribute
+	MaxEnrollments int
+}
+
+// GetCAInfoResponse is the response from the CA's info endpoint
+type GetCAInfoResponse struct {
+	// CAName is the name of the CA
+	CAName string
+	// CAChain is the PEM-encoded certificate chain of the CA, starting with the root CA certificate
+	CAChain []byte
+	// RootCertificates are the PEM-encoded root CA certificates of the chain
+	RootCertificates [][]byte
+	// IntermediateCertificates are the PEM-encoded intermediate CA certificates of the chain
+	IntermediateCertificates [][]byte
+	// Version of the CA server
+	Version string
+}
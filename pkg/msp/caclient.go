@@ -8,6 +8,7 @@ package msp
 
 import (
 	"fmt"
+	"sync"
 
 	"strings"
 
@@ -28,8 +29,19 @@ type CAClientImpl struct {
 	cryptoSuite     core.CryptoSuite
 	identityManager msp.IdentityManager
 	userStore       msp.UserStore
-	adapter         *fabricCAAdapter
-	registrar       core.EnrollCredentials
+	defaultCA       *caInstance
+	caIDs           map[string]string
+	caConfigs       map[string]*core.CAConfig
+	cas             map[string]*caInstance
+	casMutex        sync.Mutex
+}
+
+// caInstance is a CA of the organization along with its registrar
+type caInstance struct {
+	adapter   *fabricCAAdapter
+	registrar core.EnrollCredentials
+	caID      string
+	caName    string
 }
 
 // NewCAClient creates a new CA CAClient instance
@@ -66,7 +78,7 @@ func NewCAClient(orgName string, identityManager msp.IdentityManager, userStore
 	var adapter *fabricCAAdapter
 	var registrar core.EnrollCredentials
 
-	// Currently, an organization can be associated with only one CA
+	// The first CA of the organization is the default CA
 	caName := orgConfig.CertificateAuthorities[0]
 	caConfig, err = config.CAConfig(orgName)
 	if err == nil {
@@ -80,6 +92,27 @@ func NewCAClient(orgName string, identityManager msp.IdentityManager, userStore
 		return nil, errors.Wrapf(err, "error initializing CA [%s]", caName)
 	}
 
+	defaultCA := &caInstance{adapter: adapter, registrar: registrar, caID: caName, caName: caConfig.CAName}
+	caIDs := map[string]string{strings.ToLower(caName): caName}
+	if caConfig.CAName != "" {
+		caIDs[strings.ToLower(caConfig.CAName)] = caName
+	}
+
+	// Additional CAs (e.g. intermediate or TLS CAs) are selected by their ID or CA name
+	caConfigs := make(map[string]*core.CAConfig)
+	for _, caID := range orgConfig.CertificateAuthorities[1:] {
+		conf, err := config.CAConfigByID(caID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error initializing CA [%s]", caID)
+		}
+		caConfigs[caID] = conf
+		for _, key := range []string{caID, conf.CAName} {
+			if _, ok := caIDs[strings.ToLower(key)]; key != "" && !ok {
+				caIDs[strings.ToLower(key)] = caID
+			}
+		}
+	}
+
 	mgr := &CAClientImpl{
 		orgName:         orgName,
 		orgMSPID:        orgConfig.MSPID,
@@ -87,8 +120,10 @@ func NewCAClient(orgName string, identityManager msp.IdentityManager, userStore
 		cryptoSuite:     cryptoSuite,
 		identityManager: identityManager,
 		userStore:       userStore,
-		adapter:         adapter,
-		registrar:       registrar,
+		defaultCA:       defaultCA,
+		caIDs:           caIDs,
+		caConfigs:       caConfigs,
+		cas:             map[string]*caInstance{caName: defaultCA},
 	}
 	return mgr, nil
 }
@@ -101,24 +136,40 @@ func NewCAClient(orgName string, identityManager msp.IdentityManager, userStore
 // enrollmentID The registered ID to use for enrollment
 // enrollmentSecret The secret associated with the enrollment ID
 func (c *CAClientImpl) Enroll(enrollmentID string, enrollmentSecret string) error {
+	return c.EnrollWithRequest(&api.EnrollmentRequest{Name: enrollmentID, Secret: enrollmentSecret})
+}
 
-	if c.adapter == nil {
-		return fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+// EnrollWithRequest enrolls a registered user as Enroll does, with the attribute requests,
+// CSR information, profile, label and CA name of the request. The credentials issued by a CA
+// other than the default CA of the organization are stored under the enrollment ID qualified
+// with the ID of the CA (enrollmentID@caID), so that they don't replace the credentials issued
+// by the default CA.
+//
+// request: Enrollment Request, which contains the registered ID and secret to use for enrollment
+func (c *CAClientImpl) EnrollWithRequest(request *api.EnrollmentRequest) error {
+
+	if request == nil {
+		return errors.New("enrollment request is required")
 	}
-	if enrollmentID == "" {
+	if request.Name == "" {
 		return errors.New("enrollmentID is required")
 	}
-	if enrollmentSecret == "" {
+	if request.Secret == "" {
 		return errors.New("enrollmentSecret is required")
 	}
-	// TODO add attributes
-	cert, err := c.adapter.Enroll(enrollmentID, enrollmentSecret)
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return err
+	}
+	req := *request
+	req.CAName = caname
+	cert, err := ca.adapter.Enroll(&req)
 	if err != nil {
 		return errors.Wrap(err, "enroll failed")
 	}
 	userData := &msp.UserData{
 		MSPID: c.orgMSPID,
-		ID:    enrollmentID,
+		ID:    c.userID(ca, request.Name),
 		EnrollmentCertificate: cert,
 	}
 	err = c.userStore.Store(userData)
@@ -128,23 +179,43 @@ func (c *CAClientImpl) Enroll(enrollmentID string, enrollmentSecret string) erro
 	return nil
 }
 
-// Reenroll an enrolled user in order to obtain a new signed X509 certificate
+// Reenroll an enrolled user in order to obtain a new signed X509 certificate.
+// The enrollment ID may be qualified with the ID of the CA which issued the credentials
+// (enrollmentID@caID), as for credentials issued by a CA other than the default CA.
 func (c *CAClientImpl) Reenroll(enrollmentID string) error {
+	return c.ReenrollWithRequest(&api.ReenrollmentRequest{Name: enrollmentID})
+}
 
-	if c.adapter == nil {
-		return fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+// ReenrollWithRequest reenrolls an enrolled user as Reenroll does, with the attribute requests,
+// CSR information, profile, label and CA name of the request.
+// request: Reenrollment Request, which contains the ID of the enrolled user
+func (c *CAClientImpl) ReenrollWithRequest(request *api.ReenrollmentRequest) error {
+
+	if request == nil {
+		return errors.New("reenrollment request is required")
 	}
-	if enrollmentID == "" {
+	if request.Name == "" {
 		logger.Infof("invalid re-enroll request, missing enrollmentID")
 		return errors.New("user name missing")
 	}
 
-	user, err := c.identityManager.GetSigningIdentity(enrollmentID)
+	req := *request
+	if req.CAName == "" {
+		req.CAName, req.Name = c.splitUserID(req.Name)
+	}
+
+	ca, caname, err := c.selectCA(req.CAName)
+	if err != nil {
+		return err
+	}
+
+	user, err := c.identityManager.GetSigningIdentity(c.userID(ca, req.Name))
 	if err != nil {
-		return errors.Wrapf(err, "failed to retrieve user: %s", enrollmentID)
+		return errors.Wrapf(err, "failed to retrieve user: %s", request.Name)
 	}
 
-	cert, err := c.adapter.Reenroll(user.PrivateKey(), user.EnrollmentCertificate())
+	req.CAName = caname
+	cert, err := ca.adapter.Reenroll(user.PrivateKey(), user.EnrollmentCertificate(), &req)
 	if err != nil {
 		return errors.Wrap(err, "reenroll failed")
 	}
@@ -165,10 +236,15 @@ func (c *CAClientImpl) Reenroll(enrollmentID string) error {
 // request: Registration Request
 // Returns Enrolment Secret
 func (c *CAClientImpl) Register(request *api.RegistrationRequest) (string, error) {
-	if c.adapter == nil {
-		return "", fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+	var caname string
+	if request != nil {
+		caname = request.CAName
 	}
-	if c.registrar.EnrollID == "" {
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return "", err
+	}
+	if ca.registrar.EnrollID == "" {
 		return "", api.ErrCARegistrarNotFound
 	}
 	// Validate registration request
@@ -179,12 +255,14 @@ func (c *CAClientImpl) Register(request *api.RegistrationRequest) (string, error
 		return "", errors.New("request.Name is required")
 	}
 
-	registrar, err := c.getRegistrar(c.registrar.EnrollID, c.registrar.EnrollSecret)
+	registrar, err := c.getRegistrar(ca, caname)
 	if err != nil {
 		return "", err
 	}
 
-	secret, err := c.adapter.Register(registrar.PrivateKey(), registrar.EnrollmentCertificate(), request)
+	req := *request
+	req.CAName = caname
+	secret, err := ca.adapter.Register(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to register user")
 	}
@@ -196,10 +274,15 @@ func (c *CAClientImpl) Register(request *api.RegistrationRequest) (string, error
 // registrar: The User that is initiating the revocation
 // request: Revocation Request
 func (c *CAClientImpl) Revoke(request *api.RevocationRequest) (*api.RevocationResponse, error) {
-	if c.adapter == nil {
-		return nil, fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+	var caname string
+	if request != nil {
+		caname = request.CAName
 	}
-	if c.registrar.EnrollID == "" {
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
+	if ca.registrar.EnrollID == "" {
 		return nil, api.ErrCARegistrarNotFound
 	}
 	// Validate revocation request
@@ -207,25 +290,318 @@ func (c *CAClientImpl) Revoke(request *api.RevocationRequest) (*api.RevocationRe
 		return nil, errors.New("revocation request is required")
 	}
 
-	registrar, err := c.getRegistrar(c.registrar.EnrollID, c.registrar.EnrollSecret)
+	registrar, err := c.getRegistrar(ca, caname)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.adapter.Revoke(registrar.PrivateKey(), registrar.EnrollmentCertificate(), request)
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.Revoke(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to revoke")
 	}
 	return resp, nil
 }
 
-func (c *CAClientImpl) getRegistrar(enrollID string, enrollSecret string) (msp.SigningIdentity, error) {
+// GetIdentity returns information about the identity with the given ID from the Fabric CA
+// id: The ID of the identity
+// caname: The name of the CA, or empty for the default CA
+func (c *CAClientImpl) GetIdentity(id, caname string) (*api.IdentityResponse, error) {
+	if id == "" {
+		return nil, errors.New("id is required")
+	}
+
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ca.adapter.GetIdentity(registrar.PrivateKey(), registrar.EnrollmentCertificate(), id, caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get identity")
+	}
+	return resp, nil
+}
+
+// GetAllIdentities returns all of the identities that the registrar is authorized to see
+// caname: The name of the CA, or empty for the default CA
+func (c *CAClientImpl) GetAllIdentities(caname string) ([]*api.IdentityResponse, error) {
+
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ca.adapter.GetAllIdentities(registrar.PrivateKey(), registrar.EnrollmentCertificate(), caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get identities")
+	}
+	return resp, nil
+}
+
+// ModifyIdentity modifies an identity on the Fabric CA
+// request: Identity Request
+func (c *CAClientImpl) ModifyIdentity(request *api.IdentityRequest) (*api.IdentityResponse, error) {
+	// Validate request
+	if request == nil {
+		return nil, errors.New("identity request is required")
+	}
+	if request.ID == "" {
+		return nil, errors.New("request.ID is required")
+	}
+
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.ModifyIdentity(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify identity")
+	}
+	return resp, nil
+}
+
+// RemoveIdentity removes an identity from the Fabric CA
+// request: Remove Identity Request
+func (c *CAClientImpl) RemoveIdentity(request *api.RemoveIdentityRequest) (*api.IdentityResponse, error) {
+	// Validate request
+	if request == nil {
+		return nil, errors.New("remove identity request is required")
+	}
+	if request.ID == "" {
+		return nil, errors.New("request.ID is required")
+	}
+
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.RemoveIdentity(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove identity")
+	}
+	return resp, nil
+}
+
+// GetAffiliation returns an affiliation along with its sub-affiliations and identities
+// affiliation: The name of the affiliation e.g. org1.department1
+// caname: The name of the CA, or empty for the default CA
+func (c *CAClientImpl) GetAffiliation(affiliation, caname string) (*api.AffiliationResponse, error) {
+	if affiliation == "" {
+		return nil, errors.New("affiliation is required")
+	}
+
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ca.adapter.GetAffiliation(registrar.PrivateKey(), registrar.EnrollmentCertificate(), affiliation, caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affiliation")
+	}
+	return resp, nil
+}
+
+// GetAllAffiliations returns the tree of affiliations that the registrar is authorized to see
+// caname: The name of the CA, or empty for the default CA
+func (c *CAClientImpl) GetAllAffiliations(caname string) (*api.AffiliationResponse, error) {
+
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ca.adapter.GetAllAffiliations(registrar.PrivateKey(), registrar.EnrollmentCertificate(), caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affiliations")
+	}
+	return resp, nil
+}
+
+// AddAffiliation adds an affiliation to the Fabric CA
+// request: Affiliation Request
+func (c *CAClientImpl) AddAffiliation(request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	// Validate request
+	if request == nil {
+		return nil, errors.New("affiliation request is required")
+	}
+	if request.Name == "" {
+		return nil, errors.New("request.Name is required")
+	}
+
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.AddAffiliation(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add affiliation")
+	}
+	return resp, nil
+}
+
+// ModifyAffiliation renames an affiliation on the Fabric CA
+// request: Modify Affiliation Request
+func (c *CAClientImpl) ModifyAffiliation(request *api.ModifyAffiliationRequest) (*api.AffiliationResponse, error) {
+	// Validate request
+	if request == nil {
+		return nil, errors.New("modify affiliation request is required")
+	}
+	if request.Name == "" || request.NewName == "" {
+		return nil, errors.New("request.Name and request.NewName are required")
+	}
+
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.ModifyAffiliation(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify affiliation")
+	}
+	return resp, nil
+}
+
+// RemoveAffiliation removes an affiliation from the Fabric CA
+// request: Affiliation Request
+func (c *CAClientImpl) RemoveAffiliation(request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	// Validate request
+	if request == nil {
+		return nil, errors.New("affiliation request is required")
+	}
+	if request.Name == "" {
+		return nil, errors.New("request.Name is required")
+	}
+
+	ca, caname, err := c.selectCA(request.CAName)
+	if err != nil {
+		return nil, err
+	}
+
+	registrar, err := c.getRegistrar(ca, caname)
+	if err != nil {
+		return nil, err
+	}
+
+	req := *request
+	req.CAName = caname
+	resp, err := ca.adapter.RemoveAffiliation(registrar.PrivateKey(), registrar.EnrollmentCertificate(), &req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove affiliation")
+	}
+	return resp, nil
+}
+
+// GetCAInfo returns the name, certificate chain and version of a CA of the organization
+// caname: The name or ID of the CA, or empty for the default CA
+func (c *CAClientImpl) GetCAInfo(caname string) (*api.GetCAInfoResponse, error) {
+	ca, caname, err := c.selectCA(caname)
+	if err != nil {
+		return nil, err
+	}
 
+	resp, err := ca.adapter.GetCAInfo(caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get CA info")
+	}
+	return resp, nil
+}
+
+// selectCA returns the CA which serves requests for the given CA name, along with the
+// CA name to send to it. The CA name may be the ID or the name of any CA configured for
+// the organization. Requests for other CA names go to the default CA of the organization,
+// since a Fabric CA server may host several CAs.
+func (c *CAClientImpl) selectCA(caname string) (*caInstance, string, error) {
+	if c.defaultCA == nil {
+		return nil, "", fmt.Errorf("no CAs configured for organization: %s", c.orgName)
+	}
+
+	caID, ok := c.caIDs[strings.ToLower(caname)]
+	if !ok {
+		return c.defaultCA, caname, nil
+	}
+
+	c.casMutex.Lock()
+	defer c.casMutex.Unlock()
+
+	ca, ok := c.cas[caID]
+	if !ok {
+		caConfig := c.caConfigs[caID]
+		adapter, err := newFabricCAAdapterForCA(caConfig, c.cryptoSuite, c.config)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "error initializing CA [%s]", caID)
+		}
+		ca = &caInstance{adapter: adapter, registrar: caConfig.Registrar, caID: caID, caName: caConfig.CAName}
+		c.cas[caID] = ca
+	}
+	return ca, ca.caName, nil
+}
+
+// getRegistrar returns the signing identity of the registrar of the given CA,
+// enrolling the registrar with the CA if it isn't enrolled yet
+func (c *CAClientImpl) getRegistrar(ca *caInstance, caname string) (msp.SigningIdentity, error) {
+
+	enrollID := ca.registrar.EnrollID
+	enrollSecret := ca.registrar.EnrollSecret
 	if enrollID == "" {
 		return nil, api.ErrCARegistrarNotFound
 	}
 
-	registrar, err := c.identityManager.GetSigningIdentity(enrollID)
+	registrar, err := c.identityManager.GetSigningIdentity(c.userID(ca, enrollID))
 	if err != nil {
 		if err != msp.ErrUserNotFound {
 			return nil, err
@@ -235,14 +611,35 @@ func (c *CAClientImpl) getRegistrar(enrollID string, enrollSecret string) (msp.S
 		}
 
 		// Attempt to enroll the registrar
-		err = c.Enroll(enrollID, enrollSecret)
+		err = c.EnrollWithRequest(&api.EnrollmentRequest{Name: enrollID, Secret: enrollSecret, CAName: caname})
 		if err != nil {
 			return nil, err
 		}
-		registrar, err = c.identityManager.GetSigningIdentity(enrollID)
+		registrar, err = c.identityManager.GetSigningIdentity(c.userID(ca, enrollID))
 		if err != nil {
 			return nil, err
 		}
 	}
 	return registrar, nil
 }
+
+// userID returns the ID under which the credentials issued by the CA to the enrollment ID are stored.
+// The credentials issued by a CA other than the default CA are stored under the enrollment ID
+// qualified with the ID of the CA.
+func (c *CAClientImpl) userID(ca *caInstance, enrollmentID string) string {
+	if ca == c.defaultCA {
+		return enrollmentID
+	}
+	return enrollmentID + "@" + ca.caID
+}
+
+// splitUserID returns the CA ID and enrollment ID of the ID under which credentials are stored.
+// The CA ID is empty for the credentials issued by the default CA.
+func (c *CAClientImpl) splitUserID(id string) (string, string) {
+	for caID := range c.caConfigs {
+		if strings.HasSuffix(id, "@"+caID) {
+			return caID, strings.TrimSuffix(id, "@"+caID)
+		}
+	}
+	return "", id
+}
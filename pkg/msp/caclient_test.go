@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 	"time"
 
@@ -14,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
 	mockCore "github.com/hyperledger/fabric-sdk-go/pkg/common/providers/test/mockcore"
@@ -80,6 +85,94 @@ func TestEnrollAndReenroll(t *testing.T) {
 	}
 }
 
+// TestEnrollWithOptions tests that attribute requests, CSR info, profile and label are sent to the CA
+func TestEnrollWithOptions(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	enrollUsername := createRandomName()
+	request := &api.EnrollmentRequest{
+		Name:     enrollUsername,
+		Secret:   "enrollmentSecret",
+		AttrReqs: []*api.AttributeRequest{{Name: "attr1"}, {Name: "attr2", Optional: true}},
+		Profile:  "tls",
+		Label:    "label1",
+		CSR: &api.CSRInfo{
+			Names:      []api.CSRName{{C: "US", O: "org1.example.com", OU: "client"}},
+			Hosts:      []string{"host1.example.com", "127.0.0.1"},
+			KeyRequest: &api.KeyRequest{Algo: "ecdsa", Size: 384},
+		},
+	}
+	err := f.caClient.EnrollWithRequest(request)
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	checkEnrollmentRequest(t, enrollUsername, request.AttrReqs, request.Profile, request.Label, request.CSR)
+
+	err = f.caClient.ReenrollWithRequest(&api.ReenrollmentRequest{
+		Name:     enrollUsername,
+		AttrReqs: request.AttrReqs,
+		Profile:  request.Profile,
+		Label:    request.Label,
+		CSR:      request.CSR,
+	})
+	if err != nil {
+		t.Fatalf("Reenroll return error %v", err)
+	}
+	// The common name of a reenrollment CSR is taken from the existing certificate
+	checkEnrollmentRequest(t, "", request.AttrReqs, request.Profile, request.Label, request.CSR)
+
+	// Unsupported key request
+	request.Name = createRandomName()
+	request.CSR.KeyRequest = &api.KeyRequest{Algo: "ecdsa", Size: 100}
+	err = f.caClient.EnrollWithRequest(request)
+	if err == nil {
+		t.Fatalf("Enroll should have failed with an invalid key request")
+	}
+}
+
+func checkEnrollmentRequest(t *testing.T, name string, attrReqs []*api.AttributeRequest, profile, label string, csrInfo *api.CSRInfo) {
+	req := caServer.LastEnrollmentRequest()
+	if req == nil {
+		t.Fatalf("CA server didn't receive an enrollment request")
+	}
+	if req.Profile != profile || req.Label != label {
+		t.Fatalf("Unexpected profile and label: %s, %s", req.Profile, req.Label)
+	}
+	if len(req.AttrReqs) != len(attrReqs) {
+		t.Fatalf("Expected %d attribute requests, got %d", len(attrReqs), len(req.AttrReqs))
+	}
+	for i, attrReq := range attrReqs {
+		if req.AttrReqs[i].Name != attrReq.Name || req.AttrReqs[i].Optional != attrReq.Optional {
+			t.Fatalf("Unexpected attribute request: %+v", req.AttrReqs[i])
+		}
+	}
+	if strings.Join(req.Hosts, ",") != strings.Join(csrInfo.Hosts, ",") {
+		t.Fatalf("Unexpected hosts: %v", req.Hosts)
+	}
+
+	block, _ := pem.Decode([]byte(req.Request))
+	if block == nil {
+		t.Fatalf("Failed to decode CSR")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("Failed to parse CSR: %s", err)
+	}
+	if name != "" && csr.Subject.CommonName != name {
+		t.Fatalf("Expected CSR common name %s, got %s", name, csr.Subject.CommonName)
+	}
+	if len(csr.Subject.Organization) != 1 || csr.Subject.Organization[0] != csrInfo.Names[0].O {
+		t.Fatalf("Unexpected CSR organization: %v", csr.Subject.Organization)
+	}
+	publicKey, ok := csr.PublicKey.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve.Params().BitSize != csrInfo.KeyRequest.Size {
+		t.Fatalf("Expected ECDSA %d key in CSR", csrInfo.KeyRequest.Size)
+	}
+}
+
 // TestWrongURL tests creation of CAClient with wrong URL
 func TestWrongURL(t *testing.T) {
 
@@ -230,6 +323,342 @@ func TestRevoke(t *testing.T) {
 	}
 }
 
+// TestIdentityManagement tests getting, listing, modifying and removing identities
+func TestIdentityManagement(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	// Invalid requests
+	if _, err := f.caClient.GetIdentity("", ""); err == nil {
+		t.Fatalf("Expected error with empty ID")
+	}
+	if _, err := f.caClient.ModifyIdentity(nil); err == nil {
+		t.Fatalf("Expected error with nil request")
+	}
+	if _, err := f.caClient.ModifyIdentity(&api.IdentityRequest{}); err == nil {
+		t.Fatalf("Expected error without ID")
+	}
+	if _, err := f.caClient.RemoveIdentity(nil); err == nil {
+		t.Fatalf("Expected error with nil request")
+	}
+	if _, err := f.caClient.RemoveIdentity(&api.RemoveIdentityRequest{}); err == nil {
+		t.Fatalf("Expected error without ID")
+	}
+
+	name := createRandomName()
+	_, err := f.caClient.Register(&api.RegistrationRequest{Name: name, Type: "client", Affiliation: "org1", MaxEnrollments: 1})
+	if err != nil {
+		t.Fatalf("Register return error %v", err)
+	}
+
+	identity, err := f.caClient.GetIdentity(name, "")
+	if err != nil {
+		t.Fatalf("GetIdentity return error %v", err)
+	}
+	if identity.ID != name || identity.Type != "client" || identity.Affiliation != "org1" || identity.MaxEnrollments != 1 {
+		t.Fatalf("GetIdentity returned wrong identity: %+v", identity)
+	}
+
+	identity, err = f.caClient.ModifyIdentity(&api.IdentityRequest{
+		ID:             name,
+		Affiliation:    "org1.department1",
+		MaxEnrollments: 5,
+		Attributes:     []api.Attribute{{Key: "attr1", Value: "value1", ECert: true}},
+		Secret:         "newSecret",
+		CAName:         "ca.org1.example.com",
+	})
+	if err != nil {
+		t.Fatalf("ModifyIdentity return error %v", err)
+	}
+	if identity.Type != "client" || identity.Affiliation != "org1.department1" || identity.MaxEnrollments != 5 || identity.Secret != "newSecret" {
+		t.Fatalf("ModifyIdentity returned wrong identity: %+v", identity)
+	}
+	if len(identity.Attributes) != 1 || identity.Attributes[0].Key != "attr1" || identity.Attributes[0].Value != "value1" || !identity.Attributes[0].ECert {
+		t.Fatalf("ModifyIdentity returned wrong attributes: %+v", identity.Attributes)
+	}
+	if identity.CAName != "ca.org1.example.com" {
+		t.Fatalf("Expected CA name to be sent with request, got: %s", identity.CAName)
+	}
+
+	identities, err := f.caClient.GetAllIdentities("")
+	if err != nil {
+		t.Fatalf("GetAllIdentities return error %v", err)
+	}
+	found := false
+	for _, id := range identities {
+		if id.ID == name {
+			found = true
+			if id.Affiliation != "org1.department1" {
+				t.Fatalf("GetAllIdentities returned wrong identity: %+v", id)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("GetAllIdentities didn't return identity %s", name)
+	}
+
+	identity, err = f.caClient.RemoveIdentity(&api.RemoveIdentityRequest{ID: name})
+	if err != nil {
+		t.Fatalf("RemoveIdentity return error %v", err)
+	}
+	if identity.ID != name {
+		t.Fatalf("RemoveIdentity returned wrong identity: %+v", identity)
+	}
+
+	_, err = f.caClient.GetIdentity(name, "")
+	if err == nil {
+		t.Fatalf("Expected error getting removed identity")
+	}
+}
+
+// TestAffiliationManagement tests adding, listing, renaming and removing affiliations
+func TestAffiliationManagement(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	// Invalid requests
+	if _, err := f.caClient.GetAffiliation("", ""); err == nil {
+		t.Fatalf("Expected error with empty affiliation")
+	}
+	if _, err := f.caClient.AddAffiliation(&api.AffiliationRequest{}); err == nil {
+		t.Fatalf("Expected error without affiliation name")
+	}
+	if _, err := f.caClient.ModifyAffiliation(&api.ModifyAffiliationRequest{AffiliationRequest: api.AffiliationRequest{Name: "org1"}}); err == nil {
+		t.Fatalf("Expected error without new affiliation name")
+	}
+	if _, err := f.caClient.RemoveAffiliation(nil); err == nil {
+		t.Fatalf("Expected error with nil request")
+	}
+
+	dept := "org1." + createRandomName()
+
+	// The parent affiliation must exist unless forced
+	if _, err := f.caClient.AddAffiliation(&api.AffiliationRequest{Name: dept + ".team1"}); err == nil {
+		t.Fatalf("Expected error adding affiliation without parent")
+	}
+	resp, err := f.caClient.AddAffiliation(&api.AffiliationRequest{Name: dept + ".team1", Force: true})
+	if err != nil {
+		t.Fatalf("AddAffiliation return error %v", err)
+	}
+	if resp.Name != dept+".team1" {
+		t.Fatalf("AddAffiliation returned wrong affiliation: %+v", resp)
+	}
+
+	_, err = f.caClient.Register(&api.RegistrationRequest{Name: createRandomName(), Affiliation: dept + ".team1"})
+	if err != nil {
+		t.Fatalf("Register return error %v", err)
+	}
+
+	resp, err = f.caClient.GetAffiliation(dept, "")
+	if err != nil {
+		t.Fatalf("GetAffiliation return error %v", err)
+	}
+	if len(resp.Affiliations) != 1 || resp.Affiliations[0].Name != dept+".team1" || len(resp.Affiliations[0].Identities) != 1 {
+		t.Fatalf("GetAffiliation returned wrong affiliation tree: %+v", resp)
+	}
+
+	resp, err = f.caClient.GetAllAffiliations("")
+	if err != nil {
+		t.Fatalf("GetAllAffiliations return error %v", err)
+	}
+	if len(resp.Affiliations) == 0 || resp.Affiliations[0].Name != "org1" {
+		t.Fatalf("GetAllAffiliations returned wrong affiliation tree: %+v", resp)
+	}
+
+	// Renaming an affiliation with identities must be forced
+	newDept := "org1." + createRandomName()
+	modifyReq := &api.ModifyAffiliationRequest{AffiliationRequest: api.AffiliationRequest{Name: dept}, NewName: newDept}
+	if _, err = f.caClient.ModifyAffiliation(modifyReq); err == nil {
+		t.Fatalf("Expected error renaming affiliation with identities")
+	}
+	modifyReq.Force = true
+	resp, err = f.caClient.ModifyAffiliation(modifyReq)
+	if err != nil {
+		t.Fatalf("ModifyAffiliation return error %v", err)
+	}
+	if resp.Name != newDept || len(resp.Affiliations) != 1 || resp.Affiliations[0].Name != newDept+".team1" {
+		t.Fatalf("ModifyAffiliation returned wrong affiliation tree: %+v", resp)
+	}
+
+	// Removing an affiliation with sub-affiliations must be forced
+	if _, err = f.caClient.RemoveAffiliation(&api.AffiliationRequest{Name: newDept}); err == nil {
+		t.Fatalf("Expected error removing affiliation with sub-affiliations")
+	}
+	if _, err = f.caClient.RemoveAffiliation(&api.AffiliationRequest{Name: newDept, Force: true}); err != nil {
+		t.Fatalf("RemoveAffiliation return error %v", err)
+	}
+	if _, err = f.caClient.GetAffiliation(newDept+".team1", ""); err == nil {
+		t.Fatalf("Expected error getting removed affiliation")
+	}
+}
+
+// TestGetCAInfo tests retrieval of CA info from the default CA and from other CAs of the organization
+func TestGetCAInfo(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	// Default CA
+	resp, err := f.caClient.GetCAInfo("")
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+	if resp.CAName != "ca.org1.example.com" || resp.Version != "1.1.0" {
+		t.Fatalf("Unexpected CA info: %s, %s", resp.CAName, resp.Version)
+	}
+	if len(resp.RootCertificates) != 1 || len(resp.IntermediateCertificates) != 0 {
+		t.Fatalf("Expected one root and no intermediate certificates, got %d and %d", len(resp.RootCertificates), len(resp.IntermediateCertificates))
+	}
+
+	// Another CA of the organization, selected by ID, is queried with its configured CA name
+	resp, err = f.caClient.GetCAInfo("tlsca.org1.example.com")
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+	if resp.CAName != "tlsca.org1" {
+		t.Fatalf("Expected CA name tlsca.org1, got %s", resp.CAName)
+	}
+	caClient := f.caClient.(*CAClientImpl)
+	tlsCA, caname, err := caClient.selectCA("TLSCA.org1")
+	if err != nil || caname != "tlsca.org1" || tlsCA == caClient.defaultCA {
+		t.Fatalf("Expected CA name to select the TLS CA: %v", err)
+	}
+	if ca, _, _ := caClient.selectCA("tlsca.org1.example.com"); ca != tlsCA {
+		t.Fatalf("Expected the TLS CA to be created once")
+	}
+
+	// CA names which are not configured are passed to the default CA
+	resp, err = f.caClient.GetCAInfo("other")
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+	if resp.CAName != "other" {
+		t.Fatalf("Expected CA name other, got %s", resp.CAName)
+	}
+
+	// Enrollment and registration with the other CA
+	err = f.caClient.EnrollWithRequest(&api.EnrollmentRequest{Name: createRandomName(), Secret: "enrollmentSecret", CAName: "tlsca.org1.example.com"})
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	if caServer.LastEnrollmentRequest().CAName != "tlsca.org1" {
+		t.Fatalf("Expected enrollment with CA tlsca.org1, got %s", caServer.LastEnrollmentRequest().CAName)
+	}
+	_, err = f.caClient.Register(&api.RegistrationRequest{Name: createRandomName(), Affiliation: "org1", CAName: "tlsca.org1"})
+	if err != nil {
+		t.Fatalf("Register return error %v", err)
+	}
+	if _, err = f.identityManager.GetSigningIdentity("org1TLSAdmin@tlsca.org1.example.com"); err != nil {
+		t.Fatalf("Expected registrar of the TLS CA to be enrolled: %v", err)
+	}
+}
+
+// TestCAsSharingRegistrarID tests that the credentials issued by several CAs to the same enrollment ID are kept apart
+func TestCAsSharingRegistrarID(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	caClient := f.caClient.(*CAClientImpl)
+	tlsCA, _, err := caClient.selectCA("tlsca.org1.example.com")
+	if err != nil {
+		t.Fatalf("selectCA return error %v", err)
+	}
+	tlsCA.registrar = caClient.defaultCA.registrar
+	registrarID := caClient.defaultCA.registrar.EnrollID
+
+	// The registrar of the default CA holds a certificate the TLS CA didn't issue
+	_, err = util.ImportBCCSPKeyFromPEMBytes(generatedKeyBytes, f.cryptoSuite, false)
+	if err != nil {
+		t.Fatalf("Failed to import key: %v", err)
+	}
+	err = f.userStore.Store(&msp.UserData{MSPID: caClient.orgMSPID, ID: registrarID, EnrollmentCertificate: generatedCertBytes})
+	if err != nil {
+		t.Fatalf("Failed to store registrar: %v", err)
+	}
+
+	// The TLS CA enrolls its own registrar rather than signing with the certificate of the default CA
+	_, err = f.caClient.Register(&api.RegistrationRequest{Name: createRandomName(), Affiliation: "org1", CAName: "tlsca.org1"})
+	if err != nil {
+		t.Fatalf("Register return error %v", err)
+	}
+	tlsRegistrar, err := caClient.getRegistrar(tlsCA, "tlsca.org1")
+	if err != nil {
+		t.Fatalf("getRegistrar return error %v", err)
+	}
+	if tlsRegistrar.Identifier().ID != registrarID+"@tlsca.org1.example.com" || bytes.Equal(tlsRegistrar.EnrollmentCertificate(), generatedCertBytes) {
+		t.Fatalf("Expected the registrar of the TLS CA to hold its own certificate")
+	}
+	registrar, err := caClient.getRegistrar(caClient.defaultCA, "")
+	if err != nil {
+		t.Fatalf("getRegistrar return error %v", err)
+	}
+	if registrar.Identifier().ID != registrarID || !bytes.Equal(registrar.EnrollmentCertificate(), generatedCertBytes) {
+		t.Fatalf("Expected the registrar of the default CA to keep its certificate")
+	}
+
+	// Enrollment with the TLS CA doesn't replace the certificate issued by the default CA
+	err = f.caClient.EnrollWithRequest(&api.EnrollmentRequest{Name: registrarID, Secret: "enrollmentSecret", CAName: "tlsca.org1"})
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	userData, err := f.userStore.Load(msp.IdentityIdentifier{MSPID: caClient.orgMSPID, ID: registrarID})
+	if err != nil {
+		t.Fatalf("Failed to load registrar: %v", err)
+	}
+	if !bytes.Equal(userData.EnrollmentCertificate, generatedCertBytes) {
+		t.Fatalf("Expected enrollment with the TLS CA to keep the certificate issued by the default CA")
+	}
+
+	// Credentials issued by the TLS CA are reenrolled with the TLS CA
+	err = f.caClient.Reenroll(registrarID + "@tlsca.org1.example.com")
+	if err != nil {
+		t.Fatalf("Reenroll return error %v", err)
+	}
+	if caServer.LastEnrollmentRequest().CAName != "tlsca.org1" {
+		t.Fatalf("Expected reenrollment with CA tlsca.org1, got %s", caServer.LastEnrollmentRequest().CAName)
+	}
+}
+
+// TestSplitCAChain tests splitting of a CA chain into root and intermediate certificates
+func TestSplitCAChain(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	resp, err := f.caClient.GetCAInfo("")
+	if err != nil {
+		t.Fatalf("GetCAInfo return error %v", err)
+	}
+
+	chain := append(append([]byte{}, resp.CAChain...), '\n')
+	chain = append(chain, generatedCertBytes...)
+	rootCerts, intermediateCerts, err := splitCAChain(chain)
+	if err != nil {
+		t.Fatalf("splitCAChain return error %v", err)
+	}
+	if len(rootCerts) != 1 || len(intermediateCerts) != 1 {
+		t.Fatalf("Expected one root and one intermediate certificate, got %d and %d", len(rootCerts), len(intermediateCerts))
+	}
+	if strings.TrimSpace(string(intermediateCerts[0])) != string(generatedCertBytes) {
+		t.Fatalf("Unexpected intermediate certificate")
+	}
+
+	if _, _, err = splitCAChain(generatedCertBytes); err == nil {
+		t.Fatalf("Expected error for chain without root certificate")
+	}
+	if _, _, err = splitCAChain([]byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")); err == nil {
+		t.Fatalf("Expected error for invalid certificate")
+	}
+}
+
 // TestCAConfigError will test CAClient creation with bad CAConfig
 func TestCAConfigError(t *testing.T) {
 
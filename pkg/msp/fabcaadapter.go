@@ -7,11 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+
+	"github.com/cloudflare/cfssl/csr"
 	"github.com/pkg/errors"
 
 	caapi "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/api"
 	calib "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/lib"
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
+	sdkconfig "github.com/hyperledger/fabric-sdk-go/pkg/core/config"
 	"github.com/hyperledger/fabric-sdk-go/pkg/core/config/endpoint"
 	"github.com/hyperledger/fabric-sdk-go/pkg/msp/api"
 )
@@ -38,16 +46,35 @@ func newFabricCAAdapter(orgName string, cryptoSuite core.CryptoSuite, config cor
 	return a, nil
 }
 
+// newFabricCAAdapterForCA creates an adapter for the CA with the given configuration
+func newFabricCAAdapterForCA(caConfig *core.CAConfig, cryptoSuite core.CryptoSuite, config core.Config) (*fabricCAAdapter, error) {
+
+	caClient, err := createFabricCAClientForCA(caConfig, cryptoSuite, config)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &fabricCAAdapter{
+		config:      config,
+		cryptoSuite: cryptoSuite,
+		caClient:    caClient,
+	}
+	return a, nil
+}
+
 // Enroll handles enrollment.
-func (c *fabricCAAdapter) Enroll(enrollmentID string, enrollmentSecret string) ([]byte, error) {
+func (c *fabricCAAdapter) Enroll(request *api.EnrollmentRequest) ([]byte, error) {
 
-	logger.Debugf("Enrolling user [%s]", enrollmentID)
+	logger.Debugf("Enrolling user [%s]", request.Name)
 
-	// TODO add attributes
 	careq := &caapi.EnrollmentRequest{
-		CAName: c.caClient.Config.CAName,
-		Name:   enrollmentID,
-		Secret: enrollmentSecret,
+		CAName:   c.caName(request.CAName),
+		Name:     request.Name,
+		Secret:   request.Secret,
+		AttrReqs: getCAAttributeRequests(request.AttrReqs),
+		Profile:  request.Profile,
+		Label:    request.Label,
+		CSR:      getCACSRInfo(request.CSR),
 	}
 	caresp, err := c.caClient.Enroll(careq)
 	if err != nil {
@@ -57,12 +84,16 @@ func (c *fabricCAAdapter) Enroll(enrollmentID string, enrollmentSecret string) (
 }
 
 // Reenroll handles re-enrollment
-func (c *fabricCAAdapter) Reenroll(key core.Key, cert []byte) ([]byte, error) {
+func (c *fabricCAAdapter) Reenroll(key core.Key, cert []byte, request *api.ReenrollmentRequest) ([]byte, error) {
 
-	logger.Debugf("Enrolling user [%s]")
+	logger.Debugf("Reenrolling user [%s]", request.Name)
 
 	careq := &caapi.ReenrollmentRequest{
-		CAName: c.caClient.Config.CAName,
+		CAName:   c.caName(request.CAName),
+		AttrReqs: getCAAttributeRequests(request.AttrReqs),
+		Profile:  request.Profile,
+		Label:    request.Label,
+		CSR:      getCACSRInfo(request.CSR),
 	}
 	caidentity, err := c.caClient.NewIdentity(key, cert)
 	if err != nil {
@@ -77,6 +108,14 @@ func (c *fabricCAAdapter) Reenroll(key core.Key, cert []byte) ([]byte, error) {
 	return caresp.Identity.GetECert().Cert(), nil
 }
 
+// caName returns the given CA name or, if empty, the name of the configured CA
+func (c *fabricCAAdapter) caName(caname string) string {
+	if caname != "" {
+		return caname
+	}
+	return c.caClient.Config.CAName
+}
+
 // Register handles user registration
 // key: registrar private key
 // cert: registrar enrollment certificate
@@ -84,11 +123,6 @@ func (c *fabricCAAdapter) Reenroll(key core.Key, cert []byte) ([]byte, error) {
 // Returns Enrolment Secret
 func (c *fabricCAAdapter) Register(key core.Key, cert []byte, request *api.RegistrationRequest) (string, error) {
 	// Contruct request for Fabric CA client
-	var attributes []caapi.Attribute
-	for i := range request.Attributes {
-		attributes = append(attributes, caapi.Attribute{Name: request.
-			Attributes[i].Key, Value: request.Attributes[i].Value})
-	}
 	var req = caapi.RegistrationRequest{
 		CAName:         request.CAName,
 		Name:           request.Name,
@@ -96,7 +130,7 @@ func (c *fabricCAAdapter) Register(key core.Key, cert []byte, request *api.Regis
 		MaxEnrollments: request.MaxEnrollments,
 		Affiliation:    request.Affiliation,
 		Secret:         request.Secret,
-		Attributes:     attributes}
+		Attributes:     getCAAttributes(request.Attributes)}
 
 	registrar, err := c.caClient.NewIdentity(key, cert)
 	if err != nil {
@@ -150,12 +184,361 @@ func (c *fabricCAAdapter) Revoke(key core.Key, cert []byte, request *api.Revocat
 	}, nil
 }
 
-func createFabricCAClient(org string, cryptoSuite core.CryptoSuite, config core.Config) (*calib.Client, error) {
+// GetIdentity returns information about the identity with the given ID
+// key: registrar private key
+// cert: registrar enrollment certificate
+func (c *fabricCAAdapter) GetIdentity(key core.Key, cert []byte, id, caname string) (*api.IdentityResponse, error) {
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
 
-	// Create new Fabric-ca client without configs
-	c := &calib.Client{
-		Config: &calib.ClientConfig{},
+	resp, err := registrar.GetIdentity(id, caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get identity")
+	}
+
+	return &api.IdentityResponse{
+		ID:             resp.ID,
+		Type:           resp.Type,
+		MaxEnrollments: resp.MaxEnrollments,
+		Affiliation:    resp.Affiliation,
+		Attributes:     getAttributes(resp.Attributes),
+		CAName:         resp.CAName,
+	}, nil
+}
+
+// GetAllIdentities returns all of the identities that the registrar is authorized to see
+// key: registrar private key
+// cert: registrar enrollment certificate
+func (c *fabricCAAdapter) GetAllIdentities(key core.Key, cert []byte, caname string) ([]*api.IdentityResponse, error) {
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	var identities []*api.IdentityResponse
+	err = registrar.GetAllIdentities(caname, func(decoder *json.Decoder) error {
+		var identity caapi.IdentityInfo
+		if err := decoder.Decode(&identity); err != nil {
+			return err
+		}
+		identities = append(identities, &api.IdentityResponse{
+			ID:             identity.ID,
+			Type:           identity.Type,
+			MaxEnrollments: identity.MaxEnrollments,
+			Affiliation:    identity.Affiliation,
+			Attributes:     getAttributes(identity.Attributes),
+			CAName:         caname,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get identities")
+	}
+	return identities, nil
+}
+
+// ModifyIdentity modifies an identity
+// key: registrar private key
+// cert: registrar enrollment certificate
+// request: Identity Request
+func (c *fabricCAAdapter) ModifyIdentity(key core.Key, cert []byte, request *api.IdentityRequest) (*api.IdentityResponse, error) {
+	var req = caapi.ModifyIdentityRequest{
+		CAName:         request.CAName,
+		ID:             request.ID,
+		Type:           request.Type,
+		MaxEnrollments: request.MaxEnrollments,
+		Affiliation:    request.Affiliation,
+		Secret:         request.Secret,
+		Attributes:     getCAAttributes(request.Attributes),
+	}
+
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.ModifyIdentity(&req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify identity")
+	}
+
+	return getIdentityResponse(resp), nil
+}
+
+// RemoveIdentity removes an identity
+// key: registrar private key
+// cert: registrar enrollment certificate
+// request: Remove Identity Request
+func (c *fabricCAAdapter) RemoveIdentity(key core.Key, cert []byte, request *api.RemoveIdentityRequest) (*api.IdentityResponse, error) {
+	var req = caapi.RemoveIdentityRequest{
+		CAName: request.CAName,
+		ID:     request.ID,
+		Force:  request.Force,
+	}
+
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.RemoveIdentity(&req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove identity")
+	}
+
+	return getIdentityResponse(resp), nil
+}
+
+// GetAffiliation returns an affiliation along with its sub-affiliations and identities
+// key: registrar private key
+// cert: registrar enrollment certificate
+func (c *fabricCAAdapter) GetAffiliation(key core.Key, cert []byte, affiliation, caname string) (*api.AffiliationResponse, error) {
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.GetAffiliation(affiliation, caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affiliation")
+	}
+
+	return getAffiliationResponse(resp), nil
+}
+
+// GetAllAffiliations returns all of the affiliations that the registrar is authorized to see
+// key: registrar private key
+// cert: registrar enrollment certificate
+func (c *fabricCAAdapter) GetAllAffiliations(key core.Key, cert []byte, caname string) (*api.AffiliationResponse, error) {
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.GetAllAffiliations(caname)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affiliations")
+	}
+
+	return getAffiliationResponse(resp), nil
+}
+
+// AddAffiliation adds an affiliation
+// key: registrar private key
+// cert: registrar enrollment certificate
+// request: Affiliation Request
+func (c *fabricCAAdapter) AddAffiliation(key core.Key, cert []byte, request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	var req = caapi.AddAffiliationRequest{
+		CAName: request.CAName,
+		Name:   request.Name,
+		Force:  request.Force,
+	}
+
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.AddAffiliation(&req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add affiliation")
+	}
+
+	return getAffiliationResponse(resp), nil
+}
+
+// ModifyAffiliation renames an affiliation
+// key: registrar private key
+// cert: registrar enrollment certificate
+// request: Modify Affiliation Request
+func (c *fabricCAAdapter) ModifyAffiliation(key core.Key, cert []byte, request *api.ModifyAffiliationRequest) (*api.AffiliationResponse, error) {
+	var req = caapi.ModifyAffiliationRequest{
+		CAName:  request.CAName,
+		Name:    request.Name,
+		NewName: request.NewName,
+		Force:   request.Force,
+	}
+
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.ModifyAffiliation(&req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to modify affiliation")
+	}
+
+	return getAffiliationResponse(resp), nil
+}
+
+// RemoveAffiliation removes an affiliation
+// key: registrar private key
+// cert: registrar enrollment certificate
+// request: Affiliation Request
+func (c *fabricCAAdapter) RemoveAffiliation(key core.Key, cert []byte, request *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	var req = caapi.RemoveAffiliationRequest{
+		CAName: request.CAName,
+		Name:   request.Name,
+		Force:  request.Force,
+	}
+
+	registrar, err := c.caClient.NewIdentity(key, cert)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create CA signing identity")
+	}
+
+	resp, err := registrar.RemoveAffiliation(&req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to remove affiliation")
+	}
+
+	return getAffiliationResponse(resp), nil
+}
+
+// GetCAInfo returns the name, certificate chain and version of the CA
+// caname: The name of the CA, or empty for the configured CA
+func (c *fabricCAAdapter) GetCAInfo(caname string) (*api.GetCAInfoResponse, error) {
+	req := &caapi.GetCAInfoRequest{
+		CAName: c.caName(caname),
+	}
+
+	resp, err := c.caClient.GetCAInfo(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get CA info")
+	}
+
+	rootCerts, intermediateCerts, err := splitCAChain(resp.CAChain)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid CA chain")
+	}
+
+	return &api.GetCAInfoResponse{
+		CAName:                   resp.CAName,
+		CAChain:                  resp.CAChain,
+		RootCertificates:         rootCerts,
+		IntermediateCertificates: intermediateCerts,
+		Version:                  resp.Version,
+	}, nil
+}
+
+// splitCAChain splits a PEM-encoded CA chain into self-signed root certificates
+// and intermediate certificates, in the same way as the Fabric CA client does
+// when storing the chain in the cacerts and intermediatecerts folders of an MSP.
+func splitCAChain(chain []byte) ([][]byte, [][]byte, error) {
+	var rootCerts, intermediateCerts [][]byte
+	for len(chain) > 0 {
+		var block *pem.Block
+		block, chain = pem.Decode(chain)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to parse CA certificate")
+		}
+
+		certPEM := pem.EncodeToMemory(block)
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil {
+			rootCerts = append(rootCerts, certPEM)
+		} else {
+			intermediateCerts = append(intermediateCerts, certPEM)
+		}
+	}
+	if len(rootCerts) == 0 {
+		return nil, nil, errors.New("no root CA certificate found")
+	}
+	return rootCerts, intermediateCerts, nil
+}
+
+func getAffiliationResponse(resp *caapi.AffiliationResponse) *api.AffiliationResponse {
+	return &api.AffiliationResponse{
+		AffiliationInfo: getAffiliationInfo(resp.AffiliationInfo),
+		CAName:          resp.CAName,
+	}
+}
+
+func getAffiliationInfo(caInfo caapi.AffiliationInfo) api.AffiliationInfo {
+	info := api.AffiliationInfo{Name: caInfo.Name}
+	for _, affiliation := range caInfo.Affiliations {
+		info.Affiliations = append(info.Affiliations, getAffiliationInfo(affiliation))
+	}
+	for _, identity := range caInfo.Identities {
+		info.Identities = append(info.Identities, api.IdentityInfo{
+			ID:             identity.ID,
+			Type:           identity.Type,
+			Affiliation:    identity.Affiliation,
+			Attributes:     getAttributes(identity.Attributes),
+			MaxEnrollments: identity.MaxEnrollments,
+		})
+	}
+	return info
+}
+
+func getIdentityResponse(resp *caapi.IdentityResponse) *api.IdentityResponse {
+	return &api.IdentityResponse{
+		ID:             resp.ID,
+		Type:           resp.Type,
+		MaxEnrollments: resp.MaxEnrollments,
+		Affiliation:    resp.Affiliation,
+		Attributes:     getAttributes(resp.Attributes),
+		CAName:         resp.CAName,
+		Secret:         resp.Secret,
 	}
+}
+
+// getCAAttributes converts attributes to Fabric CA attributes. As with registration,
+// the attribute key is the name of the Fabric CA attribute.
+func getCAAttributes(attributes []api.Attribute) []caapi.Attribute {
+	var caAttributes []caapi.Attribute
+	for _, attribute := range attributes {
+		caAttributes = append(caAttributes, caapi.Attribute{Name: attribute.Key, Value: attribute.Value, ECert: attribute.ECert})
+	}
+	return caAttributes
+}
+
+func getCAAttributeRequests(attrReqs []*api.AttributeRequest) []*caapi.AttributeRequest {
+	var caAttrReqs []*caapi.AttributeRequest
+	for _, attrReq := range attrReqs {
+		caAttrReqs = append(caAttrReqs, &caapi.AttributeRequest{Name: attrReq.Name, Optional: attrReq.Optional})
+	}
+	return caAttrReqs
+}
+
+func getCACSRInfo(csrInfo *api.CSRInfo) *caapi.CSRInfo {
+	if csrInfo == nil {
+		return nil
+	}
+
+	caCSRInfo := &caapi.CSRInfo{
+		Hosts:        csrInfo.Hosts,
+		SerialNumber: csrInfo.SerialNumber,
+	}
+	for _, name := range csrInfo.Names {
+		caCSRInfo.Names = append(caCSRInfo.Names, csr.Name{C: name.C, ST: name.ST, L: name.L, O: name.O, OU: name.OU})
+	}
+	if csrInfo.KeyRequest != nil {
+		caCSRInfo.KeyRequest = &caapi.BasicKeyRequest{Algo: csrInfo.KeyRequest.Algo, Size: csrInfo.KeyRequest.Size}
+	}
+	return caCSRInfo
+}
+
+// getAttributes converts Fabric CA attributes to attributes
+func getAttributes(caAttributes []caapi.Attribute) []api.Attribute {
+	var attributes []api.Attribute
+	for _, attribute := range caAttributes {
+		attributes = append(attributes, api.Attribute{Name: attribute.Name, Key: attribute.Name, Value: attribute.Value, ECert: attribute.ECert})
+	}
+	return attributes
+}
+
+func createFabricCAClient(org string, cryptoSuite core.CryptoSuite, config core.Config) (*calib.Client, error) {
 
 	conf, err := config.CAConfig(org)
 	if err != nil {
@@ -166,29 +549,60 @@ func createFabricCAClient(org string, cryptoSuite core.CryptoSuite, config core.
 		return nil, errors.Errorf("Orgnization %s have no corresponding CA in the configs", org)
 	}
 
-	//set server CAName
-	c.Config.CAName = conf.CAName
-	//set server URL
-	c.Config.URL = endpoint.ToAddress(conf.URL)
 	//certs file list
-	c.Config.TLS.CertFiles, err = config.CAServerCertPaths(org)
+	serverCertFiles, err := config.CAServerCertPaths(org)
 	if err != nil {
 		return nil, err
 	}
 
 	// set key file and cert file
-	c.Config.TLS.Client.CertFile, err = config.CAClientCertPath(org)
+	clientCertFile, err := config.CAClientCertPath(org)
 	if err != nil {
 		return nil, err
 	}
 
-	c.Config.TLS.Client.KeyFile, err = config.CAClientKeyPath(org)
+	clientKeyFile, err := config.CAClientKeyPath(org)
 	if err != nil {
 		return nil, err
 	}
 
+	return newFabricCAClient(conf, serverCertFiles, clientCertFile, clientKeyFile, cryptoSuite, config)
+}
+
+func createFabricCAClientForCA(conf *core.CAConfig, cryptoSuite core.CryptoSuite, config core.Config) (*calib.Client, error) {
+
+	certFiles := strings.Split(conf.TLSCACerts.Path, ",")
+	serverCertFiles := make([]string, len(certFiles))
+	for i, v := range certFiles {
+		serverCertFiles[i] = sdkconfig.SubstPathVars(v)
+	}
+
+	clientCertFile := sdkconfig.SubstPathVars(conf.TLSCACerts.Client.Cert.Path)
+	clientKeyFile := sdkconfig.SubstPathVars(conf.TLSCACerts.Client.Key.Path)
+
+	return newFabricCAClient(conf, serverCertFiles, clientCertFile, clientKeyFile, cryptoSuite, config)
+}
+
+func newFabricCAClient(conf *core.CAConfig, serverCertFiles []string, clientCertFile, clientKeyFile string, cryptoSuite core.CryptoSuite, config core.Config) (*calib.Client, error) {
+
+	// Create new Fabric-ca client without configs
+	c := &calib.Client{
+		Config: &calib.ClientConfig{},
+	}
+
+	//set server CAName
+	c.Config.CAName = conf.CAName
+	//set server URL
+	c.Config.URL = endpoint.ToAddress(conf.URL)
+	//certs file list
+	c.Config.TLS.CertFiles = serverCertFiles
+
+	// set key file and cert file
+	c.Config.TLS.Client.CertFile = clientCertFile
+	c.Config.TLS.Client.KeyFile = clientKeyFile
+
 	// get CAClient configs
-	_, err = config.Client()
+	_, err := config.Client()
 	if err != nil {
 		return nil, err
 	}
@@ -69,8 +69,46 @@ func (mgr *IdentityManager) GetSigningIdentity(id string) (msp.SigningIdentity,
 	return user, nil
 }
 
-// GetUser returns a user for the given user name
+// GetUser returns a user for the given user name.
+// The same User instance is returned for a user name on every call, updated with the
+// credentials currently in the stores, so that clients holding the user pick up a renewed certificate.
+// Users are held until they are dropped with RemoveCachedUser.
 func (mgr *IdentityManager) GetUser(username string) (*User, error) {
+	u, err := mgr.loadUser(username)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.cacheUser(username, u), nil
+}
+
+// cacheUser returns the cached instance of the user, updated with the credentials of the given user
+func (mgr *IdentityManager) cacheUser(username string, u *User) *User {
+	mgr.usersMutex.Lock()
+	defer mgr.usersMutex.Unlock()
+
+	if mgr.users == nil {
+		mgr.users = make(map[string]*User)
+	}
+	cached, ok := mgr.users[username]
+	if !ok || cached.mspID != u.mspID {
+		mgr.users[username] = u
+		return u
+	}
+	cached.setCredentials(u.credentials())
+	return cached
+}
+
+// RemoveCachedUser drops the user from the users held by the identity manager, so that the
+// User instance handed out for the user name is no longer updated with renewed credentials.
+// The credentials in the stores are kept, and GetUser returns a new User instance afterwards.
+func (mgr *IdentityManager) RemoveCachedUser(username string) {
+	mgr.usersMutex.Lock()
+	defer mgr.usersMutex.Unlock()
+
+	delete(mgr.users, username)
+}
+
+func (mgr *IdentityManager) loadUser(username string) (*User, error) {
 
 	u, err := mgr.loadUserFromStore(username)
 	if err != nil {
@@ -9,6 +9,7 @@ package msp
 import (
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -28,6 +29,8 @@ type IdentityManager struct {
 	mspPrivKeyStore core.KVStore
 	mspCertStore    core.KVStore
 	userStore       msp.UserStore
+	users           map[string]*User
+	usersMutex      sync.Mutex
 }
 
 // NewIdentityManager creates a new instance of IdentityManager
@@ -77,6 +80,7 @@ func NewIdentityManager(orgName string, userStore msp.UserStore, cryptoSuite cor
 		mspCertStore:    mspCertStore,
 		embeddedUsers:   orgConfig.Users,
 		userStore:       userStore,
+		users:           make(map[string]*User),
 		// CA Client state is created lazily, when (if) needed
 	}
 	return mgr, nil
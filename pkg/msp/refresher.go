@@ -0,0 +1,352 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	fabricCaUtil "github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/msp/api"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultRefreshWindow is the period before the expiry of a certificate within which it is renewed
+	DefaultRefreshWindow = 24 * time.Hour
+	// DefaultRefreshInterval is the interval at which the expiry of certificates is checked
+	DefaultRefreshInterval = 10 * time.Minute
+	// DefaultRefreshEventBufferSize is the number of refresh events buffered for the consumer
+	DefaultRefreshEventBufferSize = 100
+)
+
+// RefreshEvent notifies the renewal, or a failed attempt to renew, the enrollment certificate of an identity
+type RefreshEvent struct {
+	// ID is the name of the identity
+	ID string
+	// Expiry is the expiry time of the certificate being renewed
+	Expiry time.Time
+	// NewExpiry is the expiry time of the renewed certificate, which is zero if the renewal failed
+	NewExpiry time.Time
+	// Attempt is the number of the renewal attempt, starting at 1
+	Attempt int
+	// Err is the reason of a failed renewal attempt
+	Err error
+	// NextAttempt is the time of the next attempt after a failure. It is zero once the
+	// retries are exhausted, in which case renewal is attempted again at the next check.
+	NextAttempt time.Time
+}
+
+// IdentityRefresher renews the enrollment certificates of identities before they expire.
+//
+// It watches the identities added with Watch. Identities aren't discovered from the user store,
+// which can't be enumerated, nor from the users handed out by the identity manager, so every
+// identity to renew must be watched. When the certificate of an identity enters the refresh window
+// before its expiry, the identity is reenrolled with the CA and the credentials of the User held
+// by the identity manager are replaced, so that existing clients sign with the renewed certificate.
+type IdentityRefresher struct {
+	caClient        api.CAClient
+	identityManager *IdentityManager
+	window          time.Duration
+	interval        time.Duration
+	retryOpts       retry.Opts
+	bufferSize      int
+	events          chan *RefreshEvent
+	mutex           sync.Mutex
+	ids             map[string]bool
+	retries         map[string]*refreshRetry
+	done            chan struct{}
+	wg              sync.WaitGroup
+}
+
+// refreshRetry holds the state of the renewal of an identity after a failure
+type refreshRetry struct {
+	attempts    int
+	nextAttempt time.Time
+}
+
+// RefresherOption describes a functional parameter for NewIdentityRefresher
+type RefresherOption func(*IdentityRefresher) error
+
+// WithRefreshWindow sets the period before the expiry of a certificate within which it is renewed
+func WithRefreshWindow(window time.Duration) RefresherOption {
+	return func(r *IdentityRefresher) error {
+		if window <= 0 {
+			return errors.New("refresh window must be positive")
+		}
+		r.window = window
+		return nil
+	}
+}
+
+// WithRefreshInterval sets the interval at which the expiry of certificates is checked
+func WithRefreshInterval(interval time.Duration) RefresherOption {
+	return func(r *IdentityRefresher) error {
+		if interval <= 0 {
+			return errors.New("refresh interval must be positive")
+		}
+		r.interval = interval
+		return nil
+	}
+}
+
+// WithRetryOpts sets the retry policy of failed renewals. Attempts is the number of
+// retries after a failure, which are spaced by InitialBackoff multiplied by BackoffFactor
+// for each consecutive attempt, up to MaxBackoff. RetryableCodes are not used since
+// every renewal failure is retried.
+func WithRetryOpts(opts retry.Opts) RefresherOption {
+	return func(r *IdentityRefresher) error {
+		r.retryOpts = opts
+		return nil
+	}
+}
+
+// WithEventBufferSize sets the number of refresh events buffered for the consumer of Notifications
+func WithEventBufferSize(size int) RefresherOption {
+	return func(r *IdentityRefresher) error {
+		if size < 0 {
+			return errors.New("event buffer size must not be negative")
+		}
+		r.bufferSize = size
+		return nil
+	}
+}
+
+// NewIdentityRefresher creates a refresher which renews identities of the identity manager with the CA client
+func NewIdentityRefresher(caClient api.CAClient, identityManager *IdentityManager, opts ...RefresherOption) (*IdentityRefresher, error) {
+	if caClient == nil {
+		return nil, errors.New("CA client is required")
+	}
+	if identityManager == nil {
+		return nil, errors.New("identity manager is required")
+	}
+
+	r := &IdentityRefresher{
+		caClient:        caClient,
+		identityManager: identityManager,
+		window:          DefaultRefreshWindow,
+		interval:        DefaultRefreshInterval,
+		retryOpts:       retry.DefaultOpts,
+		bufferSize:      DefaultRefreshEventBufferSize,
+		ids:             make(map[string]bool),
+		retries:         make(map[string]*refreshRetry),
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, errors.WithMessage(err, "failed to create identity refresher")
+		}
+	}
+	r.events = make(chan *RefreshEvent, r.bufferSize)
+
+	return r, nil
+}
+
+// Notifications returns the channel on which refresh events are sent.
+// Events are dropped when the buffer of the channel is full.
+func (r *IdentityRefresher) Notifications() <-chan *RefreshEvent {
+	return r.events
+}
+
+// Watch adds identities whose certificates are renewed
+func (r *IdentityRefresher) Watch(ids ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, id := range ids {
+		r.ids[id] = true
+	}
+}
+
+// Unwatch removes identities whose certificates are renewed
+func (r *IdentityRefresher) Unwatch(ids ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, id := range ids {
+		delete(r.ids, id)
+		delete(r.retries, id)
+	}
+}
+
+// Start starts checking the expiry of certificates in the background
+func (r *IdentityRefresher) Start() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.done != nil {
+		return
+	}
+	r.done = make(chan struct{})
+	r.wg.Add(1)
+	go r.run(r.done)
+}
+
+// Stop stops checking the expiry of certificates
+func (r *IdentityRefresher) Stop() {
+	r.mutex.Lock()
+	if r.done == nil {
+		r.mutex.Unlock()
+		return
+	}
+	close(r.done)
+	r.done = nil
+	r.mutex.Unlock()
+
+	r.wg.Wait()
+}
+
+func (r *IdentityRefresher) run(done chan struct{}) {
+	defer r.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-timer.C:
+			next := r.refresh(now)
+			timer.Reset(next.Sub(time.Now()))
+		}
+	}
+}
+
+// refresh renews the certificates which expire within the refresh window
+// and returns the time of the next check
+func (r *IdentityRefresher) refresh(now time.Time) time.Time {
+	next := now.Add(r.interval)
+	for _, id := range r.watchedIDs() {
+		nextAttempt := r.refreshIdentity(id, now)
+		if !nextAttempt.IsZero() && nextAttempt.Before(next) {
+			next = nextAttempt
+		}
+	}
+	return next
+}
+
+// refreshIdentity renews the certificate of the identity if it expires within the
+// refresh window, and returns the time of the next attempt if the renewal failed
+func (r *IdentityRefresher) refreshIdentity(id string, now time.Time) time.Time {
+	retrying := r.getRetry(id)
+	if retrying != nil && now.Before(retrying.nextAttempt) {
+		return retrying.nextAttempt
+	}
+
+	user, err := r.identityManager.GetUser(id)
+	if err != nil {
+		logger.Warnf("Failed to load identity [%s] to check its expiry: %s", id, err)
+		return time.Time{}
+	}
+	expiry, err := certificateExpiry(user.EnrollmentCertificate())
+	if err != nil {
+		logger.Warnf("Failed to check expiry of identity [%s]: %s", id, err)
+		return time.Time{}
+	}
+	if now.Add(r.window).Before(expiry) {
+		// The certificate isn't due for renewal, or it was renewed elsewhere
+		r.setRetry(id, nil)
+		return time.Time{}
+	}
+
+	event := &RefreshEvent{ID: id, Expiry: expiry, Attempt: 1}
+	if retrying != nil {
+		event.Attempt = retrying.attempts + 1
+	}
+	logger.Infof("Renewing certificate of identity [%s] which expires at %s (attempt %d)", id, expiry, event.Attempt)
+
+	event.NewExpiry, event.Err = r.reenroll(id)
+	if event.Err != nil {
+		if event.Attempt <= r.retryOpts.Attempts {
+			event.NextAttempt = now.Add(r.backoff(event.Attempt))
+			r.setRetry(id, &refreshRetry{attempts: event.Attempt, nextAttempt: event.NextAttempt})
+		} else {
+			r.setRetry(id, nil)
+		}
+		logger.Warnf("Failed to renew certificate of identity [%s]: %s", id, event.Err)
+	} else {
+		r.setRetry(id, nil)
+		logger.Infof("Renewed certificate of identity [%s] which now expires at %s", id, event.NewExpiry)
+	}
+
+	r.notify(event)
+	return event.NextAttempt
+}
+
+// reenroll renews the certificate of the identity and returns the expiry of the new certificate
+func (r *IdentityRefresher) reenroll(id string) (time.Time, error) {
+	err := r.caClient.Reenroll(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Loading the user swaps the renewed credentials into the User held by clients
+	user, err := r.identityManager.GetUser(id)
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, "failed to load renewed identity")
+	}
+	return certificateExpiry(user.EnrollmentCertificate())
+}
+
+// backoff returns the backoff before the retry which follows the given attempt
+func (r *IdentityRefresher) backoff(attempt int) time.Duration {
+	backoff, max := float64(r.retryOpts.InitialBackoff), float64(r.retryOpts.MaxBackoff)
+	for j := 1; j < attempt && backoff < max; j++ {
+		backoff *= r.retryOpts.BackoffFactor
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return time.Duration(backoff)
+}
+
+func (r *IdentityRefresher) notify(event *RefreshEvent) {
+	select {
+	case r.events <- event:
+	default:
+		logger.Warnf("Refresh event buffer is full. Dropping event for identity [%s]", event.ID)
+	}
+}
+
+func (r *IdentityRefresher) watchedIDs() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var ids []string
+	for id := range r.ids {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (r *IdentityRefresher) getRetry(id string) *refreshRetry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.retries[id]
+}
+
+func (r *IdentityRefresher) setRetry(id string, retrying *refreshRetry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if retrying == nil {
+		delete(r.retries, id)
+		return
+	}
+	r.retries[id] = retrying
+}
+
+func certificateExpiry(certPEM []byte) (time.Time, error) {
+	cert, err := fabricCaUtil.GetX509CertificateFromPEM(certPEM)
+	if err != nil {
+		return time.Time{}, errors.WithMessage(err, "failed to parse enrollment certificate")
+	}
+	return cert.NotAfter, nil
+}
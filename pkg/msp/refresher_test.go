@@ -0,0 +1,367 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/hyperledger/fabric-sdk-go/internal/github.com/hyperledger/fabric-ca/util"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/errors/retry"
+	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/msp"
+	apimocks "github.com/hyperledger/fabric-sdk-go/pkg/msp/test/mockmspapi"
+	"github.com/pkg/errors"
+)
+
+// TestIdentityRefresher tests that identities are reenrolled within the refresh window
+func TestIdentityRefresher(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	username := createRandomName()
+	err := f.caClient.Enroll(username, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+
+	user, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+	expiry, err := certificateExpiry(user.EnrollmentCertificate())
+	if err != nil {
+		t.Fatalf("certificateExpiry return error %v", err)
+	}
+
+	refresher, err := NewIdentityRefresher(f.caClient, f.identityManager, WithRefreshWindow(time.Hour))
+	if err != nil {
+		t.Fatalf("NewIdentityRefresher return error %v", err)
+	}
+
+	// Identities handed out by the identity manager aren't renewed unless they are watched
+	refresher.refresh(expiry.Add(-30 * time.Minute))
+	if event := receiveRefreshEvent(refresher); event != nil {
+		t.Fatalf("Expected no refresh event for an identity which isn't watched, got %v", event)
+	}
+	refresher.Watch(username)
+
+	// Outside the refresh window
+	next := refresher.refresh(expiry.Add(-2 * time.Hour))
+	if !next.Equal(expiry.Add(-2 * time.Hour).Add(DefaultRefreshInterval)) {
+		t.Fatalf("Expected next check after the refresh interval, got %s", next)
+	}
+	if event := receiveRefreshEvent(refresher); event != nil {
+		t.Fatalf("Expected no refresh event outside the refresh window, got %v", event)
+	}
+
+	// Within the refresh window
+	refresher.refresh(expiry.Add(-30 * time.Minute))
+	event := receiveRefreshEvent(refresher)
+	if event == nil {
+		t.Fatalf("Expected refresh event within the refresh window")
+	}
+	if event.ID != username || event.Err != nil || event.Attempt != 1 {
+		t.Fatalf("Unexpected refresh event %v", event)
+	}
+	if !event.Expiry.Equal(expiry) || event.NewExpiry.IsZero() {
+		t.Fatalf("Unexpected expiry in refresh event %v", event)
+	}
+
+	// Clients holding the user still hold the instance used by the identity manager
+	renewed, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+	if renewed != user {
+		t.Fatalf("Expected the identity manager to return the same user instance")
+	}
+	if err := checkSigningIdentity(f.identityManager, username); err != nil {
+		t.Fatalf("checkSigningIdentity failed: %v", err)
+	}
+}
+
+// TestIdentityRefresherRetry tests that failed renewals are retried with the retry options
+func TestIdentityRefresherRetry(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	username := createRandomName()
+	err := f.caClient.Enroll(username, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCAClient := apimocks.NewMockCAClient(mockCtrl)
+	mockCAClient.EXPECT().Reenroll(username).Return(errors.New("reenroll failed")).Times(3)
+
+	retryOpts := retry.Opts{
+		Attempts:       1,
+		InitialBackoff: time.Minute,
+		MaxBackoff:     time.Hour,
+		BackoffFactor:  2,
+	}
+	refresher, err := NewIdentityRefresher(mockCAClient, f.identityManager, WithRefreshWindow(100*365*24*time.Hour), WithRetryOpts(retryOpts))
+	if err != nil {
+		t.Fatalf("NewIdentityRefresher return error %v", err)
+	}
+	refresher.Watch(username)
+
+	// First attempt fails and schedules a retry
+	now := time.Now()
+	next := refresher.refresh(now)
+	if !next.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Expected next check at the retry backoff, got %s", next)
+	}
+	event := receiveRefreshEvent(refresher)
+	if event == nil || event.Err == nil || event.Attempt != 1 || !event.NextAttempt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("Unexpected refresh event %v", event)
+	}
+
+	// No attempt before the backoff
+	refresher.refresh(now.Add(30 * time.Second))
+	if event := receiveRefreshEvent(refresher); event != nil {
+		t.Fatalf("Expected no refresh event before the retry backoff, got %v", event)
+	}
+
+	// Retry fails and exhausts the attempts
+	refresher.refresh(now.Add(time.Minute))
+	event = receiveRefreshEvent(refresher)
+	if event == nil || event.Err == nil || event.Attempt != 2 || !event.NextAttempt.IsZero() {
+		t.Fatalf("Unexpected refresh event %v", event)
+	}
+
+	// Renewal starts over at the next check
+	refresher.refresh(now.Add(2 * time.Minute))
+	event = receiveRefreshEvent(refresher)
+	if event == nil || event.Err == nil || event.Attempt != 1 {
+		t.Fatalf("Unexpected refresh event %v", event)
+	}
+}
+
+// TestIdentityRefresherStartStop tests renewal in the background
+func TestIdentityRefresherStartStop(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	username := createRandomName()
+	err := f.caClient.Enroll(username, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCAClient := apimocks.NewMockCAClient(mockCtrl)
+	mockCAClient.EXPECT().Reenroll(username).Return(nil).MinTimes(1)
+
+	refresher, err := NewIdentityRefresher(mockCAClient, f.identityManager, WithRefreshWindow(100*365*24*time.Hour), WithRefreshInterval(time.Hour))
+	if err != nil {
+		t.Fatalf("NewIdentityRefresher return error %v", err)
+	}
+	refresher.Watch(username)
+	refresher.Start()
+	defer refresher.Stop()
+
+	select {
+	case event := <-refresher.Notifications():
+		if event.ID != username || event.Err != nil {
+			t.Fatalf("Unexpected refresh event %v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for refresh event")
+	}
+}
+
+// TestIdentityRefresherUnwatch tests that identities which are no longer watched aren't renewed
+func TestIdentityRefresherUnwatch(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	username := createRandomName()
+	err := f.caClient.Enroll(username, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockCAClient := apimocks.NewMockCAClient(mockCtrl)
+	mockCAClient.EXPECT().Reenroll(username).Return(errors.New("reenroll failed")).Times(1)
+
+	refresher, err := NewIdentityRefresher(mockCAClient, f.identityManager, WithRefreshWindow(100*365*24*time.Hour))
+	if err != nil {
+		t.Fatalf("NewIdentityRefresher return error %v", err)
+	}
+	refresher.Watch(username)
+
+	now := time.Now()
+	refresher.refresh(now)
+	if event := receiveRefreshEvent(refresher); event == nil || event.Err == nil {
+		t.Fatalf("Unexpected refresh event %v", event)
+	}
+
+	// The pending retry is dropped along with the identity
+	refresher.Unwatch(username)
+	if len(refresher.watchedIDs()) != 0 || refresher.getRetry(username) != nil {
+		t.Fatalf("Expected the identity to be unwatched")
+	}
+	refresher.refresh(now.Add(time.Hour))
+	if event := receiveRefreshEvent(refresher); event != nil {
+		t.Fatalf("Expected no refresh event for an unwatched identity, got %v", event)
+	}
+}
+
+func TestIdentityRefresherOptions(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	if _, err := NewIdentityRefresher(nil, f.identityManager); err == nil {
+		t.Fatalf("Expected error without CA client")
+	}
+	if _, err := NewIdentityRefresher(f.caClient, nil); err == nil {
+		t.Fatalf("Expected error without identity manager")
+	}
+	if _, err := NewIdentityRefresher(f.caClient, f.identityManager, WithRefreshWindow(0)); err == nil {
+		t.Fatalf("Expected error with invalid refresh window")
+	}
+	if _, err := NewIdentityRefresher(f.caClient, f.identityManager, WithRefreshInterval(-time.Second)); err == nil {
+		t.Fatalf("Expected error with invalid refresh interval")
+	}
+	if _, err := NewIdentityRefresher(f.caClient, f.identityManager, WithEventBufferSize(-1)); err == nil {
+		t.Fatalf("Expected error with invalid event buffer size")
+	}
+
+	refresher, err := NewIdentityRefresher(f.caClient, f.identityManager, WithRetryOpts(retry.Opts{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+		BackoffFactor:  2,
+	}))
+	if err != nil {
+		t.Fatalf("NewIdentityRefresher return error %v", err)
+	}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, backoff := range expected {
+		if b := refresher.backoff(i + 1); b != backoff {
+			t.Fatalf("Expected backoff %s for attempt %d, got %s", backoff, i+1, b)
+		}
+	}
+}
+
+// TestGetUserSwapsCredentials tests that the identity manager updates the users it handed out
+func TestGetUserSwapsCredentials(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	orgMSPID := mspIDByOrgName(t, f.config, org1)
+
+	// An enrolled user provides a certificate whose key is in the key store
+	enrolledUsername := createRandomName()
+	err := f.caClient.Enroll(enrolledUsername, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	enrolledUserData, err := f.userStore.Load(msp.IdentityIdentifier{MSPID: orgMSPID, ID: enrolledUsername})
+	if err != nil {
+		t.Fatalf("Failed to load enrolled user: %v", err)
+	}
+
+	_, err = util.ImportBCCSPKeyFromPEMBytes(generatedKeyBytes, f.cryptoSuite, false)
+	if err != nil {
+		t.Fatalf("Failed to import key: %v", err)
+	}
+	username := createRandomName()
+	err = f.userStore.Store(&msp.UserData{MSPID: orgMSPID, ID: username, EnrollmentCertificate: generatedCertBytes})
+	if err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+	user, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+
+	// Replace the certificate in the store
+	err = f.userStore.Store(&msp.UserData{MSPID: orgMSPID, ID: username, EnrollmentCertificate: enrolledUserData.EnrollmentCertificate})
+	if err != nil {
+		t.Fatalf("Failed to store user: %v", err)
+	}
+	renewed, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+	if renewed != user {
+		t.Fatalf("Expected the identity manager to return the same user instance")
+	}
+	if !bytes.Equal(user.EnrollmentCertificate(), enrolledUserData.EnrollmentCertificate) {
+		t.Fatalf("Expected the user to hold the new certificate")
+	}
+	enrolledUser, err := f.identityManager.GetUser(enrolledUsername)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+	if !bytes.Equal(user.PrivateKey().SKI(), enrolledUser.PrivateKey().SKI()) {
+		t.Fatalf("Expected the user to hold the key of the new certificate")
+	}
+}
+
+// TestRemoveCachedUser tests that users dropped from the identity manager are no longer updated
+func TestRemoveCachedUser(t *testing.T) {
+
+	f := textFixture{}
+	f.setup("")
+	defer f.close()
+
+	username := createRandomName()
+	err := f.caClient.Enroll(username, "enrollmentSecret")
+	if err != nil {
+		t.Fatalf("Enroll return error %v", err)
+	}
+	user, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+
+	f.identityManager.RemoveCachedUser(username)
+	if _, ok := f.identityManager.users[username]; ok {
+		t.Fatalf("Expected the user to be dropped from the identity manager")
+	}
+
+	loaded, err := f.identityManager.GetUser(username)
+	if err != nil {
+		t.Fatalf("GetUser return error %v", err)
+	}
+	if loaded == user {
+		t.Fatalf("Expected the identity manager to return a new user instance")
+	}
+	if !bytes.Equal(loaded.EnrollmentCertificate(), user.EnrollmentCertificate()) {
+		t.Fatalf("Expected the credentials of the user to be kept in the stores")
+	}
+}
+
+func receiveRefreshEvent(refresher *IdentityRefresher) *RefreshEvent {
+	select {
+	case event := <-refresher.Notifications():
+		return event
+	default:
+		return nil
+	}
+}
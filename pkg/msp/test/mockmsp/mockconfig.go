@@ -42,6 +42,11 @@ func (c *MockConfig) CAConfig(org string) (*core.CAConfig, error) {
 	}, nil
 }
 
+// CAConfigByID return ca configuration
+func (c *MockConfig) CAConfigByID(caID string) (*core.CAConfig, error) {
+	return c.CAConfig("")
+}
+
 //CAServerCertPems Read configuration option for the server certificate embedded pems
 func (c *MockConfig) CAServerCertPems(org string) ([]string, error) {
 	return nil, nil
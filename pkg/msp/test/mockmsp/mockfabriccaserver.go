@@ -7,8 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package mockmsp
 
 import (
+	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"time"
 
@@ -47,6 +53,24 @@ AiaiI2BjxnL3/TetJ8iFJYZyWvK//an13WV/AiARBJd/pI5A7KZgQxJhXmmR8bie
 XdsmTcdRvJ3TS/6HCA==
 -----END CERTIFICATE-----`
 
+// Certificate of the CA which issued the ecert. It is
+// returned as the CA chain of the mock server.
+const caCert = `-----BEGIN CERTIFICATE-----
+MIICQzCCAemgAwIBAgIQYZpqGmcswky9Iy1SHBIm8zAKBggqhkjOPQQDAjBzMQsw
+CQYDVQQGEwJVUzETMBEGA1UECBMKQ2FsaWZvcm5pYTEWMBQGA1UEBxMNU2FuIEZy
+YW5jaXNjbzEZMBcGA1UEChMQb3JnMS5leGFtcGxlLmNvbTEcMBoGA1UEAxMTY2Eu
+b3JnMS5leGFtcGxlLmNvbTAeFw0xNzA3MjgxNDI3MjBaFw0yNzA3MjYxNDI3MjBa
+MHMxCzAJBgNVBAYTAlVTMRMwEQYDVQQIEwpDYWxpZm9ybmlhMRYwFAYDVQQHEw1T
+YW4gRnJhbmNpc2NvMRkwFwYDVQQKExBvcmcxLmV4YW1wbGUuY29tMRwwGgYDVQQD
+ExNjYS5vcmcxLmV4YW1wbGUuY29tMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE
+3WtPeUzseT9Wp9VUtkx6mF84plyhgTlI2pbrHa4wYKFSoQGmrt83px6Q5Qu9EmhW
+1y6Fr8DxkHvvg1NX0bCGyaNfMF0wDgYDVR0PAQH/BAQDAgGmMA8GA1UdJQQIMAYG
+BFUdJQAwDwYDVR0TAQH/BAUwAwEB/zApBgNVHQ4EIgQgh5HRNj6JUV+a+gQrBpOi
+xwS7jdldKPl9NUmiuePENS0wCgYIKoZIzj0EAwIDSAAwRQIhALUmxdk1FP8uL1so
+nLdU8D8CS2PW5DLbaMjhR1KVK3b7AiAD5vkgX1PXPRsFFYlbkp/Y+nDdDy+mk3N7
+K7xCT/QO7Q==
+-----END CERTIFICATE-----`
+
 // The enrollment response from the server
 type enrollmentResponseNet struct {
 	// Base64 encoded PEM-encoded ECert
@@ -61,13 +85,19 @@ type serverInfoResponseNet struct {
 	CAName string
 	// Base64 encoding of PEM-encoded certificate chain
 	CAChain string
+	// Version of the server
+	Version string
 }
 
 // MockFabricCAServer is a mock for FabricCAServer
 type MockFabricCAServer struct {
-	address     string
-	cryptoSuite core.CryptoSuite
-	running     bool
+	address      string
+	cryptoSuite  core.CryptoSuite
+	running      bool
+	mutex        sync.RWMutex
+	identities   map[string]api.IdentityInfo
+	affiliations map[string]bool
+	lastEnroll   *api.EnrollmentRequestNet
 }
 
 // Start fabric CA mock server
@@ -80,11 +110,18 @@ func (s *MockFabricCAServer) Start(lis net.Listener, cryptoSuite core.CryptoSuit
 	addr := lis.Addr().String()
 	s.address = addr
 	s.cryptoSuite = cryptoSuite
+	s.identities = make(map[string]api.IdentityInfo)
+	s.affiliations = map[string]bool{"org1": true, "org1.department1": true, "org2": true, "org2.department1": true}
 
 	// Register request handlers
 	http.HandleFunc("/register", s.register)
 	http.HandleFunc("/enroll", s.enroll)
 	http.HandleFunc("/reenroll", s.enroll)
+	http.HandleFunc("/cainfo", s.caInfo)
+	http.HandleFunc("/identities", s.handleIdentities)
+	http.HandleFunc("/identities/", s.handleIdentity)
+	http.HandleFunc("/affiliations", s.handleAffiliations)
+	http.HandleFunc("/affiliations/", s.handleAffiliation)
 
 	server := &http.Server{
 		Addr:      addr,
@@ -109,6 +146,13 @@ func (s *MockFabricCAServer) Running() bool {
 	return s.running
 }
 
+// LastEnrollmentRequest returns the last enrollment or reenrollment request received by the mock server
+func (s *MockFabricCAServer) LastEnrollmentRequest() *api.EnrollmentRequestNet {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.lastEnroll
+}
+
 func (s *MockFabricCAServer) addKeyToKeyStore(privateKey []byte) error {
 	// Import private key that matches the cert we will return
 	// from this mock service, so it can be looked up by SKI from the cert
@@ -121,20 +165,299 @@ func (s *MockFabricCAServer) addKeyToKeyStore(privateKey []byte) error {
 
 // Register user
 func (s *MockFabricCAServer) register(w http.ResponseWriter, req *http.Request) {
+	regReq := api.RegistrationRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&regReq); err != nil {
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mutex.Lock()
+	s.identities[regReq.Name] = api.IdentityInfo{
+		ID:             regReq.Name,
+		Type:           regReq.Type,
+		Affiliation:    regReq.Affiliation,
+		Attributes:     regReq.Attributes,
+		MaxEnrollments: regReq.MaxEnrollments,
+	}
+	s.mutex.Unlock()
+
 	resp := &api.RegistrationResponseNet{RegistrationResponse: api.RegistrationResponse{Secret: "mockSecretValue"}}
 	cfsslapi.SendResponse(w, resp)
 }
 
+// List the registered identities
+func (s *MockFabricCAServer) handleIdentities(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+		return
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	resp := &api.GetAllIDsResponse{CAName: req.URL.Query().Get("ca")}
+	for _, identity := range s.identities {
+		resp.Identities = append(resp.Identities, identity)
+	}
+	cfsslapi.SendResponse(w, resp)
+}
+
+// Get, modify or remove a registered identity
+func (s *MockFabricCAServer) handleIdentity(w http.ResponseWriter, req *http.Request) {
+	id := strings.TrimPrefix(req.URL.Path, "/identities/")
+	caname := req.URL.Query().Get("ca")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	identity, ok := s.identities[id]
+	if !ok {
+		sendError(w, http.StatusNotFound, fmt.Sprintf("identity '%s' not found", id))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		cfsslapi.SendResponse(w, &api.GetIDResponse{
+			ID:             identity.ID,
+			Type:           identity.Type,
+			Affiliation:    identity.Affiliation,
+			Attributes:     identity.Attributes,
+			MaxEnrollments: identity.MaxEnrollments,
+			CAName:         caname,
+		})
+	case http.MethodPut:
+		modReq := api.ModifyIdentityRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&modReq); err != nil {
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if modReq.Type != "" {
+			identity.Type = modReq.Type
+		}
+		if modReq.Affiliation != "" {
+			identity.Affiliation = modReq.Affiliation
+		}
+		if modReq.Attributes != nil {
+			identity.Attributes = modReq.Attributes
+		}
+		if modReq.MaxEnrollments != 0 {
+			identity.MaxEnrollments = modReq.MaxEnrollments
+		}
+		s.identities[id] = identity
+		cfsslapi.SendResponse(w, identityResponse(identity, modReq.Secret, caname))
+	case http.MethodDelete:
+		delete(s.identities, id)
+		cfsslapi.SendResponse(w, identityResponse(identity, "", caname))
+	default:
+		sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+	}
+}
+
+// List all affiliations or add an affiliation
+func (s *MockFabricCAServer) handleAffiliations(w http.ResponseWriter, req *http.Request) {
+	caname := req.URL.Query().Get("ca")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch req.Method {
+	case http.MethodGet:
+		cfsslapi.SendResponse(w, &api.AffiliationResponse{AffiliationInfo: s.affiliationInfo(""), CAName: caname})
+	case http.MethodPost:
+		addReq := api.AddAffiliationRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&addReq); err != nil {
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if s.affiliations[addReq.Name] {
+			sendError(w, http.StatusBadRequest, fmt.Sprintf("affiliation '%s' already exists", addReq.Name))
+			return
+		}
+		parents := parentAffiliations(addReq.Name)
+		force, _ := strconv.ParseBool(req.URL.Query().Get("force"))
+		for _, parent := range parents {
+			if !s.affiliations[parent] && !force {
+				sendError(w, http.StatusBadRequest, fmt.Sprintf("parent affiliation '%s' doesn't exist", parent))
+				return
+			}
+		}
+		for _, parent := range parents {
+			s.affiliations[parent] = true
+		}
+		s.affiliations[addReq.Name] = true
+		cfsslapi.SendResponse(w, &api.AffiliationResponse{AffiliationInfo: api.AffiliationInfo{Name: addReq.Name}, CAName: addReq.CAName})
+	default:
+		sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+	}
+}
+
+// Get, rename or remove an affiliation
+func (s *MockFabricCAServer) handleAffiliation(w http.ResponseWriter, req *http.Request) {
+	name := strings.TrimPrefix(req.URL.Path, "/affiliations/")
+	caname := req.URL.Query().Get("ca")
+	force, _ := strconv.ParseBool(req.URL.Query().Get("force"))
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.affiliations[name] {
+		sendError(w, http.StatusNotFound, fmt.Sprintf("affiliation '%s' not found", name))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		cfsslapi.SendResponse(w, &api.AffiliationResponse{AffiliationInfo: s.affiliationInfo(name), CAName: caname})
+	case http.MethodPut:
+		modReq := api.ModifyAffiliationRequest{}
+		if err := json.NewDecoder(req.Body).Decode(&modReq); err != nil {
+			sendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if s.affiliations[modReq.NewName] {
+			sendError(w, http.StatusBadRequest, fmt.Sprintf("affiliation '%s' already exists", modReq.NewName))
+			return
+		}
+		if len(s.affiliatedIdentities(name)) > 0 && !force {
+			sendError(w, http.StatusBadRequest, fmt.Sprintf("identities are affected by the renaming of affiliation '%s'", name))
+			return
+		}
+		for affiliation := range s.affiliations {
+			if isAffiliation(affiliation, name) {
+				delete(s.affiliations, affiliation)
+				s.affiliations[modReq.NewName+strings.TrimPrefix(affiliation, name)] = true
+			}
+		}
+		for _, identity := range s.affiliatedIdentities(name) {
+			identity.Affiliation = modReq.NewName + strings.TrimPrefix(identity.Affiliation, name)
+			s.identities[identity.ID] = identity
+		}
+		cfsslapi.SendResponse(w, &api.AffiliationResponse{AffiliationInfo: s.affiliationInfo(modReq.NewName), CAName: caname})
+	case http.MethodDelete:
+		info := s.affiliationInfo(name)
+		if (len(info.Affiliations) > 0 || len(s.affiliatedIdentities(name)) > 0) && !force {
+			sendError(w, http.StatusBadRequest, fmt.Sprintf("affiliation '%s' has sub-affiliations or identities", name))
+			return
+		}
+		for affiliation := range s.affiliations {
+			if isAffiliation(affiliation, name) {
+				delete(s.affiliations, affiliation)
+			}
+		}
+		for _, identity := range s.affiliatedIdentities(name) {
+			delete(s.identities, identity.ID)
+		}
+		cfsslapi.SendResponse(w, &api.AffiliationResponse{AffiliationInfo: info, CAName: caname})
+	default:
+		sendError(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not supported", req.Method))
+	}
+}
+
+// affiliationInfo returns the tree of the given affiliation. The empty name is the root of all affiliations.
+func (s *MockFabricCAServer) affiliationInfo(name string) api.AffiliationInfo {
+	info := api.AffiliationInfo{Name: name}
+	var children []string
+	for affiliation := range s.affiliations {
+		if (name == "" && !strings.Contains(affiliation, ".")) || (name != "" && strings.HasPrefix(affiliation, name+".") && !strings.Contains(strings.TrimPrefix(affiliation, name+"."), ".")) {
+			children = append(children, affiliation)
+		}
+	}
+	sort.Strings(children)
+	for _, child := range children {
+		info.Affiliations = append(info.Affiliations, s.affiliationInfo(child))
+	}
+	for _, identity := range s.identities {
+		if name != "" && identity.Affiliation == name {
+			info.Identities = append(info.Identities, identity)
+		}
+	}
+	return info
+}
+
+// affiliatedIdentities returns the identities which belong to the affiliation or to one of its sub-affiliations
+func (s *MockFabricCAServer) affiliatedIdentities(name string) []api.IdentityInfo {
+	var identities []api.IdentityInfo
+	for _, identity := range s.identities {
+		if isAffiliation(identity.Affiliation, name) {
+			identities = append(identities, identity)
+		}
+	}
+	return identities
+}
+
+// isAffiliation returns true if the affiliation is the given affiliation or one of its sub-affiliations
+func isAffiliation(affiliation, name string) bool {
+	return affiliation == name || strings.HasPrefix(affiliation, name+".")
+}
+
+func parentAffiliations(name string) []string {
+	var parents []string
+	parts := strings.Split(name, ".")
+	for i := 1; i < len(parts); i++ {
+		parents = append(parents, strings.Join(parts[:i], "."))
+	}
+	return parents
+}
+
+func identityResponse(identity api.IdentityInfo, secret, caname string) *api.IdentityResponse {
+	return &api.IdentityResponse{
+		ID:             identity.ID,
+		Type:           identity.Type,
+		Affiliation:    identity.Affiliation,
+		Attributes:     identity.Attributes,
+		MaxEnrollments: identity.MaxEnrollments,
+		Secret:         secret,
+		CAName:         caname,
+	}
+}
+
+// sendError sends an error response in the format of the Fabric CA server
+func sendError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(cfsslapi.NewErrorResponse(message, status)); err != nil {
+		logger.Warnf("Failed to send error response: %s", err)
+	}
+}
+
 // Enroll user
 func (s *MockFabricCAServer) enroll(w http.ResponseWriter, req *http.Request) {
+	enrollReq := &api.EnrollmentRequestNet{}
+	if err := json.NewDecoder(req.Body).Decode(enrollReq); err != nil {
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mutex.Lock()
+	s.lastEnroll = enrollReq
+	s.mutex.Unlock()
+
 	s.addKeyToKeyStore([]byte(privateKey))
 	resp := &enrollmentResponseNet{Cert: util.B64Encode([]byte(ecert))}
 	fillCAInfo(&resp.ServerInfo)
 	cfapi.SendResponse(w, resp)
 }
 
+// Get CA info
+func (s *MockFabricCAServer) caInfo(w http.ResponseWriter, req *http.Request) {
+	caInfoReq := api.GetCAInfoRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&caInfoReq); err != nil {
+		sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp := &serverInfoResponseNet{}
+	fillCAInfo(resp)
+	if caInfoReq.CAName != "" {
+		resp.CAName = caInfoReq.CAName
+	}
+	cfapi.SendResponse(w, resp)
+}
+
 // Fill the CA info structure appropriately
 func fillCAInfo(info *serverInfoResponseNet) {
 	info.CAName = "MockCAName"
-	info.CAChain = util.B64Encode([]byte("MockCAChain"))
+	info.CAChain = util.B64Encode([]byte(caCert))
+	info.Version = "1.1.0"
 }
@@ -34,6 +34,19 @@ func (m *MockCAClient) EXPECT() *MockCAClientMockRecorder {
 	return m.recorder
 }
 
+// AddAffiliation mocks base method
+func (m *MockCAClient) AddAffiliation(arg0 *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	ret := m.ctrl.Call(m, "AddAffiliation", arg0)
+	ret0, _ := ret[0].(*api.AffiliationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAffiliation indicates an expected call of AddAffiliation
+func (mr *MockCAClientMockRecorder) AddAffiliation(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAffiliation", reflect.TypeOf((*MockCAClient)(nil).AddAffiliation), arg0)
+}
+
 // Enroll mocks base method
 func (m *MockCAClient) Enroll(arg0, arg1 string) error {
 	ret := m.ctrl.Call(m, "Enroll", arg0, arg1)
@@ -46,6 +59,109 @@ func (mr *MockCAClientMockRecorder) Enroll(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockCAClient)(nil).Enroll), arg0, arg1)
 }
 
+// EnrollWithRequest mocks base method
+func (m *MockCAClient) EnrollWithRequest(arg0 *api.EnrollmentRequest) error {
+	ret := m.ctrl.Call(m, "EnrollWithRequest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollWithRequest indicates an expected call of EnrollWithRequest
+func (mr *MockCAClientMockRecorder) EnrollWithRequest(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollWithRequest", reflect.TypeOf((*MockCAClient)(nil).EnrollWithRequest), arg0)
+}
+
+// GetAffiliation mocks base method
+func (m *MockCAClient) GetAffiliation(arg0, arg1 string) (*api.AffiliationResponse, error) {
+	ret := m.ctrl.Call(m, "GetAffiliation", arg0, arg1)
+	ret0, _ := ret[0].(*api.AffiliationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAffiliation indicates an expected call of GetAffiliation
+func (mr *MockCAClientMockRecorder) GetAffiliation(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAffiliation", reflect.TypeOf((*MockCAClient)(nil).GetAffiliation), arg0, arg1)
+}
+
+// GetAllAffiliations mocks base method
+func (m *MockCAClient) GetAllAffiliations(arg0 string) (*api.AffiliationResponse, error) {
+	ret := m.ctrl.Call(m, "GetAllAffiliations", arg0)
+	ret0, _ := ret[0].(*api.AffiliationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllAffiliations indicates an expected call of GetAllAffiliations
+func (mr *MockCAClientMockRecorder) GetAllAffiliations(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAffiliations", reflect.TypeOf((*MockCAClient)(nil).GetAllAffiliations), arg0)
+}
+
+// GetAllIdentities mocks base method
+func (m *MockCAClient) GetAllIdentities(arg0 string) ([]*api.IdentityResponse, error) {
+	ret := m.ctrl.Call(m, "GetAllIdentities", arg0)
+	ret0, _ := ret[0].([]*api.IdentityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllIdentities indicates an expected call of GetAllIdentities
+func (mr *MockCAClientMockRecorder) GetAllIdentities(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllIdentities", reflect.TypeOf((*MockCAClient)(nil).GetAllIdentities), arg0)
+}
+
+// GetCAInfo mocks base method
+func (m *MockCAClient) GetCAInfo(arg0 string) (*api.GetCAInfoResponse, error) {
+	ret := m.ctrl.Call(m, "GetCAInfo", arg0)
+	ret0, _ := ret[0].(*api.GetCAInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCAInfo indicates an expected call of GetCAInfo
+func (mr *MockCAClientMockRecorder) GetCAInfo(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCAInfo", reflect.TypeOf((*MockCAClient)(nil).GetCAInfo), arg0)
+}
+
+// GetIdentity mocks base method
+func (m *MockCAClient) GetIdentity(arg0, arg1 string) (*api.IdentityResponse, error) {
+	ret := m.ctrl.Call(m, "GetIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*api.IdentityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity
+func (mr *MockCAClientMockRecorder) GetIdentity(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockCAClient)(nil).GetIdentity), arg0, arg1)
+}
+
+// ModifyAffiliation mocks base method
+func (m *MockCAClient) ModifyAffiliation(arg0 *api.ModifyAffiliationRequest) (*api.AffiliationResponse, error) {
+	ret := m.ctrl.Call(m, "ModifyAffiliation", arg0)
+	ret0, _ := ret[0].(*api.AffiliationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyAffiliation indicates an expected call of ModifyAffiliation
+func (mr *MockCAClientMockRecorder) ModifyAffiliation(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyAffiliation", reflect.TypeOf((*MockCAClient)(nil).ModifyAffiliation), arg0)
+}
+
+// ModifyIdentity mocks base method
+func (m *MockCAClient) ModifyIdentity(arg0 *api.IdentityRequest) (*api.IdentityResponse, error) {
+	ret := m.ctrl.Call(m, "ModifyIdentity", arg0)
+	ret0, _ := ret[0].(*api.IdentityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyIdentity indicates an expected call of ModifyIdentity
+func (mr *MockCAClientMockRecorder) ModifyIdentity(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyIdentity", reflect.TypeOf((*MockCAClient)(nil).ModifyIdentity), arg0)
+}
+
 // Reenroll mocks base method
 func (m *MockCAClient) Reenroll(arg0 string) error {
 	ret := m.ctrl.Call(m, "Reenroll", arg0)
@@ -58,6 +174,18 @@ func (mr *MockCAClientMockRecorder) Reenroll(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reenroll", reflect.TypeOf((*MockCAClient)(nil).Reenroll), arg0)
 }
 
+// ReenrollWithRequest mocks base method
+func (m *MockCAClient) ReenrollWithRequest(arg0 *api.ReenrollmentRequest) error {
+	ret := m.ctrl.Call(m, "ReenrollWithRequest", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReenrollWithRequest indicates an expected call of ReenrollWithRequest
+func (mr *MockCAClientMockRecorder) ReenrollWithRequest(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReenrollWithRequest", reflect.TypeOf((*MockCAClient)(nil).ReenrollWithRequest), arg0)
+}
+
 // Register mocks base method
 func (m *MockCAClient) Register(arg0 *api.RegistrationRequest) (string, error) {
 	ret := m.ctrl.Call(m, "Register", arg0)
@@ -71,6 +199,32 @@ func (mr *MockCAClientMockRecorder) Register(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockCAClient)(nil).Register), arg0)
 }
 
+// RemoveAffiliation mocks base method
+func (m *MockCAClient) RemoveAffiliation(arg0 *api.AffiliationRequest) (*api.AffiliationResponse, error) {
+	ret := m.ctrl.Call(m, "RemoveAffiliation", arg0)
+	ret0, _ := ret[0].(*api.AffiliationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveAffiliation indicates an expected call of RemoveAffiliation
+func (mr *MockCAClientMockRecorder) RemoveAffiliation(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAffiliation", reflect.TypeOf((*MockCAClient)(nil).RemoveAffiliation), arg0)
+}
+
+// RemoveIdentity mocks base method
+func (m *MockCAClient) RemoveIdentity(arg0 *api.RemoveIdentityRequest) (*api.IdentityResponse, error) {
+	ret := m.ctrl.Call(m, "RemoveIdentity", arg0)
+	ret0, _ := ret[0].(*api.IdentityResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveIdentity indicates an expected call of RemoveIdentity
+func (mr *MockCAClientMockRecorder) RemoveIdentity(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIdentity", reflect.TypeOf((*MockCAClient)(nil).RemoveIdentity), arg0)
+}
+
 // Revoke mocks base method
 func (m *MockCAClient) Revoke(arg0 *api.RevocationRequest) (*api.RevocationResponse, error) {
 	ret := m.ctrl.Call(m, "Revoke", arg0)
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"sync"
+
 	"github.com/golang/protobuf/proto"
 
 	"github.com/hyperledger/fabric-sdk-go/pkg/common/providers/core"
@@ -15,10 +17,13 @@ import (
 	"github.com/pkg/errors"
 )
 
-// User is a representation of a Fabric user
+// User is a representation of a Fabric user.
+// The enrollment certificate and private key of a user are swapped
+// when its certificate is renewed.
 type User struct {
 	id                    string
 	mspID                 string
+	mutex                 sync.RWMutex
 	enrollmentCertificate []byte
 	privateKey            core.Key
 }
@@ -41,7 +46,7 @@ func (u *User) Verify(msg []byte, sig []byte) error {
 func (u *User) Serialize() ([]byte, error) {
 	serializedIdentity := &pb_msp.SerializedIdentity{
 		Mspid:   u.mspID,
-		IdBytes: u.enrollmentCertificate,
+		IdBytes: u.EnrollmentCertificate(),
 	}
 	identity, err := proto.Marshal(serializedIdentity)
 	if err != nil {
@@ -52,14 +57,46 @@ func (u *User) Serialize() ([]byte, error) {
 
 // EnrollmentCertificate Returns the underlying ECert representing this user’s identity.
 func (u *User) EnrollmentCertificate() []byte {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
 	return u.enrollmentCertificate
 }
 
 // PrivateKey returns the crypto suite representation of the private key
 func (u *User) PrivateKey() core.Key {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
 	return u.privateKey
 }
 
+// Snapshot returns a copy of the user which holds its current enrollment certificate and private key
+// and isn't updated when they are renewed. It is used where the certificate and the key are read
+// separately, e.g. to serialize the creator of a request and then to sign it, so that they match.
+func (u *User) Snapshot() msp.SigningIdentity {
+	enrollmentCertificate, privateKey := u.credentials()
+	return &User{
+		id:                    u.id,
+		mspID:                 u.mspID,
+		enrollmentCertificate: enrollmentCertificate,
+		privateKey:            privateKey,
+	}
+}
+
+// credentials returns the enrollment certificate and private key of the user as one consistent pair
+func (u *User) credentials() ([]byte, core.Key) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return u.enrollmentCertificate, u.privateKey
+}
+
+// setCredentials atomically replaces the enrollment certificate and private key of the user
+func (u *User) setCredentials(enrollmentCertificate []byte, privateKey core.Key) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.enrollmentCertificate = enrollmentCertificate
+	u.privateKey = privateKey
+}
+
 // PublicVersion returns the public parts of this identity
 func (u *User) PublicVersion() msp.Identity {
 	return u
@@ -79,6 +79,19 @@ func TestUserMethods(t *testing.T) {
 	// Check PrivateKey
 	verifyBytes(t, user.PrivateKey().SKI(), generatedKey.SKI())
 
+	// A snapshot keeps the credentials of the user when they are renewed
+	snapshot := user.Snapshot()
+	renewedCert := []byte("renewed certificate")
+	user.setCredentials(renewedCert, nil)
+	verifyBytes(t, user.EnrollmentCertificate(), renewedCert)
+	if user.PrivateKey() != nil {
+		t.Fatal("Expected the private key of the user to be replaced")
+	}
+	verifyBytes(t, snapshot.EnrollmentCertificate(), generatedCertBytes)
+	verifyBytes(t, snapshot.PrivateKey().SKI(), generatedKey.SKI())
+	if *snapshot.Identifier() != *user.Identifier() {
+		t.Fatalf("Expected the snapshot to have the identifier of the user")
+	}
 }
 
 func verifyBytes(t *testing.T, v interface{}, expected []byte) error {
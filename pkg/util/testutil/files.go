@@ -0,0 +1,83 @@
+/*
+Copyright SecureKey Technologies Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// NewTestDir creates a temporary directory holding the given files, keyed by their slash-separated
+// path relative to the directory. The caller is responsible for removing the directory.
+func NewTestDir(t *testing.T, prefix string, files map[string]string) string {
+	dir, err := ioutil.TempDir("", prefix)
+	if err != nil {
+		t.Fatalf("creating temp dir failed: %s", err)
+	}
+	WriteFiles(t, dir, files)
+	return dir
+}
+
+// WriteFiles writes the given files, keyed by their slash-separated path relative to dir
+func WriteFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("creating dir failed: %s", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("writing file failed: %s", err)
+		}
+	}
+}
+
+// ReadTarball returns the content of the entries of the gzipped tarball, keyed by entry name
+func ReadTarball(t *testing.T, code []byte) map[string]string {
+	entries := make(map[string]string)
+	readTarball(t, code, func(name string, content []byte) {
+		entries[name] = string(content)
+	})
+	return entries
+}
+
+// TarballNames returns the names of the entries of the gzipped tarball, in the order of the tarball
+func TarballNames(t *testing.T, code []byte) []string {
+	var names []string
+	readTarball(t, code, func(name string, content []byte) {
+		names = append(names, name)
+	})
+	return names
+}
+
+func readTarball(t *testing.T, code []byte, handle func(name string, content []byte)) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		t.Fatalf("reading gzip failed: %s", err)
+	}
+	tr := tar.NewReader(gr)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatalf("reading tar failed: %s", err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("reading tar entry failed: %s", err)
+		}
+		handle(header.Name, content)
+	}
+}
@@ -34,6 +34,7 @@ declare -a FILES=(
     "lib/signer.go"
     "lib/clientconfig.go"
     "lib/util.go"
+    "lib/streamer.go"
     "lib/serverrevoke.go"
     "lib/sdkpatch_serverstruct.go"
 
@@ -73,8 +74,10 @@ FILTERS_ENABLED="fn"
 FILTER_FILENAME="lib/client.go"
 FILTER_FN="Enroll,GenCSR,SendReq,Init,newPost,newEnrollmentResponse,newCertificateRequest"
 FILTER_FN+=",getURL,NormalizeURL,initHTTPClient,net2LocalServerInfo,NewIdentity,newCfsslBasicKeyRequest"
+FILTER_FN+=",newGet,newPut,newDelete,StreamResponse,GetCAInfo"
 gofilter
 sed -i'' -e 's/util.GetServerPort()/\"\"/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
+sed -i'' -e 's/fmt.Println("No results returned")/log.Debugf("No results returned")/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
 sed -i'' -e '/log "github.com\// a\
 "github.com\/hyperledger\/fabric-sdk-go\/pkg\/common\/providers\/core"\
 ' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
@@ -89,6 +92,9 @@ done
 
 FILTER_FILENAME="lib/identity.go"
 FILTER_FN="newIdentity,Revoke,Post,addTokenAuthHdr,GetECert,Reenroll,Register,GetName"
+FILTER_FN+=",GetIdentity,GetAllIdentities,AddIdentity,ModifyIdentity,RemoveIdentity"
+FILTER_FN+=",GetAllAffiliations,GetAffiliation,AddAffiliation,ModifyAffiliation,RemoveAffiliation"
+FILTER_FN+=",Get,GetStreamResponse,Put,Delete"
 gofilter
 sed -i'' -e 's/util.GetDefaultBCCSP()/nil/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
 sed -i'' -e '/log "github.com\// a\
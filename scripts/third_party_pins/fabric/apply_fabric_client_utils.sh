@@ -33,6 +33,7 @@ declare -a PKGS=(
     "common/channelconfig"
     "common/attrmgr"
     "common/ledger"
+    "common/tools/configtxlator/update"
 
     "sdkpatch/logbridge"
     "sdkpatch/cryptosuitebridge"
@@ -105,6 +106,8 @@ declare -a FILES=(
     "common/channelconfig/organization.go"
 
     "common/ledger/ledger_interface.go"
+
+    "common/tools/configtxlator/update/update.go"
     
     "sdkpatch/logbridge/logbridge.go"
     "sdkpatch/cryptosuitebridge/cryptosuitebridge.go"
@@ -200,6 +203,11 @@ FILTER_FILENAME="common/channelconfig/organization.go"
 FILTER_FN=
 gofilter
 
+FILTER_FILENAME="common/tools/configtxlator/update/update.go"
+FILTER_FN="Compute,computePoliciesMapUpdate,computeValuesMapUpdate,computeGroupsMapUpdate,computeGroupUpdate"
+gofilter
+sed -i'' -e 's/cb.NewConfigGroup()/\&cb.ConfigGroup{}/g' "${TMP_PROJECT_PATH}/${FILTER_FILENAME}"
+
 FILTER_FILENAME="core/ledger/kvledger/txmgmt/version/version.go"
 FILTER_FN=
 gofilter
@@ -0,0 +1,60 @@
+#!/bin/bash
+#
+# Copyright SecureKey Technologies Inc. All Rights Reserved.
+#
+# SPDX-License-Identifier: Apache-2.0
+#
+
+# This script pins the discovery and gossip proto packages from Hyperledger Fabric into the SDK
+# These files are checked into third_party paths.
+# Note: This script must be adjusted as upstream makes adjustments
+
+set -e
+
+IMPORT_SUBSTS=($IMPORT_SUBSTS)
+
+NAMESPACE_PREFIX="sdk."
+
+declare -a PKGS=(
+    "protos/discovery"
+    "protos/gossip"
+)
+
+declare -a FILES=(
+    "protos/discovery/protocol.pb.go"
+    "protos/gossip/message.pb.go"
+)
+
+# Create directory structure for packages
+for i in "${PKGS[@]}"
+do
+    mkdir -p $INTERNAL_PATH/${i}
+done
+
+# Apply patching
+echo "Patching import paths on upstream project ..."
+WORKING_DIR=$TMP_PROJECT_PATH FILES="${FILES[@]}" IMPORT_SUBSTS="${IMPORT_SUBSTS[@]}" scripts/third_party_pins/common/apply_import_patching.sh
+
+echo "Inserting modification notice ..."
+WORKING_DIR=$TMP_PROJECT_PATH FILES="${FILES[@]}" ALLOW_NONE_LICENSE_ID="true" scripts/third_party_pins/common/apply_header_notice.sh
+
+echo "Changing proto registration paths to be unique"
+for i in "${FILES[@]}"
+do
+  if [[ ${i} == "protos/discovery"* ]]; then
+    sed -i'' -e "/proto.RegisterType/s/discovery/${NAMESPACE_PREFIX}discovery/g" "${TMP_PROJECT_PATH}/${i}"
+    sed -i'' -e "/proto.RegisterEnum/s/discovery/${NAMESPACE_PREFIX}discovery/g" "${TMP_PROJECT_PATH}/${i}"
+  fi
+  if [[ ${i} == "protos/gossip"* ]]; then
+    sed -i'' -e "/proto.RegisterType/s/gossip/${NAMESPACE_PREFIX}gossip/g" "${TMP_PROJECT_PATH}/${i}"
+    sed -i'' -e "/proto.RegisterEnum/s/gossip/${NAMESPACE_PREFIX}gossip/g" "${TMP_PROJECT_PATH}/${i}"
+  fi
+done
+
+# Copy patched project into internal paths
+echo "Copying patched upstream project into working directory ..."
+for i in "${FILES[@]}"
+do
+    TARGET_PATH=`dirname $INTERNAL_PATH/${i}`
+    cp $TMP_PROJECT_PATH/${i} $TARGET_PATH
+done
@@ -13,6 +13,7 @@ set -e
 
 UPSTREAM_PROJECT="github.com/hyperledger/fabric"
 UPSTREAM_BRANCH="${UPSTREAM_BRANCH:-release}"
+DISCOVERY_UPSTREAM_COMMIT="${DISCOVERY_UPSTREAM_COMMIT:-v1.2.0}"
 SCRIPTS_PATH="scripts/third_party_pins/fabric"
 PATCHES_PATH="${SCRIPTS_PATH}/patches"
 
@@ -95,6 +96,15 @@ declare -a PROTOS_IMPORT_SUBSTS=(
 )
 eval "INTERNAL_PATH=$THIRDPARTY_FABRIC_API_PATH TMP_PROJECT_PATH=$TMP_PROJECT_PATH IMPORT_SUBSTS=\"${PROTOS_IMPORT_SUBSTS[*]}\" $SCRIPTS_PATH/apply_fabric_protos.sh"
 
+# discovery protos
+# The discovery service was introduced in Fabric 1.2, so its protos (and the gossip protos they
+# depend on) are pinned from a later commit than the rest of the project
+echo "Pinning and patching discovery protos (third party) ($UPSTREAM_PROJECT:$DISCOVERY_UPSTREAM_COMMIT) ..."
+cd $TMP_PROJECT_PATH
+git checkout $DISCOVERY_UPSTREAM_COMMIT -- protos/discovery protos/gossip
+cd $CWD
+eval "INTERNAL_PATH=$THIRDPARTY_FABRIC_API_PATH TMP_PROJECT_PATH=$TMP_PROJECT_PATH IMPORT_SUBSTS=\"${PROTOS_IMPORT_SUBSTS[*]}\" $SCRIPTS_PATH/apply_fabric_discovery_protos.sh"
+
 # proto utils
 echo "Pinning and patching protos (internal) ..."
 declare -a PROTOS_INTERNAL_IMPORT_SUBSTS=(